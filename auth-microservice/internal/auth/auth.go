@@ -41,7 +41,7 @@ func New(q *queries.Queries, rdb *redis.Client, p *pgxpool.Pool, c config.Config
 
 func (a *Microservice) SignUp(ctx context.Context, request *gen.SignUpRequest) (*gen.JWTResponse, error) {
 	if len(request.Password) < 8 {
-		return nil, errs.BadRequest(errors.New("password must be at least 8 characters"))
+		return nil, errs.Validation([]errs.Violation{{Field: "password", Rule: "min", Message: "must be at least 8 characters"}})
 	}
 	password, err := bcrypt.GenerateFromPassword([]byte(request.Password), 12)
 	if err != nil {
@@ -2,10 +2,18 @@ package errs
 
 import (
 	"net/http"
+	"strings"
 
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/protoadapt"
 )
 
+// Domain is the domain of the google.rpc.ErrorInfo details of the errors, the main service
+// only trusts the reasons of this domain.
+const Domain = "auth.go-with-tools"
+
 func (e *AppError) HttpCode() int {
 	switch e.Code {
 	case UnauthorizedErrCode:
@@ -18,6 +26,8 @@ func (e *AppError) HttpCode() int {
 		return http.StatusConflict
 	case NotFoundErrCode:
 		return http.StatusNotFound
+	case ValidationErrCode:
+		return http.StatusBadRequest
 	default:
 		return http.StatusInternalServerError
 	}
@@ -35,7 +45,55 @@ func (e *AppError) GrpcCode() codes.Code {
 		return codes.AlreadyExists
 	case NotFoundErrCode:
 		return codes.NotFound
+	case ValidationErrCode:
+		return codes.InvalidArgument
 	default:
 		return codes.Internal
 	}
-}
\ No newline at end of file
+}
+
+// String is the machine-readable name of the code, the same the main service gives its codes.
+func (c Code) String() string {
+	switch c {
+	case UnauthorizedErrCode:
+		return "unauthorized"
+	case BadRequestErrCode:
+		return "bad_request"
+	case UnprocessableEntityErrCode:
+		return "unprocessable_entity"
+	case ConflictErrCode:
+		return "conflict"
+	case NotFoundErrCode:
+		return "not_found"
+	case ValidationErrCode:
+		return "validation_failed"
+	default:
+		return "internal_error"
+	}
+}
+
+// GrpcStatus is the status the error is sent with. Its details carry what the gRPC code loses:
+// the code of the error as the google.rpc.ErrorInfo reason, the id of the request and the violations.
+func (e *AppError) GrpcStatus(requestId string) *status.Status {
+	st := status.New(e.GrpcCode(), e.Error())
+	details := []protoadapt.MessageV1{
+		&errdetails.ErrorInfo{Reason: strings.ToUpper(e.Code.String()), Domain: Domain},
+		&errdetails.RequestInfo{RequestId: requestId},
+	}
+	if len(e.Violations) > 0 {
+		badRequest := &errdetails.BadRequest{}
+		for _, violation := range e.Violations {
+			badRequest.FieldViolations = append(badRequest.FieldViolations, &errdetails.BadRequest_FieldViolation{
+				Field:       violation.Field,
+				Reason:      violation.Rule,
+				Description: violation.Message,
+			})
+		}
+		details = append(details, badRequest)
+	}
+	withDetails, err := st.WithDetails(details...)
+	if err != nil {
+		return st
+	}
+	return withDetails
+}
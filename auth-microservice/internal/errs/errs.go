@@ -1,5 +1,10 @@
 package errs
 
+import (
+	"errors"
+	"strings"
+)
+
 type Code int
 
 const (
@@ -9,11 +14,21 @@ const (
 	UnprocessableEntityErrCode
 	ConflictErrCode
 	NotFoundErrCode
+	ValidationErrCode
 )
 
 type AppError struct {
 	Code Code
 	Err  error
+	// Violations are the invalid fields of a request that failed validation.
+	Violations []Violation
+}
+
+// Violation is a field of a request that broke a validation rule, Rule is the name of the rule, e.g. min.
+type Violation struct {
+	Field   string
+	Rule    string
+	Message string
 }
 
 func (e *AppError) Error() string {
@@ -68,3 +83,16 @@ func NotFound(err error) *AppError {
 		Err:  err,
 	}
 }
+
+// Validation is the error of a request with invalid fields, its message lists all of them.
+func Validation(violations []Violation) *AppError {
+	messages := make([]string, len(violations))
+	for i, violation := range violations {
+		messages[i] = violation.Field + " " + violation.Message
+	}
+	return &AppError{
+		Code:       ValidationErrCode,
+		Err:        errors.New(strings.Join(messages, "; ")),
+		Violations: violations,
+	}
+}
@@ -10,7 +10,6 @@ import (
 	"github.com/Aoladiy/go-with-tools-auth-microservice/internal/errs"
 	uuid2 "github.com/google/uuid"
 	"google.golang.org/grpc"
-	"google.golang.org/grpc/status"
 )
 
 func Logger() grpc.UnaryServerInterceptor {
@@ -30,18 +29,18 @@ func Logger() grpc.UnaryServerInterceptor {
 			e := errs.Internal(fmt.Errorf("error is not of type *errs.AppError: %w", err))
 			logError(uuid, info.FullMethod, e)
 			logDebug(uuid, info.FullMethod, finish)
-			return nil, respondError(e)
+			return nil, respondError(uuid, e)
 		}
 
 		if appErr.Code == errs.InternalErrCode {
 			logError(uuid, info.FullMethod, appErr.Err)
 			logDebug(uuid, info.FullMethod, finish)
-			return nil, respondError(appErr)
+			return nil, respondError(uuid, appErr)
 		}
 
 		logInfo(uuid, info.FullMethod, appErr.Err)
 		logDebug(uuid, info.FullMethod, finish)
-		return nil, respondError(appErr)
+		return nil, respondError(uuid, appErr)
 	}
 }
 
@@ -69,6 +68,6 @@ func logDebug(requestId, method string, duration time.Duration) {
 	)
 }
 
-func respondError(appErr *errs.AppError) error {
-	return status.Error(appErr.GrpcCode(), appErr.Error())
+func respondError(requestId string, appErr *errs.AppError) error {
+	return appErr.GrpcStatus(requestId).Err()
 }
@@ -16,7 +16,7 @@ import (
 	"github.com/Aoladiy/go-with-tools/internal/server"
 )
 
-func gracefulShutdown(apiServer *server.Server, done, kafkaDone chan bool, kafkaCancel context.CancelFunc) {
+func gracefulShutdown(apiServer *server.Server, done, kafkaDone, schedulerDone chan bool, kafkaCancel context.CancelFunc) {
 	// Create context that listens for the interrupt signal from the OS.
 	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
 	defer stop()
@@ -28,6 +28,7 @@ func gracefulShutdown(apiServer *server.Server, done, kafkaDone chan bool, kafka
 	stop() // Allow Ctrl+C to force shutdown
 	kafkaCancel()
 	<-kafkaDone
+	<-schedulerDone
 
 	// The context is used to inform the server it has 5 seconds to finish
 	// the request it is currently handling
@@ -66,11 +67,12 @@ func main() {
 	k := messaging.New(c)
 	ctx, kafkaCancel := context.WithCancel(context.Background())
 	kafkaDone := k.ReadMessages(ctx)
+	schedulerDone := newServer.RunPriceScheduler(ctx)
 	// Create a done channel to signal when the shutdown is complete
 	done := make(chan bool, 1)
 
 	// Run graceful shutdown in a separate goroutine
-	go gracefulShutdown(newServer, done, kafkaDone, kafkaCancel)
+	go gracefulShutdown(newServer, done, kafkaDone, schedulerDone, kafkaCancel)
 
 	newServer.Serve()
 
@@ -0,0 +1,64 @@
+package DTO
+
+import (
+	"bytes"
+	"encoding/json"
+)
+
+// Patch is a member of an RFC 7396 merge patch. Set is false when the member is absent and the
+// value has to be kept, Null is true when it is null and the value has to be removed.
+type Patch[T any] struct {
+	Set   bool
+	Null  bool
+	Value T
+}
+
+func (p *Patch[T]) UnmarshalJSON(data []byte) error {
+	p.Set = true
+	if bytes.Equal(data, []byte("null")) {
+		p.Null = true
+		return nil
+	}
+	return json.Unmarshal(data, &p.Value)
+}
+
+// Present returns the value of the member, nil when it is absent or null. Validation rules of the
+// member are checked against it, so they only apply to the values the patch writes.
+func (p Patch[T]) Present() *T {
+	if !p.Set || p.Null {
+		return nil
+	}
+	return &p.Value
+}
+
+// MoneyPatch is a merge patch of a money.Money, an absent member keeps its value.
+type MoneyPatch struct {
+	Amount   Patch[int64]  `json:"amount" binding:"omitnil,min=0"`
+	Currency Patch[string] `json:"currency" binding:"omitnil,currency"`
+}
+
+// ProductPatchRequest is a merge patch of a product. A null description empties it, a null is_active
+// makes the product active like on create and a null slug generates it from the name again.
+type ProductPatchRequest struct {
+	BrandId     Patch[int64]      `json:"brand_id" swaggertype:"integer" binding:"omitnil,gt=0"`
+	CategoryId  Patch[int64]      `json:"category_id" swaggertype:"integer" binding:"omitnil,gt=0"`
+	Name        Patch[string]     `json:"name" swaggertype:"string" binding:"omitnil,notblank,max=255"`
+	Slug        Patch[string]     `json:"slug" swaggertype:"string" binding:"omitempty,slug,max=255"`
+	Description Patch[string]     `json:"description" swaggertype:"string"`
+	Price       Patch[MoneyPatch] `json:"price" swaggertype:"object"`
+	IsActive    Patch[bool]       `json:"is_active" swaggertype:"boolean"`
+}
+
+// BrandPatchRequest is a merge patch of a brand, a null slug generates it from the name again.
+type BrandPatchRequest struct {
+	Name Patch[string] `json:"name" swaggertype:"string" binding:"omitnil,notblank,max=255"`
+	Slug Patch[string] `json:"slug" swaggertype:"string" binding:"omitempty,slug,max=255"`
+}
+
+// CategoryPatchRequest is a merge patch of a category. A null parent_id makes it a root category,
+// a null slug generates it from the name again.
+type CategoryPatchRequest struct {
+	Name     Patch[string] `json:"name" swaggertype:"string" binding:"omitnil,notblank,max=255"`
+	Slug     Patch[string] `json:"slug" swaggertype:"string" binding:"omitempty,slug,max=255"`
+	ParentId Patch[int64]  `json:"parent_id" swaggertype:"integer" binding:"omitnil,gt=0"`
+}
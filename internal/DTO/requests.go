@@ -1,41 +1,257 @@
 package DTO
 
+import (
+	"time"
+
+	"github.com/Aoladiy/go-with-tools/internal/money"
+)
+
 type BrandRequest struct {
-	Name string `json:"name"`
-	Slug string `json:"slug"`
+	Name string `json:"name" binding:"required,notblank,max=255"`
+	Slug string `json:"slug" binding:"omitempty,slug,max=255"`
 }
 
 type CategoryRequest struct {
-	Name     string `json:"name"`
-	Slug     string `json:"slug"`
-	ParentId *int64 `json:"parent_id,omitempty"`
+	Name     string `json:"name" binding:"required,notblank,max=255"`
+	Slug     string `json:"slug" binding:"omitempty,slug,max=255"`
+	ParentId *int64 `json:"parent_id,omitempty" binding:"omitnil,gt=0"`
+}
+
+type CategoryMoveRequest struct {
+	ParentId *int64 `json:"parent_id" binding:"omitnil,gt=0"`
 }
 
 type ProductRequest struct {
-	BrandId     int64   `json:"brand_id"`
-	CategoryId  int64   `json:"category_id"`
-	Name        string  `json:"name"`
-	Slug        string  `json:"slug"`
-	Description *string `json:"description,omitempty"`
-	PriceKopeck int32   `json:"price_kopeck"`
-	IsActive    *bool   `json:"is_active,omitempty"`
+	BrandId     int64       `json:"brand_id" binding:"required,gt=0"`
+	CategoryId  int64       `json:"category_id" binding:"required,gt=0"`
+	Name        string      `json:"name" binding:"required,notblank,max=255"`
+	Slug        string      `json:"slug" binding:"omitempty,slug,max=255"`
+	Description *string     `json:"description,omitempty"`
+	Price       money.Money `json:"price"`
+	IsActive    *bool       `json:"is_active,omitempty"`
+}
+
+type VariantRequest struct {
+	Sku      string            `json:"sku" binding:"required,notblank,max=255"`
+	Barcode  *string           `json:"barcode,omitempty" binding:"omitnil,notblank,max=255"`
+	Price    *money.Money      `json:"price,omitempty"`
+	IsActive *bool             `json:"is_active,omitempty"`
+	Options  map[string]string `json:"options" binding:"dive,keys,notblank,endkeys,notblank"`
+}
+
+// PriceScheduleRequest targets exactly one of a product, a brand or a category with its subtree.
+type PriceScheduleRequest struct {
+	ProductId  *int64      `json:"product_id,omitempty" binding:"omitnil,gt=0"`
+	BrandId    *int64      `json:"brand_id,omitempty" binding:"omitnil,gt=0"`
+	CategoryId *int64      `json:"category_id,omitempty" binding:"omitnil,gt=0"`
+	NewPrice   money.Money `json:"new_price"`
+	StartsAt   time.Time   `json:"starts_at" binding:"required"`
+	EndsAt     *time.Time  `json:"ends_at,omitempty"`
+}
+
+// PromotionRequest targets exactly one of a product, a brand or a category with its subtree.
+// Value is a percent (1-100) for the percent type and minor units of Currency for the fixed one,
+// which only applies to products priced in that currency.
+type PromotionRequest struct {
+	Name        string     `json:"name" binding:"required,notblank,max=255"`
+	Type        string     `json:"type" binding:"required,oneof=percent fixed"`
+	Value       int64      `json:"value" binding:"required,gt=0"`
+	Currency    *string    `json:"currency,omitempty" binding:"omitnil,currency"`
+	ProductId   *int64     `json:"product_id,omitempty" binding:"omitnil,gt=0"`
+	BrandId     *int64     `json:"brand_id,omitempty" binding:"omitnil,gt=0"`
+	CategoryId  *int64     `json:"category_id,omitempty" binding:"omitnil,gt=0"`
+	StartsAt    time.Time  `json:"starts_at" binding:"required"`
+	EndsAt      *time.Time `json:"ends_at,omitempty"`
+	Priority    int32      `json:"priority"`
+	IsStackable *bool      `json:"is_stackable,omitempty"`
+	IsActive    *bool      `json:"is_active,omitempty"`
+}
+
+type MediaOrderRequest struct {
+	Ids []int64 `json:"ids" binding:"required,unique,dive,gt=0"`
+}
+
+type AttributeDefinitionRequest struct {
+	Code         string   `json:"code" binding:"required,attribute_code,max=255"`
+	Name         string   `json:"name" binding:"required,notblank,max=255"`
+	Type         string   `json:"type" enums:"string,number,boolean,enum" binding:"required,oneof=string number boolean enum"`
+	Unit         *string  `json:"unit,omitempty" binding:"omitnil,notblank"`
+	EnumValues   []string `json:"enum_values,omitempty" binding:"unique,dive,notblank"`
+	IsRequired   *bool    `json:"is_required,omitempty"`
+	IsFilterable *bool    `json:"is_filterable,omitempty"`
+}
+
+// ProductAttributesRequest holds attribute values keyed by attribute code. The JSON type of
+// a value must match the attribute type; enum values are strings.
+type ProductAttributesRequest struct {
+	Values map[string]any `json:"values" binding:"required,dive,keys,notblank,endkeys"`
+}
+
+type InventoryMovementRequest struct {
+	ProductId   int64  `json:"product_id" binding:"required,gt=0"`
+	VariantId   *int64 `json:"variant_id,omitempty" binding:"omitnil,gt=0"`
+	Delta       int32  `json:"delta" binding:"required"`
+	Description string `json:"description" binding:"required,notblank"`
+}
+
+type PageRequest struct {
+	Limit  int32  `form:"limit" binding:"omitempty,min=1,max=100"`
+	Cursor string `form:"cursor"`
+	SortBy string `form:"sort_by"`
+	Order  string `form:"order" binding:"omitempty,oneof=asc desc"`
+}
+
+type BrandListRequest struct {
+	PageRequest
+	CreatedFrom *time.Time `form:"created_from"`
+	CreatedTo   *time.Time `form:"created_to"`
+	UpdatedFrom *time.Time `form:"updated_from"`
+	UpdatedTo   *time.Time `form:"updated_to"`
+}
+
+type PriceScheduleListRequest struct {
+	PageRequest
+	Status     *string `form:"status" binding:"omitnil,oneof=pending applied completed failed"`
+	ProductId  *int64  `form:"product_id" binding:"omitnil,gt=0"`
+	BrandId    *int64  `form:"brand_id" binding:"omitnil,gt=0"`
+	CategoryId *int64  `form:"category_id" binding:"omitnil,gt=0"`
+}
+
+// PriceHistoryListRequest filters price changes. ProductId and UpdatedBy only apply to the recent changes feed,
+// the product and variant history take them from the path.
+type PriceHistoryListRequest struct {
+	PageRequest
+	ProductId   *int64     `form:"product_id" binding:"omitnil,gt=0"`
+	UpdatedBy   *int64     `form:"updated_by" binding:"omitnil,gt=0"`
+	CreatedFrom *time.Time `form:"created_from"`
+	CreatedTo   *time.Time `form:"created_to"`
+}
+
+type PromotionListRequest struct {
+	PageRequest
+	IsActive   *bool  `form:"is_active"`
+	ProductId  *int64 `form:"product_id" binding:"omitnil,gt=0"`
+	BrandId    *int64 `form:"brand_id" binding:"omitnil,gt=0"`
+	CategoryId *int64 `form:"category_id" binding:"omitnil,gt=0"`
+}
+
+type CategoryListRequest struct {
+	PageRequest
+	ParentId    *int64     `form:"parent_id" binding:"omitnil,gt=0"`
+	CreatedFrom *time.Time `form:"created_from"`
+	CreatedTo   *time.Time `form:"created_to"`
+	UpdatedFrom *time.Time `form:"updated_from"`
+	UpdatedTo   *time.Time `form:"updated_to"`
+}
+
+type ProductListRequest struct {
+	PageRequest
+	BrandId     *int64     `form:"brand_id" binding:"omitnil,gt=0"`
+	CategoryId  *int64     `form:"category_id" binding:"omitnil,gt=0"`
+	IsActive    *bool      `form:"is_active"`
+	PriceMin    *int64     `form:"price_min" binding:"omitnil,min=0"`
+	PriceMax    *int64     `form:"price_max" binding:"omitnil,min=0"`
+	CreatedFrom *time.Time `form:"created_from"`
+	CreatedTo   *time.Time `form:"created_to"`
+	UpdatedFrom *time.Time `form:"updated_from"`
+	UpdatedTo   *time.Time `form:"updated_to"`
+}
+
+// FrontProductListRequest filters storefront products. Attributes is filled from attr[code]
+// query params: a comma separated list of values or a number range like 8..16 with either
+// bound optional.
+type FrontProductListRequest struct {
+	PageRequest
+	PriceListQuery
+	CategoryId *int64            `form:"category_id" binding:"omitnil,gt=0"`
+	BrandIds   []int64           `form:"brand_id" binding:"dive,gt=0"`
+	Attributes map[string]string `form:"-"`
+}
+
+type FrontCategoryProductListRequest struct {
+	PageRequest
+	PriceListQuery
+}
+
+type ProductSearchRequest struct {
+	PageRequest
+	Query    string `form:"q" binding:"max=255"`
+	IsActive *bool  `form:"is_active"`
+}
+
+type FrontProductSearchRequest struct {
+	ProductSearchRequest
+	PriceListQuery
+}
+
+// PriceListQuery selects the prices the storefront shows. PriceList is the code of an active price list,
+// Currency alone picks the active list of the currency without a customer group or, if there is none,
+// converts the base prices by the exchange rates.
+type PriceListQuery struct {
+	Currency  *string `form:"currency" binding:"omitnil,currency"`
+	PriceList *string `form:"price_list" binding:"omitnil,notblank"`
+}
+
+// PriceListRequest describes a named set of prices in one currency. Products without an explicit
+// price in the list get their base price converted by the exchange rates and rounded to a multiple
+// of RoundingStep minor units (1 by default) with RoundingMode: half_up (default), up or down.
+type PriceListRequest struct {
+	Code          string  `json:"code" binding:"required,notblank,max=255"`
+	Name          string  `json:"name" binding:"required,notblank,max=255"`
+	Currency      string  `json:"currency" binding:"required,currency"`
+	CustomerGroup *string `json:"customer_group,omitempty" binding:"omitnil,notblank,max=255"`
+	RoundingStep  *int64  `json:"rounding_step,omitempty" binding:"omitnil,min=1"`
+	RoundingMode  *string `json:"rounding_mode,omitempty" binding:"omitnil,oneof=half_up up down"`
+	IsActive      *bool   `json:"is_active,omitempty"`
+}
+
+type PriceListListRequest struct {
+	PageRequest
+	Currency      *string `form:"currency" binding:"omitnil,currency"`
+	CustomerGroup *string `form:"customer_group" binding:"omitnil,notblank"`
+	IsActive      *bool   `form:"is_active"`
+}
+
+type PriceListItemRequest struct {
+	Price money.Money `json:"price"`
+}
+
+// ExchangeRateRequest sets how many units of QuoteCurrency one unit of BaseCurrency costs.
+// Rate is a decimal string with up to 10 digits before and after the point.
+type ExchangeRateRequest struct {
+	BaseCurrency  string `json:"base_currency" example:"EUR" binding:"required,currency"`
+	QuoteCurrency string `json:"quote_currency" example:"RUB" binding:"required,currency"`
+	Rate          string `json:"rate" example:"92.4512" binding:"required"`
+}
+
+type ExportRequest struct {
+	Format string `form:"format" binding:"omitempty,oneof=csv jsonl xlsx"`
+}
+
+type ProductImportRequest struct {
+	DryRun bool `form:"dry_run"`
+}
+
+type ProductSuggestRequest struct {
+	Query string `form:"q" binding:"required,max=255"`
+	Limit int32  `form:"limit" binding:"omitempty,min=1,max=100"`
 }
 
 type SignUpRequest struct {
-	Email    string `json:"email"`
-	Password string `json:"password"`
+	Email    string `json:"email" binding:"required,email"`
+	Password string `json:"password" binding:"required,min=8"`
 }
 
 type SignInRequest struct {
-	Email    string `json:"email"`
-	Password string `json:"password"`
+	Email    string `json:"email" binding:"required,email"`
+	Password string `json:"password" binding:"required"`
 }
 
 type TokenRefreshRequest struct {
-	RefreshToken string `json:"refresh_token"`
+	RefreshToken string `json:"refresh_token" binding:"required"`
 }
 
 type SignOutRequest struct {
-	AccessToken  string `json:"access_token"`
-	RefreshToken string `json:"refresh_token"`
+	AccessToken  string `json:"access_token" binding:"required"`
+	RefreshToken string `json:"refresh_token" binding:"required"`
 }
@@ -1,13 +1,20 @@
 package DTO
 
-import "time"
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/Aoladiy/go-with-tools/internal/money"
+)
 
 type BrandResponse struct {
-	Id        int64     `json:"id"`
-	Name      string    `json:"name"`
-	Slug      string    `json:"slug"`
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
+	Id        int64           `json:"id"`
+	Name      string          `json:"name"`
+	Slug      string          `json:"slug"`
+	Media     []MediaResponse `json:"media"`
+	CreatedAt time.Time       `json:"created_at"`
+	UpdatedAt time.Time       `json:"updated_at"`
+	Version   int64           `json:"version"`
 }
 
 type CategoryResponse struct {
@@ -17,25 +24,483 @@ type CategoryResponse struct {
 	ParentId  *int64    `json:"parent_id"`
 	CreatedAt time.Time `json:"created_at"`
 	UpdatedAt time.Time `json:"updated_at"`
+	Version   int64     `json:"version"`
 }
 
 type ProductResponse struct {
+	Id          int64           `json:"id"`
+	BrandId     int64           `json:"brand_id"`
+	CategoryId  int64           `json:"category_id"`
+	Name        string          `json:"name"`
+	Slug        string          `json:"slug"`
+	Description string          `json:"description"`
+	Price       money.Money     `json:"price"`
+	IsActive    bool            `json:"is_active"`
+	Media       []MediaResponse `json:"media"`
+	CreatedAt   time.Time       `json:"created_at"`
+	UpdatedAt   time.Time       `json:"updated_at"`
+	Version     int64           `json:"version"`
+}
+
+type MediaResponse struct {
+	Id          int64             `json:"id"`
+	Url         string            `json:"url"`
+	Thumbnails  map[string]string `json:"thumbnails"`
+	ContentType string            `json:"content_type"`
+	SizeBytes   int64             `json:"size_bytes"`
+	Width       int32             `json:"width"`
+	Height      int32             `json:"height"`
+	Position    int32             `json:"position"`
+	IsPrimary   bool              `json:"is_primary"`
+	CreatedAt   time.Time         `json:"created_at"`
+}
+
+type PriceScheduleResponse struct {
+	Id           int64       `json:"id"`
+	ProductId    *int64      `json:"product_id"`
+	BrandId      *int64      `json:"brand_id"`
+	CategoryId   *int64      `json:"category_id"`
+	NewPrice     money.Money `json:"new_price"`
+	StartsAt     time.Time   `json:"starts_at"`
+	EndsAt       *time.Time  `json:"ends_at"`
+	Status       string      `json:"status"`
+	FailedStatus *string     `json:"failed_status"`
+	AppliedAt    *time.Time  `json:"applied_at"`
+	RevertedAt   *time.Time  `json:"reverted_at"`
+	Attempts     int32       `json:"attempts"`
+	LastError    *string     `json:"last_error"`
+	RetryAt      *time.Time  `json:"retry_at"`
+	CreatedBy    int64       `json:"created_by"`
+	CreatedAt    time.Time   `json:"created_at"`
+	UpdatedAt    time.Time   `json:"updated_at"`
+}
+
+type PriceHistoryResponse struct {
+	Id             int64       `json:"id"`
+	ProductId      int64       `json:"product_id"`
+	ProductName    string      `json:"product_name"`
+	VariantId      *int64      `json:"variant_id"`
+	OldPrice       money.Money `json:"old_price"`
+	NewPrice       money.Money `json:"new_price"`
+	UpdatedBy      int64       `json:"updated_by"`
+	UpdatedByEmail string      `json:"updated_by_email"`
+	CreatedAt      time.Time   `json:"created_at"`
+}
+
+type PromotionResponse struct {
+	Id          int64      `json:"id"`
+	Name        string     `json:"name"`
+	Type        string     `json:"type"`
+	Value       int64      `json:"value"`
+	Currency    *string    `json:"currency"`
+	ProductId   *int64     `json:"product_id"`
+	BrandId     *int64     `json:"brand_id"`
+	CategoryId  *int64     `json:"category_id"`
+	StartsAt    time.Time  `json:"starts_at"`
+	EndsAt      *time.Time `json:"ends_at"`
+	Priority    int32      `json:"priority"`
+	IsStackable bool       `json:"is_stackable"`
+	IsActive    bool       `json:"is_active"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+}
+
+type AppliedPromotionResponse struct {
+	Id       int64       `json:"id"`
+	Name     string      `json:"name"`
+	Type     string      `json:"type"`
+	Value    int64       `json:"value"`
+	Discount money.Money `json:"discount"`
+}
+
+// ResolvedPriceResponse is a base price with the promotions in effect applied to it.
+type ResolvedPriceResponse struct {
+	BasePrice         money.Money                `json:"base_price"`
+	EffectivePrice    money.Money                `json:"effective_price"`
+	AppliedPromotions []AppliedPromotionResponse `json:"applied_promotions"`
+}
+
+type PriceListResponse struct {
+	Id            int64     `json:"id"`
+	Code          string    `json:"code"`
+	Name          string    `json:"name"`
+	Currency      string    `json:"currency"`
+	CustomerGroup *string   `json:"customer_group"`
+	RoundingStep  int64     `json:"rounding_step"`
+	RoundingMode  string    `json:"rounding_mode"`
+	IsActive      bool      `json:"is_active"`
+	CreatedAt     time.Time `json:"created_at"`
+	UpdatedAt     time.Time `json:"updated_at"`
+}
+
+type PriceListItemResponse struct {
+	ProductId   int64       `json:"product_id"`
+	ProductName string      `json:"product_name"`
+	Price       money.Money `json:"price"`
+	CreatedAt   time.Time   `json:"created_at"`
+	UpdatedAt   time.Time   `json:"updated_at"`
+}
+
+type ExchangeRateResponse struct {
+	Id            int64     `json:"id"`
+	BaseCurrency  string    `json:"base_currency"`
+	QuoteCurrency string    `json:"quote_currency"`
+	Rate          string    `json:"rate"`
+	CreatedAt     time.Time `json:"created_at"`
+	UpdatedAt     time.Time `json:"updated_at"`
+}
+
+type ExchangeRateImportResponse struct {
+	Imported int `json:"imported"`
+}
+
+// SlugResponse is the record a storefront slug leads to, slug is the current one.
+type SlugResponse struct {
+	Kind string `json:"kind"`
+	Id   int64  `json:"id"`
+	Slug string `json:"slug"`
+}
+
+type FrontBrandResponse struct {
+	Id   int64  `json:"id"`
+	Name string `json:"name"`
+	Slug string `json:"slug"`
+}
+
+// FrontBrandDetailResponse is the storefront page of a brand.
+type FrontBrandDetailResponse struct {
+	Id    int64           `json:"id"`
+	Name  string          `json:"name"`
+	Slug  string          `json:"slug"`
+	Media []MediaResponse `json:"media"`
+}
+
+type FrontCategoryResponse struct {
+	Id       int64  `json:"id"`
+	Name     string `json:"name"`
+	Slug     string `json:"slug"`
+	ParentId *int64 `json:"parent_id"`
+}
+
+// FrontCategoryDetailResponse is the storefront page of a category, breadcrumbs go from the root
+// and end with the category itself.
+type FrontCategoryDetailResponse struct {
+	Id          int64                   `json:"id"`
+	Name        string                  `json:"name"`
+	Slug        string                  `json:"slug"`
+	ParentId    *int64                  `json:"parent_id"`
+	Breadcrumbs []FrontCategoryResponse `json:"breadcrumbs"`
+}
+
+type CategoryTreeResponse struct {
+	Id       int64                  `json:"id"`
+	Name     string                 `json:"name"`
+	Slug     string                 `json:"slug"`
+	Children []CategoryTreeResponse `json:"children"`
+}
+
+type FrontProductResponse struct {
+	Id                int64                      `json:"id"`
+	BrandId           int64                      `json:"brand_id"`
+	BrandName         string                     `json:"brand_name"`
+	CategoryId        int64                      `json:"category_id"`
+	CategoryName      string                     `json:"category_name"`
+	Name              string                     `json:"name"`
+	Slug              string                     `json:"slug"`
+	Description       string                     `json:"description"`
+	Price             money.Money                `json:"price"`
+	EffectivePrice    money.Money                `json:"effective_price"`
+	AppliedPromotions []AppliedPromotionResponse `json:"applied_promotions"`
+}
+
+// FrontProductDetailResponse is the storefront page of a product with its brand, category and
+// the breadcrumbs of the category from the root.
+type FrontProductDetailResponse struct {
+	FrontProductResponse
+	Media       []MediaResponse         `json:"media"`
+	Brand       FrontBrandResponse      `json:"brand"`
+	Category    FrontCategoryResponse   `json:"category"`
+	Breadcrumbs []FrontCategoryResponse `json:"breadcrumbs"`
+}
+
+type VariantResponse struct {
+	Id             int64             `json:"id"`
+	ProductId      int64             `json:"product_id"`
+	Sku            string            `json:"sku"`
+	Barcode        *string           `json:"barcode"`
+	Price          *money.Money      `json:"price"`
+	EffectivePrice money.Money       `json:"effective_price"`
+	IsActive       bool              `json:"is_active"`
+	Stock          int64             `json:"stock"`
+	Options        map[string]string `json:"options"`
+	CreatedAt      time.Time         `json:"created_at"`
+	UpdatedAt      time.Time         `json:"updated_at"`
+}
+
+type FrontOptionResponse struct {
+	Name   string   `json:"name"`
+	Values []string `json:"values"`
+}
+
+type FrontVariantResponse struct {
+	Id                int64                      `json:"id"`
+	Sku               string                     `json:"sku"`
+	Price             money.Money                `json:"price"`
+	InStock           bool                       `json:"in_stock"`
+	Options           map[string]string          `json:"options"`
+	EffectivePrice    money.Money                `json:"effective_price"`
+	AppliedPromotions []AppliedPromotionResponse `json:"applied_promotions"`
+}
+
+type FrontVariantMatrixResponse struct {
+	ProductId int64                  `json:"product_id"`
+	Options   []FrontOptionResponse  `json:"options"`
+	Variants  []FrontVariantResponse `json:"variants"`
+}
+
+type AttributeDefinitionResponse struct {
+	Id           int64     `json:"id"`
+	CategoryId   int64     `json:"category_id"`
+	Code         string    `json:"code"`
+	Name         string    `json:"name"`
+	Type         string    `json:"type"`
+	Unit         *string   `json:"unit"`
+	EnumValues   []string  `json:"enum_values"`
+	IsRequired   bool      `json:"is_required"`
+	IsFilterable bool      `json:"is_filterable"`
+	CreatedAt    time.Time `json:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
+
+type ProductAttributeValueResponse struct {
+	AttributeId int64   `json:"attribute_id"`
+	Code        string  `json:"code"`
+	Name        string  `json:"name"`
+	Type        string  `json:"type"`
+	Unit        *string `json:"unit"`
+	Value       any     `json:"value"`
+}
+
+type FrontBrandFacetResponse struct {
+	Id    int64  `json:"id"`
+	Name  string `json:"name"`
+	Count int64  `json:"count"`
+}
+
+type FrontFacetValueResponse struct {
+	Value string `json:"value"`
+	Count int64  `json:"count"`
+}
+
+type FrontAttributeFacetResponse struct {
+	Id     int64                     `json:"id"`
+	Code   string                    `json:"code"`
+	Name   string                    `json:"name"`
+	Type   string                    `json:"type"`
+	Unit   *string                   `json:"unit"`
+	Min    *float64                  `json:"min,omitempty"`
+	Max    *float64                  `json:"max,omitempty"`
+	Values []FrontFacetValueResponse `json:"values"`
+}
+
+type FrontFacetsResponse struct {
+	Brands     []FrontBrandFacetResponse     `json:"brands"`
+	Attributes []FrontAttributeFacetResponse `json:"attributes"`
+}
+
+type InventoryMovementResponse struct {
 	Id          int64     `json:"id"`
-	BrandId     int64     `json:"brand_id"`
-	CategoryId  int64     `json:"category_id"`
-	Name        string    `json:"name"`
-	Slug        string    `json:"slug"`
+	ProductId   int64     `json:"product_id"`
+	VariantId   *int64    `json:"variant_id"`
+	Delta       int32     `json:"delta"`
 	Description string    `json:"description"`
-	PriceKopeck int32     `json:"price_kopeck"`
-	IsActive    bool      `json:"is_active"`
 	CreatedAt   time.Time `json:"created_at"`
-	UpdatedAt   time.Time `json:"updated_at"`
 }
 
-type ErrorResponse struct {
+type StockResponse struct {
+	ProductId int64 `json:"product_id"`
+	Quantity  int64 `json:"quantity"`
+}
+
+type ProductSuggestionResponse struct {
+	Id   int64  `json:"id"`
+	Name string `json:"name"`
+	Slug string `json:"slug"`
+}
+
+type TrashedBrandResponse struct {
+	BrandResponse
+	DeletedAt time.Time `json:"deleted_at"`
+}
+
+type TrashedCategoryResponse struct {
+	CategoryResponse
+	DeletedAt time.Time `json:"deleted_at"`
+}
+
+type TrashedProductResponse struct {
+	ProductResponse
+	DeletedAt time.Time `json:"deleted_at"`
+}
+
+type BrandRestoreResponse struct {
+	Brand            BrandResponse `json:"brand"`
+	RestoredProducts int64         `json:"restored_products"`
+}
+
+type CategoryRestoreResponse struct {
+	Category           CategoryResponse `json:"category"`
+	RestoredCategories int64            `json:"restored_categories"`
+	RestoredProducts   int64            `json:"restored_products"`
+}
+
+// ProductImportResponse counts what the import did, or would do in a dry run. Nothing is
+// imported if there are errors.
+type ProductImportResponse struct {
+	DryRun    bool                         `json:"dry_run"`
+	Total     int                          `json:"total"`
+	Created   int                          `json:"created"`
+	Updated   int                          `json:"updated"`
+	Unchanged int                          `json:"unchanged"`
+	Errors    []ProductImportErrorResponse `json:"errors"`
+}
+
+type ProductImportErrorResponse struct {
+	Line  int    `json:"line"`
+	Slug  string `json:"slug,omitempty"`
 	Error string `json:"error"`
 }
 
+type PurgeResponse struct {
+	DeletedBefore      time.Time `json:"deleted_before"`
+	Brands             int64     `json:"brands"`
+	Categories         int64     `json:"categories"`
+	Products           int64     `json:"products"`
+	Variants           int64     `json:"variants"`
+	InventoryMovements int64     `json:"inventory_movements"`
+	PriceHistory       int64     `json:"price_history"`
+	Media              int64     `json:"media"`
+}
+
+type PageInfo struct {
+	Total      int64   `json:"total"`
+	NextCursor *string `json:"next_cursor"`
+}
+
+type PriceSchedulePageResponse struct {
+	Items []PriceScheduleResponse `json:"items"`
+	PageInfo
+}
+
+type PriceHistoryPageResponse struct {
+	Items []PriceHistoryResponse `json:"items"`
+	PageInfo
+}
+
+type PriceListPageResponse struct {
+	Items []PriceListResponse `json:"items"`
+	PageInfo
+}
+
+type PriceListItemPageResponse struct {
+	Items []PriceListItemResponse `json:"items"`
+	PageInfo
+}
+
+type PromotionPageResponse struct {
+	Items []PromotionResponse `json:"items"`
+	PageInfo
+}
+
+type BrandPageResponse struct {
+	Items []BrandResponse `json:"items"`
+	PageInfo
+}
+
+type CategoryPageResponse struct {
+	Items []CategoryResponse `json:"items"`
+	PageInfo
+}
+
+type ProductPageResponse struct {
+	Items []ProductResponse `json:"items"`
+	PageInfo
+}
+
+type FrontProductPageResponse struct {
+	Items []FrontProductResponse `json:"items"`
+	PageInfo
+}
+
+type FrontProductFacetedPageResponse struct {
+	Items  []FrontProductResponse `json:"items"`
+	Facets FrontFacetsResponse    `json:"facets"`
+	PageInfo
+}
+
+type InventoryMovementPageResponse struct {
+	Items []InventoryMovementResponse `json:"items"`
+	PageInfo
+}
+
+type TrashedBrandPageResponse struct {
+	Items []TrashedBrandResponse `json:"items"`
+	PageInfo
+}
+
+type TrashedCategoryPageResponse struct {
+	Items []TrashedCategoryResponse `json:"items"`
+	PageInfo
+}
+
+type TrashedProductPageResponse struct {
+	Items []TrashedProductResponse `json:"items"`
+	PageInfo
+}
+
+// ErrorResponse is the body of every error, an RFC 7807 problem sent as application/problem+json.
+// Code and Type tell the kinds of problems apart, Violations lists the invalid fields of a request
+// that failed validation and Extensions are the other members some problems have.
+type ErrorResponse struct {
+	Type       string              `json:"type" example:"urn:go-with-tools:problem:validation-failed"`
+	Title      string              `json:"title" example:"Validation failed"`
+	Status     int                 `json:"status" example:"400"`
+	Detail     string              `json:"detail" example:"name is required"`
+	Instance   string              `json:"instance" example:"/api/v1/admin/brands"`
+	RequestId  string              `json:"request_id,omitempty" example:"0b5c4c2e-8a4f-4c3e-9d7a-2f1e6b9c1d3a"`
+	Code       string              `json:"code" enums:"bad_request,validation_failed,unauthorized,not_found,conflict,precondition_failed,unprocessable_entity,internal_error,service_unavailable,gateway_timeout" example:"validation_failed"`
+	Violations []ViolationResponse `json:"violations,omitempty"`
+	Extensions map[string]any      `json:"-"`
+}
+
+// MarshalJSON puts the extensions next to the standard members, which they can't replace.
+func (r ErrorResponse) MarshalJSON() ([]byte, error) {
+	type problem ErrorResponse
+	data, err := json.Marshal(problem(r))
+	if err != nil || len(r.Extensions) == 0 {
+		return data, err
+	}
+	members := make(map[string]any, len(r.Extensions))
+	for key, value := range r.Extensions {
+		members[key] = value
+	}
+	var standard map[string]json.RawMessage
+	err = json.Unmarshal(data, &standard)
+	if err != nil {
+		return nil, err
+	}
+	for key, value := range standard {
+		members[key] = value
+	}
+	return json.Marshal(members)
+}
+
+type ViolationResponse struct {
+	Field   string `json:"field" example:"price.amount"`
+	Rule    string `json:"rule" example:"min"`
+	Message string `json:"message" example:"must be at least 0"`
+}
+
 type JWTResponse struct {
 	AccessToken  string `json:"access_token"`
 	RefreshToken string `json:"refresh_token"`
@@ -0,0 +1,352 @@
+package attribute
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/Aoladiy/go-with-tools/internal/DTO"
+	"github.com/Aoladiy/go-with-tools/internal/database/queries"
+	"github.com/Aoladiy/go-with-tools/internal/errs"
+	"github.com/Aoladiy/go-with-tools/internal/helpers"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const (
+	TypeString  = "string"
+	TypeNumber  = "number"
+	TypeBoolean = "boolean"
+	TypeEnum    = "enum"
+)
+
+var codeRegexp = regexp.MustCompile(`^[a-z][a-z0-9_]*$`)
+
+type Service struct {
+	q *queries.Queries
+	p *pgxpool.Pool
+}
+
+func New(q *queries.Queries, p *pgxpool.Pool) *Service {
+	return &Service{q: q, p: p}
+}
+
+// GetAll returns attribute definitions that apply to the category: its own and the ones
+// inherited from its ancestors.
+func (s *Service) GetAll(ctx context.Context, categoryId int64) ([]DTO.AttributeDefinitionResponse, *errs.AppError) {
+	_, err := s.q.GetCategory(ctx, categoryId)
+	if err != nil {
+		return nil, errs.FromPgErr(err)
+	}
+
+	definitions, err := s.q.GetCategoryAttributeDefinitions(ctx, categoryId)
+	if err != nil {
+		return nil, errs.Internal(err)
+	}
+
+	definitionsResponse := make([]DTO.AttributeDefinitionResponse, len(definitions))
+	for i, definition := range definitions {
+		definitionsResponse[i] = mapDefinitionToResponse(definition)
+	}
+	return definitionsResponse, nil
+}
+
+func (s *Service) Create(ctx context.Context, categoryId int64, request DTO.AttributeDefinitionRequest) (DTO.AttributeDefinitionResponse, *errs.AppError) {
+	request, appErr := normalizeDefinitionRequest(request)
+	if appErr != nil {
+		return DTO.AttributeDefinitionResponse{}, appErr
+	}
+
+	var definition queries.AttributeDefinition
+	appErr = helpers.WithTx(ctx, s.p, s.q, func(timeout context.Context, q *queries.Queries) *errs.AppError {
+		_, err := q.GetCategory(timeout, categoryId)
+		if err != nil {
+			return errs.FromPgErr(err)
+		}
+		appErr := checkCodeConflicts(timeout, q, categoryId, 0, request.Code)
+		if appErr != nil {
+			return appErr
+		}
+
+		definition, err = q.CreateAttributeDefinition(timeout, mapRequestToCreateParams(categoryId, request))
+		if err != nil {
+			return errs.FromPgErr(err)
+		}
+		return nil
+	})
+	if appErr != nil {
+		return DTO.AttributeDefinitionResponse{}, appErr
+	}
+
+	return mapDefinitionToResponse(definition), nil
+}
+
+func (s *Service) Update(ctx context.Context, categoryId, id int64, request DTO.AttributeDefinitionRequest) (DTO.AttributeDefinitionResponse, *errs.AppError) {
+	request, appErr := normalizeDefinitionRequest(request)
+	if appErr != nil {
+		return DTO.AttributeDefinitionResponse{}, appErr
+	}
+
+	var definition queries.AttributeDefinition
+	appErr = helpers.WithTx(ctx, s.p, s.q, func(timeout context.Context, q *queries.Queries) *errs.AppError {
+		oldDefinition, err := q.GetAttributeDefinition(timeout, queries.GetAttributeDefinitionParams{ID: id, CategoryID: categoryId})
+		if err != nil {
+			return errs.FromPgErr(err)
+		}
+		appErr := checkCodeConflicts(timeout, q, categoryId, id, request.Code)
+		if appErr != nil {
+			return appErr
+		}
+
+		// stored values must stay valid for the new definition
+		if oldDefinition.Type != request.Type {
+			values, err := q.CountAttributeValues(timeout, id)
+			if err != nil {
+				return errs.Internal(err)
+			}
+			if values > 0 {
+				return errs.UnprocessableEntity(fmt.Errorf("type of attribute with id=%d can't be changed while %d products have a value", id, values))
+			}
+		}
+		if request.Type == TypeEnum {
+			values, err := q.CountAttributeValuesNotIn(timeout, queries.CountAttributeValuesNotInParams{AttributeID: id, Allowed: request.EnumValues})
+			if err != nil {
+				return errs.Internal(err)
+			}
+			if values > 0 {
+				return errs.UnprocessableEntity(fmt.Errorf("%d products use enum values missing from enum_values", values))
+			}
+		}
+
+		definition, err = q.UpdateAttributeDefinition(timeout, mapRequestToUpdateParams(categoryId, id, request))
+		if err != nil {
+			return errs.FromPgErr(err)
+		}
+		return nil
+	})
+	if appErr != nil {
+		return DTO.AttributeDefinitionResponse{}, appErr
+	}
+
+	return mapDefinitionToResponse(definition), nil
+}
+
+// Delete removes an attribute definition together with all product values of it.
+func (s *Service) Delete(ctx context.Context, categoryId, id int64) (int, *errs.AppError) {
+	rows, err := s.q.DeleteAttributeDefinition(ctx, queries.DeleteAttributeDefinitionParams{ID: id, CategoryID: categoryId})
+	if err != nil {
+		return 0, errs.Internal(err)
+	}
+	if rows == 0 {
+		return int(rows), errs.NotFound(errors.New("attribute not found"))
+	}
+	return int(rows), nil
+}
+
+func (s *Service) GetProductValues(ctx context.Context, productId int64) ([]DTO.ProductAttributeValueResponse, *errs.AppError) {
+	_, err := s.q.GetProduct(ctx, productId)
+	if err != nil {
+		return nil, errs.FromPgErr(err)
+	}
+
+	values, err := s.q.GetProductAttributeValues(ctx, productId)
+	if err != nil {
+		return nil, errs.Internal(err)
+	}
+
+	valuesResponse := make([]DTO.ProductAttributeValueResponse, len(values))
+	for i, value := range values {
+		valuesResponse[i] = mapValueToResponse(value)
+	}
+	return valuesResponse, nil
+}
+
+// SetProductValues replaces all attribute values of a product. Every value must match
+// a definition of the product's category or its ancestors, and required ones must be present.
+func (s *Service) SetProductValues(ctx context.Context, productId int64, request DTO.ProductAttributesRequest) ([]DTO.ProductAttributeValueResponse, *errs.AppError) {
+	appErr := helpers.WithTx(ctx, s.p, s.q, func(timeout context.Context, q *queries.Queries) *errs.AppError {
+		_, err := q.LockProduct(timeout, productId)
+		if err != nil {
+			return errs.FromPgErr(err)
+		}
+		product, err := q.GetProduct(timeout, productId)
+		if err != nil {
+			return errs.FromPgErr(err)
+		}
+		definitions, err := q.GetCategoryAttributeDefinitions(timeout, product.CategoryID)
+		if err != nil {
+			return errs.Internal(err)
+		}
+
+		params := make([]queries.CreateProductAttributeValueParams, 0, len(request.Values))
+		var violations []string
+		for _, definition := range definitions {
+			raw, ok := request.Values[definition.Code]
+			if !ok || raw == nil {
+				if definition.IsRequired {
+					violations = append(violations, fmt.Sprintf("%s: value is required", definition.Code))
+				}
+				continue
+			}
+			value, number, err := ParseValue(definition, raw)
+			if err != nil {
+				violations = append(violations, fmt.Sprintf("%s: %s", definition.Code, err))
+				continue
+			}
+			params = append(params, queries.CreateProductAttributeValueParams{
+				ProductID:   productId,
+				AttributeID: definition.ID,
+				Value:       value,
+				ValueNumber: number,
+			})
+		}
+		for code := range request.Values {
+			if !slices.ContainsFunc(definitions, func(definition queries.AttributeDefinition) bool { return definition.Code == code }) {
+				violations = append(violations, fmt.Sprintf("%s: category of the product has no such attribute", code))
+			}
+		}
+		if len(violations) > 0 {
+			slices.Sort(violations)
+			return errs.UnprocessableEntity(errors.New(strings.Join(violations, "; ")))
+		}
+
+		err = q.DeleteProductAttributeValues(timeout, productId)
+		if err != nil {
+			return errs.Internal(err)
+		}
+		for _, param := range params {
+			err = q.CreateProductAttributeValue(timeout, param)
+			if err != nil {
+				return errs.FromPgErr(err)
+			}
+		}
+		return nil
+	})
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	return s.GetProductValues(ctx, productId)
+}
+
+// ParseValue validates a JSON decoded value against the definition and returns its
+// normalized text form, which is what filters and facets compare, and the numeric form
+// for number attributes.
+func ParseValue(definition queries.AttributeDefinition, raw any) (string, *float64, error) {
+	switch definition.Type {
+	case TypeString:
+		value, ok := raw.(string)
+		if !ok || strings.TrimSpace(value) == "" {
+			return "", nil, errors.New("expected a non-empty string")
+		}
+		return strings.TrimSpace(value), nil, nil
+	case TypeNumber:
+		value, ok := raw.(float64)
+		if !ok {
+			return "", nil, errors.New("expected a number")
+		}
+		return FormatNumber(value), &value, nil
+	case TypeBoolean:
+		value, ok := raw.(bool)
+		if !ok {
+			return "", nil, errors.New("expected a boolean")
+		}
+		return strconv.FormatBool(value), nil, nil
+	case TypeEnum:
+		value, ok := raw.(string)
+		if !ok || !slices.Contains(definition.EnumValues, value) {
+			return "", nil, fmt.Errorf("expected one of %v", definition.EnumValues)
+		}
+		return value, nil, nil
+	default:
+		return "", nil, fmt.Errorf("unknown attribute type %q", definition.Type)
+	}
+}
+
+// FormatNumber is the canonical text form of a number attribute value, so 16 and 16.0 match.
+func FormatNumber(value float64) string {
+	return strconv.FormatFloat(value, 'f', -1, 64)
+}
+
+// IsValidCode reports whether the code can be an attribute code.
+func IsValidCode(code string) bool {
+	return codeRegexp.MatchString(code)
+}
+
+func normalizeDefinitionRequest(request DTO.AttributeDefinitionRequest) (DTO.AttributeDefinitionRequest, *errs.AppError) {
+	request.Code = strings.TrimSpace(request.Code)
+	request.Name = strings.TrimSpace(request.Name)
+	if !codeRegexp.MatchString(request.Code) {
+		return DTO.AttributeDefinitionRequest{}, errs.BadRequest(errors.New("code must start with a latin letter and contain only lowercase latin letters, digits and underscores"))
+	}
+	if request.Name == "" {
+		return DTO.AttributeDefinitionRequest{}, errs.BadRequest(errors.New("name must not be empty"))
+	}
+
+	switch request.Type {
+	case TypeString, TypeBoolean:
+	case TypeNumber:
+		if request.Unit != nil {
+			unit := strings.TrimSpace(*request.Unit)
+			request.Unit = &unit
+			if unit == "" {
+				request.Unit = nil
+			}
+		}
+	case TypeEnum:
+		if len(request.EnumValues) == 0 {
+			return DTO.AttributeDefinitionRequest{}, errs.BadRequest(errors.New("enum attribute must have enum_values"))
+		}
+		values := make([]string, 0, len(request.EnumValues))
+		for _, value := range request.EnumValues {
+			value = strings.TrimSpace(value)
+			if value == "" {
+				return DTO.AttributeDefinitionRequest{}, errs.BadRequest(errors.New("enum_values must not contain empty values"))
+			}
+			if slices.Contains(values, value) {
+				return DTO.AttributeDefinitionRequest{}, errs.BadRequest(fmt.Errorf("enum value %q is repeated", value))
+			}
+			values = append(values, value)
+		}
+		request.EnumValues = values
+	default:
+		return DTO.AttributeDefinitionRequest{}, errs.BadRequest(fmt.Errorf("unknown type %q, expected string, number, boolean or enum", request.Type))
+	}
+
+	if request.Type != TypeNumber && request.Unit != nil {
+		return DTO.AttributeDefinitionRequest{}, errs.BadRequest(errors.New("only number attributes can have a unit"))
+	}
+	if request.Type != TypeEnum {
+		if len(request.EnumValues) > 0 {
+			return DTO.AttributeDefinitionRequest{}, errs.BadRequest(errors.New("only enum attributes can have enum_values"))
+		}
+		request.EnumValues = []string{}
+	}
+	return request, nil
+}
+
+// checkCodeConflicts keeps codes unique along every root-to-leaf path of the category tree,
+// so a product never gets two applicable definitions with the same code. It takes the tree lock,
+// so a category move can't change the paths between the check and the write.
+func checkCodeConflicts(timeout context.Context, q *queries.Queries, categoryId, excludeId int64, code string) *errs.AppError {
+	err := q.LockCategoryTree(timeout)
+	if err != nil {
+		return errs.Internal(err)
+	}
+	conflicts, err := q.CountAttributeCodeConflicts(timeout, queries.CountAttributeCodeConflictsParams{
+		Code:       code,
+		ExcludeID:  excludeId,
+		CategoryID: categoryId,
+	})
+	if err != nil {
+		return errs.Internal(err)
+	}
+	if conflicts > 0 {
+		return errs.Conflict(fmt.Errorf("attribute with code %q is already defined in a parent or child category", code))
+	}
+	return nil
+}
@@ -0,0 +1,72 @@
+package attribute
+
+import (
+	"strconv"
+
+	"github.com/Aoladiy/go-with-tools/internal/DTO"
+	"github.com/Aoladiy/go-with-tools/internal/database/queries"
+	"github.com/Aoladiy/go-with-tools/internal/helpers"
+)
+
+func mapRequestToCreateParams(categoryId int64, request DTO.AttributeDefinitionRequest) queries.CreateAttributeDefinitionParams {
+	return queries.CreateAttributeDefinitionParams{
+		CategoryID:   categoryId,
+		Code:         request.Code,
+		Name:         request.Name,
+		Type:         request.Type,
+		Unit:         request.Unit,
+		EnumValues:   request.EnumValues,
+		IsRequired:   helpers.DerefBool(request.IsRequired, false),
+		IsFilterable: helpers.DerefBool(request.IsFilterable, true),
+	}
+}
+
+func mapRequestToUpdateParams(categoryId, id int64, request DTO.AttributeDefinitionRequest) queries.UpdateAttributeDefinitionParams {
+	return queries.UpdateAttributeDefinitionParams{
+		ID:           id,
+		CategoryID:   categoryId,
+		Code:         request.Code,
+		Name:         request.Name,
+		Type:         request.Type,
+		Unit:         request.Unit,
+		EnumValues:   request.EnumValues,
+		IsRequired:   helpers.DerefBool(request.IsRequired, false),
+		IsFilterable: helpers.DerefBool(request.IsFilterable, true),
+	}
+}
+
+func mapDefinitionToResponse(definition queries.AttributeDefinition) DTO.AttributeDefinitionResponse {
+	return DTO.AttributeDefinitionResponse{
+		Id:           definition.ID,
+		CategoryId:   definition.CategoryID,
+		Code:         definition.Code,
+		Name:         definition.Name,
+		Type:         definition.Type,
+		Unit:         definition.Unit,
+		EnumValues:   definition.EnumValues,
+		IsRequired:   definition.IsRequired,
+		IsFilterable: definition.IsFilterable,
+		CreatedAt:    definition.CreatedAt,
+		UpdatedAt:    definition.UpdatedAt,
+	}
+}
+
+func mapValueToResponse(value queries.GetProductAttributeValuesRow) DTO.ProductAttributeValueResponse {
+	response := DTO.ProductAttributeValueResponse{
+		AttributeId: value.ID,
+		Code:        value.Code,
+		Name:        value.Name,
+		Type:        value.Type,
+		Unit:        value.Unit,
+		Value:       value.Value,
+	}
+	switch value.Type {
+	case TypeNumber:
+		if value.ValueNumber != nil {
+			response.Value = *value.ValueNumber
+		}
+	case TypeBoolean:
+		response.Value, _ = strconv.ParseBool(value.Value)
+	}
+	return response
+}
@@ -0,0 +1,76 @@
+package auth
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/Aoladiy/go-with-tools/internal/errs"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// domain is the google.rpc.ErrorInfo domain of the errors of the auth microservice.
+const domain = "auth.go-with-tools"
+
+// FromGrpcErr translates an error of a call to the auth microservice into the error it was there.
+// The status code gives the error, the ErrorInfo reason refines it where the code is shared, e.g.
+// InvalidArgument is both a bad request and a failed validation. The message and the field
+// violations are kept and the id of the request in the microservice is added as the
+// auth_request_id extension.
+func FromGrpcErr(err error) *errs.AppError {
+	st, ok := status.FromError(err)
+	if !ok {
+		return errs.Internal(err)
+	}
+
+	appErr := fromCode(st, err)
+	for _, detail := range st.Details() {
+		switch detail := detail.(type) {
+		case *errdetails.ErrorInfo:
+			if detail.GetDomain() != domain {
+				continue
+			}
+			code, ok := errs.ParseCode(strings.ToLower(detail.GetReason()))
+			if ok && code != errs.InternalErrCode && appErr.Code != errs.InternalErrCode {
+				appErr.Code = code
+			}
+		case *errdetails.RequestInfo:
+			appErr.With("auth_request_id", detail.GetRequestId())
+		case *errdetails.BadRequest:
+			for _, violation := range detail.GetFieldViolations() {
+				appErr.Violations = append(appErr.Violations, errs.Violation{
+					Field:   violation.GetField(),
+					Rule:    violation.GetReason(),
+					Message: violation.GetDescription(),
+				})
+			}
+		}
+	}
+	return appErr
+}
+
+// fromCode is the error of the status code alone. It covers every code errs.AppError.GrpcCode of the
+// auth microservice gives and the ones the call itself fails with when the microservice is down or slow,
+// the rest are internal.
+func fromCode(st *status.Status, err error) *errs.AppError {
+	message := errors.New(st.Message())
+	switch st.Code() {
+	case codes.Unauthenticated:
+		return errs.Unauthorized(message)
+	case codes.InvalidArgument:
+		return errs.BadRequest(message)
+	case codes.AlreadyExists:
+		return errs.Conflict(message)
+	case codes.NotFound:
+		return errs.NotFound(message)
+	case codes.Unavailable:
+		return errs.ServiceUnavailable(fmt.Errorf("auth microservice is unavailable | %w", err))
+	case codes.DeadlineExceeded:
+		return errs.GatewayTimeout(fmt.Errorf("auth microservice didn't answer in time | %w", err))
+	default:
+		return errs.Internal(err)
+	}
+}
@@ -3,69 +3,257 @@ package brand
 import (
 	"context"
 	"errors"
+	"fmt"
+	"time"
 
 	"github.com/Aoladiy/go-with-tools/internal/DTO"
 	"github.com/Aoladiy/go-with-tools/internal/database/queries"
 	"github.com/Aoladiy/go-with-tools/internal/errs"
+	"github.com/Aoladiy/go-with-tools/internal/export"
 	"github.com/Aoladiy/go-with-tools/internal/helpers"
+	"github.com/Aoladiy/go-with-tools/internal/media"
+	"github.com/Aoladiy/go-with-tools/internal/slug"
 
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
 type Service struct {
-	q *queries.Queries
-	p *pgxpool.Pool
+	q     *queries.Queries
+	p     *pgxpool.Pool
+	media *media.Service
 }
 
-func New(q *queries.Queries, p *pgxpool.Pool) *Service {
-	return &Service{q: q, p: p}
+var sortFields = []string{"name", "created_at", "updated_at"}
+
+func New(q *queries.Queries, p *pgxpool.Pool, media *media.Service) *Service {
+	return &Service{q: q, p: p, media: media}
 }
 
+// Create generates the slug from the name when the request has none.
 func (s *Service) Create(ctx context.Context, request DTO.BrandRequest) (DTO.BrandResponse, *errs.AppError) {
-	brand, err := s.q.CreateBrand(ctx, mapRequestToCreateParams(request))
-	if err != nil {
-		return DTO.BrandResponse{}, errs.FromPgErr(err)
+	var brand queries.CreateBrandRow
+	appErr := helpers.WithTx(ctx, s.p, s.q, func(timeout context.Context, q *queries.Queries) *errs.AppError {
+		var appErr *errs.AppError
+		request.Slug, appErr = slug.Assign(timeout, q, slug.Brands, 0, request.Slug, request.Name)
+		if appErr != nil {
+			return appErr
+		}
+
+		var err error
+		brand, err = q.CreateBrand(timeout, mapRequestToCreateParams(request))
+		if err != nil {
+			return errs.FromPgErr(err)
+		}
+		return nil
+	})
+	if appErr != nil {
+		return DTO.BrandResponse{}, appErr
 	}
 
-	return mapCreateRowToResponse(brand), nil
+	response := mapCreateRowToResponse(brand)
+	response.Media = []DTO.MediaResponse{}
+	return response, nil
 }
 
-func (s *Service) GetAll(ctx context.Context) ([]DTO.BrandResponse, *errs.AppError) {
-	brands, err := s.q.GetAllBrands(ctx)
+func (s *Service) GetAll(ctx context.Context, request DTO.BrandListRequest) (DTO.BrandPageResponse, *errs.AppError) {
+	page, appErr := helpers.ParsePage(request.PageRequest, sortFields...)
+	if appErr != nil {
+		return DTO.BrandPageResponse{}, appErr
+	}
+
+	brands, err := s.q.GetAllBrands(ctx, mapListRequestToGetAllParams(request, page))
 	if err != nil {
-		return nil, errs.Internal(err)
+		return DTO.BrandPageResponse{}, errs.Internal(err)
+	}
+	total, err := s.q.CountBrands(ctx, mapListRequestToCountParams(request))
+	if err != nil {
+		return DTO.BrandPageResponse{}, errs.Internal(err)
 	}
 
 	brandsResponse := make([]DTO.BrandResponse, len(brands))
 	for i, brand := range brands {
 		brandsResponse[i] = mapGetAllRowToResponse(brand)
 	}
+	items, pageInfo := helpers.Paginate(brandsResponse, total, page, cursorOf(page.SortBy))
+	appErr = s.withMedia(ctx, helpers.Pointers(items)...)
+	if appErr != nil {
+		return DTO.BrandPageResponse{}, appErr
+	}
+	return DTO.BrandPageResponse{Items: items, PageInfo: pageInfo}, nil
+}
+
+// Export writes the brands GetAll would return, with the same filters and sort.
+func (s *Service) Export(ctx context.Context, request DTO.BrandListRequest, w export.Writer) *errs.AppError {
+	page, appErr := helpers.ParsePage(request.PageRequest, sortFields...)
+	if appErr != nil {
+		return appErr
+	}
+
+	err := w.WriteHeader("id", "name", "slug", "created_at", "updated_at")
+	if err != nil {
+		return errs.Internal(err)
+	}
+	return helpers.WithSnapshot(ctx, s.p, s.q, func(timeout context.Context, q *queries.Queries) *errs.AppError {
+		return helpers.Walk(page, func(page helpers.Page) ([]DTO.BrandResponse, *errs.AppError) {
+			brands, err := q.GetAllBrands(timeout, mapListRequestToGetAllParams(request, page))
+			if err != nil {
+				return nil, errs.Internal(err)
+			}
+			brandsResponse := make([]DTO.BrandResponse, len(brands))
+			for i, brand := range brands {
+				brandsResponse[i] = mapGetAllRowToResponse(brand)
+			}
+			return brandsResponse, nil
+		}, cursorOf(page.SortBy), func(brand DTO.BrandResponse) error {
+			return w.WriteRow(brand.Id, brand.Name, brand.Slug, brand.CreatedAt, brand.UpdatedAt)
+		})
+	})
+}
+
+func (s *Service) GetAllFront(ctx context.Context) ([]DTO.FrontBrandResponse, *errs.AppError) {
+	brands, err := s.q.GetAllFrontBrands(ctx)
+	if err != nil {
+		return nil, errs.Internal(err)
+	}
+
+	brandsResponse := make([]DTO.FrontBrandResponse, len(brands))
+	for i, brand := range brands {
+		brandsResponse[i] = mapGetAllFrontRowToFrontResponse(brand)
+	}
 	return brandsResponse, nil
 }
 
+// GetFrontBySlug returns a brand that isn't deleted by its current slug together with its media.
+func (s *Service) GetFrontBySlug(ctx context.Context, slug string) (DTO.FrontBrandDetailResponse, *errs.AppError) {
+	brand, err := s.q.GetFrontBrandBySlug(ctx, slug)
+	if err != nil {
+		appErr := errs.FromPgErr(err)
+		if appErr.Code == errs.NotFoundErrCode {
+			return DTO.FrontBrandDetailResponse{}, errs.NotFound(fmt.Errorf("brand with slug %q not found | %w", slug, err))
+		}
+		return DTO.FrontBrandDetailResponse{}, appErr
+	}
+
+	media, appErr := s.media.ForBrands(ctx, []int64{brand.ID})
+	if appErr != nil {
+		return DTO.FrontBrandDetailResponse{}, appErr
+	}
+	response := mapGetFrontBySlugRowToDetailResponse(brand)
+	response.Media = media[0]
+	return response, nil
+}
+
 func (s *Service) Get(ctx context.Context, id int64) (DTO.BrandResponse, *errs.AppError) {
 	brand, err := s.q.GetBrand(ctx, id)
 	if err != nil {
 		return DTO.BrandResponse{}, errs.FromPgErr(err)
 	}
 
-	return mapGetRowToResponse(brand), nil
+	response := mapGetRowToResponse(brand)
+	appErr := s.withMedia(ctx, &response)
+	if appErr != nil {
+		return DTO.BrandResponse{}, appErr
+	}
+	return response, nil
 }
 
-func (s *Service) Update(ctx context.Context, id int64, request DTO.BrandRequest) (DTO.BrandResponse, *errs.AppError) {
-	brand, err := s.q.UpdateBrand(ctx, mapRequestToUpdateParams(id, request))
-	if err != nil {
-		return DTO.BrandResponse{}, errs.FromPgErr(err)
+// Update keeps the current slug when the request has none, a replaced slug goes to the history.
+func (s *Service) Update(ctx context.Context, id int64, version *int64, request DTO.BrandRequest) (DTO.BrandResponse, *errs.AppError) {
+	var brand queries.UpdateBrandRow
+	appErr := helpers.WithTx(ctx, s.p, s.q, func(timeout context.Context, q *queries.Queries) *errs.AppError {
+		appErr := lockVersion(timeout, q, id, version)
+		if appErr != nil {
+			return appErr
+		}
+		oldBrand, err := q.GetBrand(timeout, id)
+		if err != nil {
+			return errs.FromPgErr(err)
+		}
+		if request.Slug == "" {
+			request.Slug = oldBrand.Slug
+		}
+		request.Slug, appErr = slug.Assign(timeout, q, slug.Brands, id, request.Slug, request.Name)
+		if appErr != nil {
+			return appErr
+		}
+
+		brand, err = q.UpdateBrand(timeout, mapRequestToUpdateParams(id, request))
+		if err != nil {
+			return errs.FromPgErr(err)
+		}
+		return slug.Record(timeout, q, slug.Brands, id, oldBrand.Slug, brand.Slug)
+	})
+	if appErr != nil {
+		return DTO.BrandResponse{}, appErr
+	}
+
+	response := mapUpdateRowToResponse(brand)
+	appErr = s.withMedia(ctx, &response)
+	if appErr != nil {
+		return DTO.BrandResponse{}, appErr
+	}
+	return response, nil
+}
+
+// Patch applies a merge patch to the brand, only the members present in the patch are written.
+func (s *Service) Patch(ctx context.Context, id int64, version *int64, patch DTO.BrandPatchRequest) (DTO.BrandResponse, *errs.AppError) {
+	if patch.Name.Null {
+		return DTO.BrandResponse{}, errs.BadRequest(errors.New("name can't be removed"))
+	}
+
+	var brand queries.PatchBrandRow
+	appErr := helpers.WithTx(ctx, s.p, s.q, func(timeout context.Context, q *queries.Queries) *errs.AppError {
+		appErr := lockVersion(timeout, q, id, version)
+		if appErr != nil {
+			return appErr
+		}
+		oldBrand, err := q.GetBrand(timeout, id)
+		if err != nil {
+			return errs.FromPgErr(err)
+		}
+
+		params := queries.PatchBrandParams{ID: id}
+		name := oldBrand.Name
+		if patch.Name.Set {
+			name = patch.Name.Value
+			params.Name = &name
+		}
+		if patch.Slug.Set {
+			newSlug, appErr := slug.Assign(timeout, q, slug.Brands, id, patch.Slug.Value, name)
+			if appErr != nil {
+				return appErr
+			}
+			params.Slug = &newSlug
+		}
+
+		brand, err = q.PatchBrand(timeout, params)
+		if err != nil {
+			return errs.FromPgErr(err)
+		}
+		return slug.Record(timeout, q, slug.Brands, id, oldBrand.Slug, brand.Slug)
+	})
+	if appErr != nil {
+		return DTO.BrandResponse{}, appErr
 	}
 
-	return mapUpdateRowToResponse(brand), nil
+	response := mapPatchRowToResponse(brand)
+	appErr = s.withMedia(ctx, &response)
+	if appErr != nil {
+		return DTO.BrandResponse{}, appErr
+	}
+	return response, nil
 }
 
-func (s *Service) Delete(ctx context.Context, id int64) (int, *errs.AppError) {
+func (s *Service) Delete(ctx context.Context, id int64, version *int64) (int, *errs.AppError) {
 	var rows int64
 	appErr := helpers.WithTx(ctx, s.p, s.q, func(timeout context.Context, q *queries.Queries) *errs.AppError {
+		appErr := lockVersion(timeout, q, id, version)
+		if appErr != nil {
+			return appErr
+		}
+		deletionId := helpers.NewDeletionId()
 		var err error
-		rows, err = q.DeleteBrand(timeout, id)
+		rows, err = q.DeleteBrand(timeout, queries.DeleteBrandParams{ID: id, DeletionID: deletionId})
 		if err != nil {
 			return errs.Internal(err)
 		}
@@ -73,7 +261,7 @@ func (s *Service) Delete(ctx context.Context, id int64) (int, *errs.AppError) {
 			return errs.NotFound(errors.New("brand not found"))
 		}
 
-		_, err = q.DeleteProductsByBrandId(timeout, id)
+		_, err = q.DeleteProductsByBrandId(timeout, queries.DeleteProductsByBrandIdParams{BrandID: id, DeletionID: deletionId})
 		if err != nil {
 			return errs.Internal(err)
 		}
@@ -85,3 +273,122 @@ func (s *Service) Delete(ctx context.Context, id int64) (int, *errs.AppError) {
 
 	return int(rows), nil
 }
+
+// lockVersion locks the brand till the end of the transaction and checks it still has the expected version.
+func lockVersion(ctx context.Context, q *queries.Queries, id int64, version *int64) *errs.AppError {
+	current, err := q.LockBrand(ctx, id)
+	if err != nil {
+		appErr := errs.FromPgErr(err)
+		if appErr.Code == errs.NotFoundErrCode {
+			return errs.NotFound(fmt.Errorf("brand with id=%d not found | %w", id, err))
+		}
+		return appErr
+	}
+	return helpers.CheckVersion(version, current)
+}
+
+func (s *Service) GetTrash(ctx context.Context, request DTO.PageRequest) (DTO.TrashedBrandPageResponse, *errs.AppError) {
+	page, appErr := helpers.ParsePage(request, helpers.SortByDeletedAt)
+	if appErr != nil {
+		return DTO.TrashedBrandPageResponse{}, appErr
+	}
+
+	brands, err := s.q.GetTrashedBrands(ctx, queries.GetTrashedBrandsParams{
+		CursorID:    page.CursorId,
+		CursorValue: page.CursorValue,
+		SortBy:      page.SortBy,
+		SortDesc:    page.SortDesc,
+		PageLimit:   page.Limit + 1,
+	})
+	if err != nil {
+		return DTO.TrashedBrandPageResponse{}, errs.Internal(err)
+	}
+	total, err := s.q.CountTrashedBrands(ctx)
+	if err != nil {
+		return DTO.TrashedBrandPageResponse{}, errs.Internal(err)
+	}
+
+	brandsResponse := make([]DTO.TrashedBrandResponse, len(brands))
+	for i, brand := range brands {
+		brandsResponse[i] = mapTrashedRowToResponse(brand)
+	}
+	items, pageInfo := helpers.Paginate(brandsResponse, total, page, func(brand DTO.TrashedBrandResponse) (string, int64) {
+		if page.SortBy == helpers.SortByDeletedAt {
+			return brand.DeletedAt.Format(time.RFC3339Nano), brand.Id
+		}
+		return "", brand.Id
+	})
+	trashed := make([]*DTO.BrandResponse, len(items))
+	for i := range items {
+		trashed[i] = &items[i].BrandResponse
+	}
+	appErr = s.withMedia(ctx, trashed...)
+	if appErr != nil {
+		return DTO.TrashedBrandPageResponse{}, appErr
+	}
+	return DTO.TrashedBrandPageResponse{Items: items, PageInfo: pageInfo}, nil
+}
+
+// Restore brings a soft-deleted brand back together with the products its deletion cascaded to.
+// Products deleted separately, before or after, stay in the trash.
+func (s *Service) Restore(ctx context.Context, id int64) (DTO.BrandRestoreResponse, *errs.AppError) {
+	var response DTO.BrandRestoreResponse
+	appErr := helpers.WithTx(ctx, s.p, s.q, func(timeout context.Context, q *queries.Queries) *errs.AppError {
+		deletionId, err := q.LockTrashedBrand(timeout, id)
+		if err != nil {
+			return errs.FromPgErr(err)
+		}
+
+		brand, err := q.RestoreBrand(timeout, id)
+		if err != nil {
+			return errs.FromPgErr(err)
+		}
+		response.Brand = mapRestoreRowToResponse(brand)
+
+		response.RestoredProducts, err = q.RestoreProductsByDeletionId(timeout, deletionId)
+		if err != nil {
+			return errs.Internal(err)
+		}
+		return nil
+	})
+	if appErr != nil {
+		return DTO.BrandRestoreResponse{}, appErr
+	}
+
+	appErr = s.withMedia(ctx, &response.Brand)
+	if appErr != nil {
+		return DTO.BrandRestoreResponse{}, appErr
+	}
+	return response, nil
+}
+
+// withMedia fills in the media of the brands with a single query for all of them.
+func (s *Service) withMedia(ctx context.Context, brands ...*DTO.BrandResponse) *errs.AppError {
+	ids := make([]int64, len(brands))
+	for i, brand := range brands {
+		ids[i] = brand.Id
+	}
+	media, appErr := s.media.ForBrands(ctx, ids)
+	if appErr != nil {
+		return appErr
+	}
+	for i, brand := range brands {
+		brand.Media = media[i]
+	}
+	return nil
+}
+
+func cursorOf(sortBy string) func(brand DTO.BrandResponse) (string, int64) {
+	return func(brand DTO.BrandResponse) (string, int64) {
+		switch sortBy {
+		case "name":
+			return brand.Name, brand.Id
+		case "created_at":
+			return brand.CreatedAt.Format(time.RFC3339Nano), brand.Id
+		case "updated_at":
+			return brand.UpdatedAt.Format(time.RFC3339Nano), brand.Id
+		default:
+			return "", brand.Id
+		}
+	}
+}
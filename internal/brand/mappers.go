@@ -3,6 +3,7 @@ package brand
 import (
 	"github.com/Aoladiy/go-with-tools/internal/DTO"
 	"github.com/Aoladiy/go-with-tools/internal/database/queries"
+	"github.com/Aoladiy/go-with-tools/internal/helpers"
 )
 
 func mapRequestToCreateParams(request DTO.BrandRequest) queries.CreateBrandParams {
@@ -19,6 +20,30 @@ func mapCreateRowToResponse(brand queries.CreateBrandRow) DTO.BrandResponse {
 		Slug:      brand.Slug,
 		CreatedAt: brand.CreatedAt,
 		UpdatedAt: brand.UpdatedAt,
+		Version:   brand.Version,
+	}
+}
+
+func mapListRequestToGetAllParams(request DTO.BrandListRequest, page helpers.Page) queries.GetAllBrandsParams {
+	return queries.GetAllBrandsParams{
+		CreatedFrom: helpers.ToPgTimestamptz(request.CreatedFrom),
+		CreatedTo:   helpers.ToPgTimestamptz(request.CreatedTo),
+		UpdatedFrom: helpers.ToPgTimestamptz(request.UpdatedFrom),
+		UpdatedTo:   helpers.ToPgTimestamptz(request.UpdatedTo),
+		CursorID:    page.CursorId,
+		CursorValue: page.CursorValue,
+		SortBy:      page.SortBy,
+		SortDesc:    page.SortDesc,
+		PageLimit:   page.Limit + 1,
+	}
+}
+
+func mapListRequestToCountParams(request DTO.BrandListRequest) queries.CountBrandsParams {
+	return queries.CountBrandsParams{
+		CreatedFrom: helpers.ToPgTimestamptz(request.CreatedFrom),
+		CreatedTo:   helpers.ToPgTimestamptz(request.CreatedTo),
+		UpdatedFrom: helpers.ToPgTimestamptz(request.UpdatedFrom),
+		UpdatedTo:   helpers.ToPgTimestamptz(request.UpdatedTo),
 	}
 }
 
@@ -29,6 +54,7 @@ func mapGetAllRowToResponse(brand queries.GetAllBrandsRow) DTO.BrandResponse {
 		Slug:      brand.Slug,
 		CreatedAt: brand.CreatedAt,
 		UpdatedAt: brand.UpdatedAt,
+		Version:   brand.Version,
 	}
 }
 
@@ -39,6 +65,7 @@ func mapGetRowToResponse(brand queries.GetBrandRow) DTO.BrandResponse {
 		Slug:      brand.Slug,
 		CreatedAt: brand.CreatedAt,
 		UpdatedAt: brand.UpdatedAt,
+		Version:   brand.Version,
 	}
 }
 
@@ -57,5 +84,57 @@ func mapUpdateRowToResponse(brand queries.UpdateBrandRow) DTO.BrandResponse {
 		Slug:      brand.Slug,
 		CreatedAt: brand.CreatedAt,
 		UpdatedAt: brand.UpdatedAt,
+		Version:   brand.Version,
+	}
+}
+
+func mapPatchRowToResponse(brand queries.PatchBrandRow) DTO.BrandResponse {
+	return DTO.BrandResponse{
+		Id:        brand.ID,
+		Name:      brand.Name,
+		Slug:      brand.Slug,
+		CreatedAt: brand.CreatedAt,
+		UpdatedAt: brand.UpdatedAt,
+		Version:   brand.Version,
+	}
+}
+
+func mapGetAllFrontRowToFrontResponse(brand queries.GetAllFrontBrandsRow) DTO.FrontBrandResponse {
+	return DTO.FrontBrandResponse{
+		Id:   brand.ID,
+		Name: brand.Name,
+		Slug: brand.Slug,
+	}
+}
+
+func mapGetFrontBySlugRowToDetailResponse(brand queries.GetFrontBrandBySlugRow) DTO.FrontBrandDetailResponse {
+	return DTO.FrontBrandDetailResponse{
+		Id:   brand.ID,
+		Name: brand.Name,
+		Slug: brand.Slug,
+	}
+}
+
+func mapTrashedRowToResponse(brand queries.GetTrashedBrandsRow) DTO.TrashedBrandResponse {
+	return DTO.TrashedBrandResponse{
+		BrandResponse: DTO.BrandResponse{
+			Id:        brand.ID,
+			Name:      brand.Name,
+			Slug:      brand.Slug,
+			CreatedAt: brand.CreatedAt,
+			UpdatedAt: brand.UpdatedAt,
+		},
+		DeletedAt: brand.DeletedAt,
+	}
+}
+
+func mapRestoreRowToResponse(brand queries.RestoreBrandRow) DTO.BrandResponse {
+	return DTO.BrandResponse{
+		Id:        brand.ID,
+		Name:      brand.Name,
+		Slug:      brand.Slug,
+		CreatedAt: brand.CreatedAt,
+		UpdatedAt: brand.UpdatedAt,
+		Version:   brand.Version,
 	}
 }
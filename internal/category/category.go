@@ -4,54 +4,119 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"net/http"
+	"strings"
+	"time"
 
 	"github.com/Aoladiy/go-with-tools/internal/DTO"
 	"github.com/Aoladiy/go-with-tools/internal/database/queries"
 	"github.com/Aoladiy/go-with-tools/internal/errs"
+	"github.com/Aoladiy/go-with-tools/internal/export"
 	"github.com/Aoladiy/go-with-tools/internal/helpers"
+	"github.com/Aoladiy/go-with-tools/internal/slug"
 
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
 type Service struct {
-	q *queries.Queries
-	p *pgxpool.Pool
+	q        *queries.Queries
+	p        *pgxpool.Pool
+	maxDepth int
 }
 
-func New(q *queries.Queries, p *pgxpool.Pool) *Service {
-	return &Service{q: q, p: p}
+var sortFields = []string{"name", "created_at", "updated_at"}
+
+func New(q *queries.Queries, p *pgxpool.Pool, maxDepth int) *Service {
+	return &Service{q: q, p: p, maxDepth: maxDepth}
 }
 
+// Create generates the slug from the name when the request has none.
 func (s *Service) Create(ctx context.Context, request DTO.CategoryRequest) (DTO.CategoryResponse, *errs.AppError) {
-	if request.ParentId != nil {
-		_, appErr := s.Get(ctx, *request.ParentId)
+	var category queries.CreateCategoryRow
+	appErr := helpers.WithTx(ctx, s.p, s.q, func(timeout context.Context, q *queries.Queries) *errs.AppError {
+		appErr := s.validateParent(timeout, q, 0, request.ParentId)
 		if appErr != nil {
-			if appErr.Code == http.StatusNotFound {
-				return DTO.CategoryResponse{}, errs.NotFound(fmt.Errorf("parent category with id=%d not found | %w", *request.ParentId, appErr.Unwrap()))
-			}
-			return DTO.CategoryResponse{}, appErr
+			return appErr
+		}
+		request.Slug, appErr = slug.Assign(timeout, q, slug.Categories, 0, request.Slug, request.Name)
+		if appErr != nil {
+			return appErr
 		}
-	}
 
-	category, err := s.q.CreateCategory(ctx, mapRequestToCreateParams(request))
-	if err != nil {
-		return DTO.CategoryResponse{}, errs.FromPgErr(err)
+		var err error
+		category, err = q.CreateCategory(timeout, mapRequestToCreateParams(request))
+		if err != nil {
+			return errs.FromPgErr(err)
+		}
+		return nil
+	})
+	if appErr != nil {
+		return DTO.CategoryResponse{}, appErr
 	}
 
 	return mapCreateRowToResponse(category), nil
 }
 
-func (s *Service) GetAll(ctx context.Context) ([]DTO.CategoryResponse, *errs.AppError) {
-	categories, err := s.q.GetAllCategories(ctx)
+func (s *Service) GetAll(ctx context.Context, request DTO.CategoryListRequest) (DTO.CategoryPageResponse, *errs.AppError) {
+	page, appErr := helpers.ParsePage(request.PageRequest, sortFields...)
+	if appErr != nil {
+		return DTO.CategoryPageResponse{}, appErr
+	}
+
+	categories, err := s.q.GetAllCategories(ctx, mapListRequestToGetAllParams(request, page))
 	if err != nil {
-		return nil, errs.Internal(err)
+		return DTO.CategoryPageResponse{}, errs.Internal(err)
+	}
+	total, err := s.q.CountCategories(ctx, mapListRequestToCountParams(request))
+	if err != nil {
+		return DTO.CategoryPageResponse{}, errs.Internal(err)
 	}
 
 	categoriesResponse := make([]DTO.CategoryResponse, len(categories))
 	for i, category := range categories {
 		categoriesResponse[i] = mapGetAllRowToResponse(category)
 	}
+	items, pageInfo := helpers.Paginate(categoriesResponse, total, page, cursorOf(page.SortBy))
+	return DTO.CategoryPageResponse{Items: items, PageInfo: pageInfo}, nil
+}
+
+// Export writes the categories GetAll would return, with the same filters and sort.
+func (s *Service) Export(ctx context.Context, request DTO.CategoryListRequest, w export.Writer) *errs.AppError {
+	page, appErr := helpers.ParsePage(request.PageRequest, sortFields...)
+	if appErr != nil {
+		return appErr
+	}
+
+	err := w.WriteHeader("id", "name", "slug", "parent_id", "created_at", "updated_at")
+	if err != nil {
+		return errs.Internal(err)
+	}
+	return helpers.WithSnapshot(ctx, s.p, s.q, func(timeout context.Context, q *queries.Queries) *errs.AppError {
+		return helpers.Walk(page, func(page helpers.Page) ([]DTO.CategoryResponse, *errs.AppError) {
+			categories, err := q.GetAllCategories(timeout, mapListRequestToGetAllParams(request, page))
+			if err != nil {
+				return nil, errs.Internal(err)
+			}
+			categoriesResponse := make([]DTO.CategoryResponse, len(categories))
+			for i, category := range categories {
+				categoriesResponse[i] = mapGetAllRowToResponse(category)
+			}
+			return categoriesResponse, nil
+		}, cursorOf(page.SortBy), func(category DTO.CategoryResponse) error {
+			return w.WriteRow(category.Id, category.Name, category.Slug, category.ParentId, category.CreatedAt, category.UpdatedAt)
+		})
+	})
+}
+
+func (s *Service) GetAllFront(ctx context.Context) ([]DTO.FrontCategoryResponse, *errs.AppError) {
+	categories, err := s.q.GetAllFrontCategories(ctx)
+	if err != nil {
+		return nil, errs.Internal(err)
+	}
+
+	categoriesResponse := make([]DTO.FrontCategoryResponse, len(categories))
+	for i, category := range categories {
+		categoriesResponse[i] = mapGetAllFrontRowToFrontResponse(category)
+	}
 	return categoriesResponse, nil
 }
 
@@ -64,73 +129,428 @@ func (s *Service) Get(ctx context.Context, id int64) (DTO.CategoryResponse, *err
 	return mapGetRowToResponse(category), nil
 }
 
-func (s *Service) Update(ctx context.Context, id int64, request DTO.CategoryRequest) (DTO.CategoryResponse, *errs.AppError) {
-	if request.ParentId != nil {
-		_, appErr := s.Get(ctx, *request.ParentId)
+// Update keeps the current slug when the request has none, a replaced slug goes to the history.
+func (s *Service) Update(ctx context.Context, id int64, version *int64, request DTO.CategoryRequest) (DTO.CategoryResponse, *errs.AppError) {
+	var category queries.UpdateCategoryRow
+	appErr := helpers.WithTx(ctx, s.p, s.q, func(timeout context.Context, q *queries.Queries) *errs.AppError {
+		appErr := s.validateParent(timeout, q, id, request.ParentId)
+		if appErr != nil {
+			return appErr
+		}
+		appErr = lockVersion(timeout, q, id, version)
+		if appErr != nil {
+			return appErr
+		}
+		oldCategory, err := q.GetCategory(timeout, id)
+		if err != nil {
+			return errs.FromPgErr(err)
+		}
+		if request.Slug == "" {
+			request.Slug = oldCategory.Slug
+		}
+		request.Slug, appErr = slug.Assign(timeout, q, slug.Categories, id, request.Slug, request.Name)
+		if appErr != nil {
+			return appErr
+		}
+
+		category, err = q.UpdateCategory(timeout, mapRequestToUpdateParams(id, request))
+		if err != nil {
+			return errs.FromPgErr(err)
+		}
+		appErr = reparented(timeout, q, id, oldCategory.ParentID, category.ParentID)
 		if appErr != nil {
-			if appErr.Code == http.StatusNotFound {
-				return DTO.CategoryResponse{}, errs.NotFound(fmt.Errorf("parent category with id=%d not found | %w", *request.ParentId, appErr.Unwrap()))
+			return appErr
+		}
+		return slug.Record(timeout, q, slug.Categories, id, oldCategory.Slug, category.Slug)
+	})
+	if appErr != nil {
+		return DTO.CategoryResponse{}, appErr
+	}
+
+	return mapUpdateRowToResponse(category), nil
+}
+
+// Patch applies a merge patch to the category, only the members present in the patch are written.
+func (s *Service) Patch(ctx context.Context, id int64, version *int64, patch DTO.CategoryPatchRequest) (DTO.CategoryResponse, *errs.AppError) {
+	if patch.Name.Null {
+		return DTO.CategoryResponse{}, errs.BadRequest(errors.New("name can't be removed"))
+	}
+
+	var category queries.PatchCategoryRow
+	appErr := helpers.WithTx(ctx, s.p, s.q, func(timeout context.Context, q *queries.Queries) *errs.AppError {
+		// the tree lock of validateParent is taken before the category itself, the same order as in Update
+		params := queries.PatchCategoryParams{ID: id}
+		if patch.ParentId.Set {
+			if !patch.ParentId.Null {
+				params.ParentID = &patch.ParentId.Value
+			}
+			appErr := s.validateParent(timeout, q, id, params.ParentID)
+			if appErr != nil {
+				return appErr
+			}
+			params.SetParentID = true
+		}
+		appErr := lockVersion(timeout, q, id, version)
+		if appErr != nil {
+			return appErr
+		}
+		oldCategory, err := q.GetCategory(timeout, id)
+		if err != nil {
+			return errs.FromPgErr(err)
+		}
+
+		name := oldCategory.Name
+		if patch.Name.Set {
+			name = patch.Name.Value
+			params.Name = &name
+		}
+		if patch.Slug.Set {
+			newSlug, appErr := slug.Assign(timeout, q, slug.Categories, id, patch.Slug.Value, name)
+			if appErr != nil {
+				return appErr
 			}
-			return DTO.CategoryResponse{}, appErr
+			params.Slug = &newSlug
+		}
+
+		category, err = q.PatchCategory(timeout, params)
+		if err != nil {
+			return errs.FromPgErr(err)
+		}
+		appErr = reparented(timeout, q, id, oldCategory.ParentID, category.ParentID)
+		if appErr != nil {
+			return appErr
 		}
+		return slug.Record(timeout, q, slug.Categories, id, oldCategory.Slug, category.Slug)
+	})
+	if appErr != nil {
+		return DTO.CategoryResponse{}, appErr
 	}
 
-	category, err := s.q.UpdateCategory(ctx, mapRequestToUpdateParams(id, request))
-	if err != nil {
-		return DTO.CategoryResponse{}, errs.FromPgErr(err)
+	return mapPatchRowToResponse(category), nil
+}
+
+// Move reparents a category together with its whole subtree. The products of the subtree lose the
+// values of the attributes inherited from the old parents.
+func (s *Service) Move(ctx context.Context, id int64, request DTO.CategoryMoveRequest) (DTO.CategoryResponse, *errs.AppError) {
+	var category queries.MoveCategoryRow
+	appErr := helpers.WithTx(ctx, s.p, s.q, func(timeout context.Context, q *queries.Queries) *errs.AppError {
+		appErr := s.validateParent(timeout, q, id, request.ParentId)
+		if appErr != nil {
+			return appErr
+		}
+
+		oldCategory, err := q.GetCategory(timeout, id)
+		if err != nil {
+			return errs.FromPgErr(err)
+		}
+		category, err = q.MoveCategory(timeout, queries.MoveCategoryParams{
+			ID:       id,
+			ParentID: request.ParentId,
+		})
+		if err != nil {
+			return errs.FromPgErr(err)
+		}
+		return reparented(timeout, q, id, oldCategory.ParentID, category.ParentID)
+	})
+	if appErr != nil {
+		return DTO.CategoryResponse{}, appErr
 	}
 
-	return mapUpdateRowToResponse(category), nil
+	return mapMoveRowToResponse(category), nil
 }
 
-func (s *Service) Delete(ctx context.Context, id int64) (int, *errs.AppError) {
+func (s *Service) Delete(ctx context.Context, id int64, version *int64) (int, *errs.AppError) {
 	var rows int64
 	appErr := helpers.WithTx(ctx, s.p, s.q, func(timeout context.Context, q *queries.Queries) *errs.AppError {
-		var err error
-		rows, err = q.DeleteCategory(timeout, id)
+		appErr := lockVersion(timeout, q, id, version)
+		if appErr != nil {
+			return appErr
+		}
+		deletionId := helpers.NewDeletionId()
+		// products go first: once the categories are deleted the subtree can't be found anymore
+		_, err := q.DeleteProductsByCategorySubtree(timeout, queries.DeleteProductsByCategorySubtreeParams{ID: id, DeletionID: deletionId})
+		if err != nil {
+			return errs.Internal(err)
+		}
+		rows, err = q.DeleteCategorySubtree(timeout, queries.DeleteCategorySubtreeParams{ID: id, DeletionID: deletionId})
 		if err != nil {
 			return errs.Internal(err)
 		}
 		if rows == 0 {
 			return errs.NotFound(errors.New("category not found"))
 		}
-		_, err = q.DeleteProductsByCategoryId(timeout, id)
+		return nil
+	})
+	if appErr != nil {
+		return 0, appErr
+	}
+
+	return int(rows), nil
+}
+
+// lockVersion locks the category till the end of the transaction and checks it still has the expected version.
+func lockVersion(ctx context.Context, q *queries.Queries, id int64, version *int64) *errs.AppError {
+	current, err := q.LockCategory(ctx, id)
+	if err != nil {
+		appErr := errs.FromPgErr(err)
+		if appErr.Code == errs.NotFoundErrCode {
+			return errs.NotFound(fmt.Errorf("category with id=%d not found | %w", id, err))
+		}
+		return appErr
+	}
+	return helpers.CheckVersion(version, current)
+}
+
+func (s *Service) GetTree(ctx context.Context) ([]DTO.CategoryTreeResponse, *errs.AppError) {
+	categories, err := s.q.GetCategoryTree(ctx)
+	if err != nil {
+		return nil, errs.Internal(err)
+	}
+
+	categoriesResponse := make([]DTO.FrontCategoryResponse, len(categories))
+	for i, category := range categories {
+		categoriesResponse[i] = mapGetTreeRowToFrontResponse(category)
+	}
+	return buildTree(categoriesResponse, nil), nil
+}
+
+func (s *Service) GetBreadcrumbs(ctx context.Context, id int64) ([]DTO.FrontCategoryResponse, *errs.AppError) {
+	categories, err := s.q.GetCategoryAncestors(ctx, id)
+	if err != nil {
+		return nil, errs.Internal(err)
+	}
+	if len(categories) == 0 {
+		return nil, errs.NotFound(errors.New("category not found"))
+	}
+
+	categoriesResponse := make([]DTO.FrontCategoryResponse, len(categories))
+	for i, category := range categories {
+		categoriesResponse[i] = mapGetAncestorsRowToFrontResponse(category)
+	}
+	return categoriesResponse, nil
+}
+
+// GetFrontBySlug returns a category that isn't deleted by its current slug together with its breadcrumbs.
+func (s *Service) GetFrontBySlug(ctx context.Context, slug string) (DTO.FrontCategoryDetailResponse, *errs.AppError) {
+	category, err := s.q.GetFrontCategoryBySlug(ctx, slug)
+	if err != nil {
+		appErr := errs.FromPgErr(err)
+		if appErr.Code == errs.NotFoundErrCode {
+			return DTO.FrontCategoryDetailResponse{}, errs.NotFound(fmt.Errorf("category with slug %q not found | %w", slug, err))
+		}
+		return DTO.FrontCategoryDetailResponse{}, appErr
+	}
+
+	breadcrumbs, appErr := s.GetBreadcrumbs(ctx, category.ID)
+	if appErr != nil {
+		return DTO.FrontCategoryDetailResponse{}, appErr
+	}
+	response := mapGetFrontBySlugRowToDetailResponse(category)
+	response.Breadcrumbs = breadcrumbs
+	return response, nil
+}
+
+func (s *Service) GetDescendants(ctx context.Context, id int64) ([]DTO.CategoryTreeResponse, *errs.AppError) {
+	_, err := s.q.GetCategory(ctx, id)
+	if err != nil {
+		return nil, errs.FromPgErr(err)
+	}
+
+	categories, err := s.q.GetCategoryDescendants(ctx, id)
+	if err != nil {
+		return nil, errs.Internal(err)
+	}
+
+	categoriesResponse := make([]DTO.FrontCategoryResponse, len(categories))
+	for i, category := range categories {
+		categoriesResponse[i] = mapGetDescendantsRowToFrontResponse(category)
+	}
+	return buildTree(categoriesResponse, &id), nil
+}
+
+func (s *Service) GetTrash(ctx context.Context, request DTO.PageRequest) (DTO.TrashedCategoryPageResponse, *errs.AppError) {
+	page, appErr := helpers.ParsePage(request, helpers.SortByDeletedAt)
+	if appErr != nil {
+		return DTO.TrashedCategoryPageResponse{}, appErr
+	}
+
+	categories, err := s.q.GetTrashedCategories(ctx, queries.GetTrashedCategoriesParams{
+		CursorID:    page.CursorId,
+		CursorValue: page.CursorValue,
+		SortBy:      page.SortBy,
+		SortDesc:    page.SortDesc,
+		PageLimit:   page.Limit + 1,
+	})
+	if err != nil {
+		return DTO.TrashedCategoryPageResponse{}, errs.Internal(err)
+	}
+	total, err := s.q.CountTrashedCategories(ctx)
+	if err != nil {
+		return DTO.TrashedCategoryPageResponse{}, errs.Internal(err)
+	}
+
+	categoriesResponse := make([]DTO.TrashedCategoryResponse, len(categories))
+	for i, category := range categories {
+		categoriesResponse[i] = mapTrashedRowToResponse(category)
+	}
+	items, pageInfo := helpers.Paginate(categoriesResponse, total, page, func(category DTO.TrashedCategoryResponse) (string, int64) {
+		if page.SortBy == helpers.SortByDeletedAt {
+			return category.DeletedAt.Format(time.RFC3339Nano), category.Id
+		}
+		return "", category.Id
+	})
+	return DTO.TrashedCategoryPageResponse{Items: items, PageInfo: pageInfo}, nil
+}
+
+// Restore brings a soft-deleted category back together with the part of its subtree and the
+// products that were deleted by the same operation. The parent has to be restored first.
+func (s *Service) Restore(ctx context.Context, id int64) (DTO.CategoryRestoreResponse, *errs.AppError) {
+	var response DTO.CategoryRestoreResponse
+	appErr := helpers.WithTx(ctx, s.p, s.q, func(timeout context.Context, q *queries.Queries) *errs.AppError {
+		err := q.LockCategoryTree(timeout)
 		if err != nil {
 			return errs.Internal(err)
 		}
 
-		appErr := s.deleteRecursivelyByParentId(timeout, q, id)
-		if appErr != nil {
-			return appErr
+		trashed, err := q.LockTrashedCategory(timeout, id)
+		if err != nil {
+			return errs.FromPgErr(err)
+		}
+		if trashed.ParentDeleted {
+			return errs.UnprocessableEntity(fmt.Errorf("parent of category with id=%d is deleted, restore it first", id))
+		}
+
+		categories, err := q.RestoreCategorySubtree(timeout, queries.RestoreCategorySubtreeParams{
+			ID:         id,
+			DeletionID: trashed.DeletionID,
+		})
+		if err != nil {
+			return errs.FromPgErr(err)
 		}
+		for _, category := range categories {
+			if category.ID == id {
+				response.Category = mapRestoreRowToResponse(category)
+			}
+		}
+		response.RestoredCategories = int64(len(categories))
 
+		response.RestoredProducts, err = q.RestoreProductsByDeletionId(timeout, trashed.DeletionID)
+		if err != nil {
+			return errs.Internal(err)
+		}
 		return nil
 	})
 	if appErr != nil {
-		return 0, appErr
+		return DTO.CategoryRestoreResponse{}, appErr
 	}
 
-	return int(rows), nil
+	return response, nil
 }
 
-func (s *Service) deleteRecursivelyByParentId(timeout context.Context, q *queries.Queries, id int64) *errs.AppError {
-	idsByParentId, err := q.GetCategoriesByParentId(timeout, &id)
+// validateParent checks that category id (0 for a new one) can be placed under parentId:
+// the parent exists, isn't the category itself or one of its descendants, and the moved
+// subtree still fits into maxDepth. It takes the tree lock, so concurrent moves can't
+// create a cycle between the check and the update.
+func (s *Service) validateParent(timeout context.Context, q *queries.Queries, id int64, parentId *int64) *errs.AppError {
+	err := q.LockCategoryTree(timeout)
 	if err != nil {
 		return errs.Internal(err)
 	}
-	for _, idByParentId := range idsByParentId {
-		appErr := s.deleteRecursivelyByParentId(timeout, q, idByParentId)
-		if appErr != nil {
-			return appErr
+
+	parentDepth := 0
+	if parentId != nil {
+		if *parentId == id {
+			return errs.UnprocessableEntity(errors.New("category can't be its own parent"))
+		}
+		ancestors, err := q.GetCategoryAncestors(timeout, *parentId)
+		if err != nil {
+			return errs.Internal(err)
+		}
+		if len(ancestors) == 0 {
+			return errs.NotFound(fmt.Errorf("parent category with id=%d not found", *parentId))
 		}
+		for _, ancestor := range ancestors {
+			if ancestor.ID == id {
+				return errs.UnprocessableEntity(fmt.Errorf("category can't be moved under its own descendant with id=%d", *parentId))
+			}
+		}
+		parentDepth = len(ancestors)
+	}
+
+	height, err := q.GetCategorySubtreeHeight(timeout, id)
+	if err != nil {
+		return errs.Internal(err)
+	}
+	if parentDepth+1+int(height) > s.maxDepth {
+		return errs.UnprocessableEntity(fmt.Errorf("category tree can't be deeper than %d levels", s.maxDepth))
 	}
-	_, err = q.DeleteCategory(timeout, id)
+
+	// attribute codes stay unique along every root-to-leaf path with the new parents too
+	conflicts, err := q.GetMovedAttributeCodeConflicts(timeout, queries.GetMovedAttributeCodeConflictsParams{
+		ParentID:   parentId,
+		CategoryID: id,
+	})
 	if err != nil {
 		return errs.Internal(err)
 	}
-	_, err = q.DeleteProductsByCategoryId(timeout, id)
+	if len(conflicts) > 0 {
+		return errs.Conflict(fmt.Errorf("attributes %s of the category or its subtree are already defined in the new parent categories", strings.Join(conflicts, ", ")))
+	}
+	return nil
+}
+
+// reparented drops the attribute values of the subtree's products that the attributes of the new
+// parents don't define, if the parent of the category has changed.
+func reparented(timeout context.Context, q *queries.Queries, id int64, oldParentId, newParentId *int64) *errs.AppError {
+	if helpers.DerefInt64(oldParentId, 0) == helpers.DerefInt64(newParentId, 0) {
+		return nil
+	}
+	_, err := q.DeleteInapplicableSubtreeAttributeValues(timeout, id)
 	if err != nil {
 		return errs.Internal(err)
 	}
 	return nil
 }
+
+// buildTree nests categories under their parents. Categories whose parent is rootParentId
+// (or that have no parent when rootParentId is nil) become the roots of the tree.
+func buildTree(categories []DTO.FrontCategoryResponse, rootParentId *int64) []DTO.CategoryTreeResponse {
+	var roots []DTO.FrontCategoryResponse
+	childrenByParentId := make(map[int64][]DTO.FrontCategoryResponse)
+	for _, category := range categories {
+		if category.ParentId == nil || (rootParentId != nil && *category.ParentId == *rootParentId) {
+			roots = append(roots, category)
+			continue
+		}
+		childrenByParentId[*category.ParentId] = append(childrenByParentId[*category.ParentId], category)
+	}
+	return buildTreeNodes(roots, childrenByParentId)
+}
+
+func buildTreeNodes(categories []DTO.FrontCategoryResponse, childrenByParentId map[int64][]DTO.FrontCategoryResponse) []DTO.CategoryTreeResponse {
+	nodes := make([]DTO.CategoryTreeResponse, len(categories))
+	for i, category := range categories {
+		nodes[i] = DTO.CategoryTreeResponse{
+			Id:       category.Id,
+			Name:     category.Name,
+			Slug:     category.Slug,
+			Children: buildTreeNodes(childrenByParentId[category.Id], childrenByParentId),
+		}
+	}
+	return nodes
+}
+
+func cursorOf(sortBy string) func(category DTO.CategoryResponse) (string, int64) {
+	return func(category DTO.CategoryResponse) (string, int64) {
+		switch sortBy {
+		case "name":
+			return category.Name, category.Id
+		case "created_at":
+			return category.CreatedAt.Format(time.RFC3339Nano), category.Id
+		case "updated_at":
+			return category.UpdatedAt.Format(time.RFC3339Nano), category.Id
+		default:
+			return "", category.Id
+		}
+	}
+}
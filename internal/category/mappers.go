@@ -3,6 +3,7 @@ package category
 import (
 	"github.com/Aoladiy/go-with-tools/internal/DTO"
 	"github.com/Aoladiy/go-with-tools/internal/database/queries"
+	"github.com/Aoladiy/go-with-tools/internal/helpers"
 )
 
 func mapRequestToCreateParams(request DTO.CategoryRequest) queries.CreateCategoryParams {
@@ -21,6 +22,32 @@ func mapCreateRowToResponse(category queries.CreateCategoryRow) DTO.CategoryResp
 		ParentId:  category.ParentID,
 		CreatedAt: category.CreatedAt,
 		UpdatedAt: category.UpdatedAt,
+		Version:   category.Version,
+	}
+}
+
+func mapListRequestToGetAllParams(request DTO.CategoryListRequest, page helpers.Page) queries.GetAllCategoriesParams {
+	return queries.GetAllCategoriesParams{
+		ParentID:    request.ParentId,
+		CreatedFrom: helpers.ToPgTimestamptz(request.CreatedFrom),
+		CreatedTo:   helpers.ToPgTimestamptz(request.CreatedTo),
+		UpdatedFrom: helpers.ToPgTimestamptz(request.UpdatedFrom),
+		UpdatedTo:   helpers.ToPgTimestamptz(request.UpdatedTo),
+		CursorID:    page.CursorId,
+		CursorValue: page.CursorValue,
+		SortBy:      page.SortBy,
+		SortDesc:    page.SortDesc,
+		PageLimit:   page.Limit + 1,
+	}
+}
+
+func mapListRequestToCountParams(request DTO.CategoryListRequest) queries.CountCategoriesParams {
+	return queries.CountCategoriesParams{
+		ParentID:    request.ParentId,
+		CreatedFrom: helpers.ToPgTimestamptz(request.CreatedFrom),
+		CreatedTo:   helpers.ToPgTimestamptz(request.CreatedTo),
+		UpdatedFrom: helpers.ToPgTimestamptz(request.UpdatedFrom),
+		UpdatedTo:   helpers.ToPgTimestamptz(request.UpdatedTo),
 	}
 }
 
@@ -32,6 +59,7 @@ func mapGetAllRowToResponse(category queries.GetAllCategoriesRow) DTO.CategoryRe
 		ParentId:  category.ParentID,
 		CreatedAt: category.CreatedAt,
 		UpdatedAt: category.UpdatedAt,
+		Version:   category.Version,
 	}
 }
 
@@ -43,6 +71,7 @@ func mapGetRowToResponse(category queries.GetCategoryRow) DTO.CategoryResponse {
 		ParentId:  category.ParentID,
 		CreatedAt: category.CreatedAt,
 		UpdatedAt: category.UpdatedAt,
+		Version:   category.Version,
 	}
 }
 
@@ -63,5 +92,101 @@ func mapUpdateRowToResponse(category queries.UpdateCategoryRow) DTO.CategoryResp
 		ParentId:  category.ParentID,
 		CreatedAt: category.CreatedAt,
 		UpdatedAt: category.UpdatedAt,
+		Version:   category.Version,
+	}
+}
+
+func mapPatchRowToResponse(category queries.PatchCategoryRow) DTO.CategoryResponse {
+	return DTO.CategoryResponse{
+		Id:        category.ID,
+		Name:      category.Name,
+		Slug:      category.Slug,
+		ParentId:  category.ParentID,
+		CreatedAt: category.CreatedAt,
+		UpdatedAt: category.UpdatedAt,
+		Version:   category.Version,
+	}
+}
+
+func mapMoveRowToResponse(category queries.MoveCategoryRow) DTO.CategoryResponse {
+	return DTO.CategoryResponse{
+		Id:        category.ID,
+		Name:      category.Name,
+		Slug:      category.Slug,
+		ParentId:  category.ParentID,
+		CreatedAt: category.CreatedAt,
+		UpdatedAt: category.UpdatedAt,
+		Version:   category.Version,
+	}
+}
+
+func mapGetAllFrontRowToFrontResponse(category queries.GetAllFrontCategoriesRow) DTO.FrontCategoryResponse {
+	return DTO.FrontCategoryResponse{
+		Id:       category.ID,
+		Name:     category.Name,
+		Slug:     category.Slug,
+		ParentId: category.ParentID,
+	}
+}
+
+func mapGetTreeRowToFrontResponse(category queries.GetCategoryTreeRow) DTO.FrontCategoryResponse {
+	return DTO.FrontCategoryResponse{
+		Id:       category.ID,
+		Name:     category.Name,
+		Slug:     category.Slug,
+		ParentId: category.ParentID,
+	}
+}
+
+func mapGetFrontBySlugRowToDetailResponse(category queries.GetFrontCategoryBySlugRow) DTO.FrontCategoryDetailResponse {
+	return DTO.FrontCategoryDetailResponse{
+		Id:       category.ID,
+		Name:     category.Name,
+		Slug:     category.Slug,
+		ParentId: category.ParentID,
+	}
+}
+
+func mapGetAncestorsRowToFrontResponse(category queries.GetCategoryAncestorsRow) DTO.FrontCategoryResponse {
+	return DTO.FrontCategoryResponse{
+		Id:       category.ID,
+		Name:     category.Name,
+		Slug:     category.Slug,
+		ParentId: category.ParentID,
+	}
+}
+
+func mapGetDescendantsRowToFrontResponse(category queries.GetCategoryDescendantsRow) DTO.FrontCategoryResponse {
+	return DTO.FrontCategoryResponse{
+		Id:       category.ID,
+		Name:     category.Name,
+		Slug:     category.Slug,
+		ParentId: category.ParentID,
+	}
+}
+
+func mapTrashedRowToResponse(category queries.GetTrashedCategoriesRow) DTO.TrashedCategoryResponse {
+	return DTO.TrashedCategoryResponse{
+		CategoryResponse: DTO.CategoryResponse{
+			Id:        category.ID,
+			Name:      category.Name,
+			Slug:      category.Slug,
+			ParentId:  category.ParentID,
+			CreatedAt: category.CreatedAt,
+			UpdatedAt: category.UpdatedAt,
+		},
+		DeletedAt: category.DeletedAt,
+	}
+}
+
+func mapRestoreRowToResponse(category queries.RestoreCategorySubtreeRow) DTO.CategoryResponse {
+	return DTO.CategoryResponse{
+		Id:        category.ID,
+		Name:      category.Name,
+		Slug:      category.Slug,
+		ParentId:  category.ParentID,
+		CreatedAt: category.CreatedAt,
+		UpdatedAt: category.UpdatedAt,
+		Version:   category.Version,
 	}
 }
@@ -2,8 +2,10 @@ package config
 
 import (
 	"errors"
+	"net/url"
 	"os"
 	"strconv"
+	"time"
 )
 
 const (
@@ -32,6 +34,20 @@ type Config struct {
 	JwtSecret string
 
 	KafkaAddr string
+
+	CategoryMaxDepth int
+
+	TrashRetention time.Duration
+
+	MediaDir     string
+	MediaBaseURL string
+	MediaMaxSize int64
+
+	PriceSchedulerInterval time.Duration
+
+	StorefrontURL string
+	ShopName      string
+	ShopCompany   string
 }
 
 func (c *Config) LoadEnv() error {
@@ -55,6 +71,20 @@ func (c *Config) LoadEnv() error {
 
 	kafkaAddr, kafkaAddrExists := os.LookupEnv("KAFKA_ADDR")
 
+	categoryMaxDepth, categoryMaxDepthExists := os.LookupEnv("CATEGORY_MAX_DEPTH")
+
+	trashRetentionDays, trashRetentionDaysExists := os.LookupEnv("TRASH_RETENTION_DAYS")
+
+	mediaDir, mediaDirExists := os.LookupEnv("MEDIA_DIR")
+	mediaBaseURL, mediaBaseURLExists := os.LookupEnv("MEDIA_BASE_URL")
+	mediaMaxSizeBytes, mediaMaxSizeBytesExists := os.LookupEnv("MEDIA_MAX_SIZE_BYTES")
+
+	priceSchedulerIntervalSeconds, priceSchedulerIntervalSecondsExists := os.LookupEnv("PRICE_SCHEDULER_INTERVAL_SECONDS")
+
+	storefrontURL, storefrontURLExists := os.LookupEnv("STOREFRONT_URL")
+	shopName, shopNameExists := os.LookupEnv("SHOP_NAME")
+	shopCompany, shopCompanyExists := os.LookupEnv("SHOP_COMPANY")
+
 	if !appHostExists {
 		return errors.New("APP_HOST .env isn't set")
 	}
@@ -107,6 +137,38 @@ func (c *Config) LoadEnv() error {
 		return errors.New("KAFKA_ADDR .env isn't set")
 	}
 
+	if !categoryMaxDepthExists {
+		return errors.New("CATEGORY_MAX_DEPTH .env isn't set")
+	}
+
+	if !trashRetentionDaysExists {
+		return errors.New("TRASH_RETENTION_DAYS .env isn't set")
+	}
+
+	if !mediaDirExists {
+		return errors.New("MEDIA_DIR .env isn't set")
+	}
+	if !mediaBaseURLExists {
+		return errors.New("MEDIA_BASE_URL .env isn't set")
+	}
+	if !mediaMaxSizeBytesExists {
+		return errors.New("MEDIA_MAX_SIZE_BYTES .env isn't set")
+	}
+
+	if !priceSchedulerIntervalSecondsExists {
+		return errors.New("PRICE_SCHEDULER_INTERVAL_SECONDS .env isn't set")
+	}
+
+	if !storefrontURLExists {
+		return errors.New("STOREFRONT_URL .env isn't set")
+	}
+	if !shopNameExists {
+		return errors.New("SHOP_NAME .env isn't set")
+	}
+	if !shopCompanyExists {
+		return errors.New("SHOP_COMPANY .env isn't set")
+	}
+
 	intAppPort, err := strconv.Atoi(appPort)
 	if err != nil {
 		return err
@@ -122,6 +184,46 @@ func (c *Config) LoadEnv() error {
 		return err
 	}
 
+	intCategoryMaxDepth, err := strconv.Atoi(categoryMaxDepth)
+	if err != nil {
+		return err
+	}
+	if intCategoryMaxDepth < 1 {
+		return errors.New("CATEGORY_MAX_DEPTH must be at least 1")
+	}
+
+	intTrashRetentionDays, err := strconv.Atoi(trashRetentionDays)
+	if err != nil {
+		return err
+	}
+	if intTrashRetentionDays < 0 {
+		return errors.New("TRASH_RETENTION_DAYS must not be negative")
+	}
+
+	int64MediaMaxSize, err := strconv.ParseInt(mediaMaxSizeBytes, 10, 64)
+	if err != nil {
+		return err
+	}
+	if int64MediaMaxSize < 1 {
+		return errors.New("MEDIA_MAX_SIZE_BYTES must be at least 1")
+	}
+
+	intPriceSchedulerIntervalSeconds, err := strconv.Atoi(priceSchedulerIntervalSeconds)
+	if err != nil {
+		return err
+	}
+	if intPriceSchedulerIntervalSeconds < 1 {
+		return errors.New("PRICE_SCHEDULER_INTERVAL_SECONDS must be at least 1")
+	}
+
+	parsedStorefrontURL, err := url.Parse(storefrontURL)
+	if err != nil {
+		return err
+	}
+	if parsedStorefrontURL.Scheme == "" || parsedStorefrontURL.Host == "" {
+		return errors.New("STOREFRONT_URL must be an absolute url")
+	}
+
 	c.AppHost = appHost
 	c.AppPort = intAppPort
 
@@ -144,5 +246,19 @@ func (c *Config) LoadEnv() error {
 
 	c.KafkaAddr = kafkaAddr
 
+	c.CategoryMaxDepth = intCategoryMaxDepth
+
+	c.TrashRetention = time.Duration(intTrashRetentionDays) * 24 * time.Hour
+
+	c.MediaDir = mediaDir
+	c.MediaBaseURL = mediaBaseURL
+	c.MediaMaxSize = int64MediaMaxSize
+
+	c.PriceSchedulerInterval = time.Duration(intPriceSchedulerIntervalSeconds) * time.Second
+
+	c.StorefrontURL = storefrontURL
+	c.ShopName = shopName
+	c.ShopCompany = shopCompany
+
 	return nil
 }
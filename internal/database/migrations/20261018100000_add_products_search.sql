@@ -0,0 +1,76 @@
+-- +goose Up
+-- +goose StatementBegin
+create extension if not exists pg_trgm;
+
+alter table products
+    add column search_vector tsvector not null default ''::tsvector;
+
+create function products_search_vector_update() returns trigger as
+$$
+begin
+    new.search_vector :=
+            setweight(to_tsvector('russian', new.name), 'A') ||
+            setweight(to_tsvector('russian', coalesce((select name from brands where id = new.brand_id), '')), 'B') ||
+            setweight(to_tsvector('russian', coalesce((select name from categories where id = new.category_id), '')), 'B') ||
+            setweight(to_tsvector('russian', new.description), 'C');
+    return new;
+end
+$$ language plpgsql;
+
+create trigger trg_products_search_vector
+    before insert or update of name, description, brand_id, category_id
+    on products
+    for each row
+execute function products_search_vector_update();
+
+-- brand and category names are part of the products' search vector,
+-- so renaming them has to recalculate it
+create function products_search_vector_refresh_by_brand() returns trigger as
+$$
+begin
+    update products set brand_id = brand_id where brand_id = new.id;
+    return null;
+end
+$$ language plpgsql;
+
+create trigger trg_brands_products_search_vector
+    after update of name
+    on brands
+    for each row
+    when (old.name is distinct from new.name)
+execute function products_search_vector_refresh_by_brand();
+
+create function products_search_vector_refresh_by_category() returns trigger as
+$$
+begin
+    update products set category_id = category_id where category_id = new.id;
+    return null;
+end
+$$ language plpgsql;
+
+create trigger trg_categories_products_search_vector
+    after update of name
+    on categories
+    for each row
+    when (old.name is distinct from new.name)
+execute function products_search_vector_refresh_by_category();
+
+update products set name = name;
+
+create index idx_products_search_vector on products using gin (search_vector);
+create index idx_products_name_trgm on products using gin (name gin_trgm_ops);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+drop index if exists idx_products_name_trgm;
+drop index if exists idx_products_search_vector;
+drop trigger if exists trg_categories_products_search_vector on categories;
+drop function if exists products_search_vector_refresh_by_category();
+drop trigger if exists trg_brands_products_search_vector on brands;
+drop function if exists products_search_vector_refresh_by_brand();
+drop trigger if exists trg_products_search_vector on products;
+drop function if exists products_search_vector_update();
+alter table products
+    drop column if exists search_vector;
+-- +goose StatementEnd
@@ -0,0 +1,30 @@
+-- +goose Up
+-- +goose StatementBegin
+-- every category on a parent_id cycle reaches itself walking up, the one with the smallest id of each
+-- cycle becomes a root, so the walks up and down the tree always end
+with recursive walk as (select id as start_id,
+                               parent_id as id
+                        from categories
+                        where parent_id is not null
+                        union
+                        select walk.start_id,
+                               categories.parent_id
+                        from walk
+                                 join categories on categories.id = walk.id
+                        where categories.parent_id is not null),
+               cycle_roots as (select start_id as id
+                               from walk
+                               group by start_id
+                               having bool_or(id = start_id)
+                                  and min(id) = start_id)
+update categories
+set parent_id  = null,
+    updated_at = now()
+where id in (select id from cycle_roots);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+-- the detached parents aren't recorded, a cycle is never restored
+select 1;
+-- +goose StatementEnd
@@ -0,0 +1,31 @@
+-- +goose Up
+-- +goose StatementBegin
+alter table brands
+    add column deletion_id uuid default null;
+alter table categories
+    add column deletion_id uuid default null;
+alter table products
+    add column deletion_id uuid default null;
+
+create index idx_brands_deleted_at on brands (deleted_at) where deleted_at is not null;
+create index idx_categories_deleted_at on categories (deleted_at) where deleted_at is not null;
+create index idx_products_deleted_at on products (deleted_at) where deleted_at is not null;
+create index idx_categories_deletion_id on categories (deletion_id) where deletion_id is not null;
+create index idx_products_deletion_id on products (deletion_id) where deletion_id is not null;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+drop index if exists idx_products_deletion_id;
+drop index if exists idx_categories_deletion_id;
+drop index if exists idx_products_deleted_at;
+drop index if exists idx_categories_deleted_at;
+drop index if exists idx_brands_deleted_at;
+
+alter table products
+    drop column if exists deletion_id;
+alter table categories
+    drop column if exists deletion_id;
+alter table brands
+    drop column if exists deletion_id;
+-- +goose StatementEnd
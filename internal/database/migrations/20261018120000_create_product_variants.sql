@@ -0,0 +1,89 @@
+-- +goose Up
+-- +goose StatementBegin
+create table option_types
+(
+    id         bigint generated always as identity primary key,
+    name       text        not null unique,
+    created_at timestamptz not null default now()
+);
+
+create table product_variants
+(
+    id           bigint generated always as identity primary key,
+    product_id   bigint      not null,
+    sku          text        not null unique,
+    barcode      text                 default null,
+    price_kopeck int                  default null check ( price_kopeck >= 0 ),
+    is_active    boolean     not null default true,
+    options_key  text        not null,
+    created_at   timestamptz not null default now(),
+    updated_at   timestamptz not null default now(),
+    deleted_at   timestamptz          default null,
+
+    constraint fk_product_variants_product_id
+        foreign key (product_id)
+            references products (id)
+            on delete restrict
+);
+create index idx_product_variants_product_id on product_variants (product_id);
+create unique index product_variants_barcode_key on product_variants (barcode) where barcode is not null;
+create unique index product_variants_options_key on product_variants (product_id, options_key) where deleted_at is null;
+
+create table variant_option_values
+(
+    variant_id     bigint not null,
+    option_type_id bigint not null,
+    value          text   not null,
+
+    primary key (variant_id, option_type_id),
+
+    constraint fk_variant_option_values_variant_id
+        foreign key (variant_id)
+            references product_variants (id)
+            on delete cascade,
+    constraint fk_variant_option_values_option_type_id
+        foreign key (option_type_id)
+            references option_types (id)
+            on delete restrict
+);
+create index idx_variant_option_values_option_type_id on variant_option_values (option_type_id);
+
+alter table inventory_movements
+    add column variant_id bigint default null,
+    add constraint fk_inventory_movements_variant_id
+        foreign key (variant_id)
+            references product_variants (id)
+            on delete restrict;
+create index idx_inventory_movements_variant_id on inventory_movements (variant_id);
+
+alter table product_price_history
+    add column variant_id bigint default null,
+    add constraint fk_product_price_history_variant_id
+        foreign key (variant_id)
+            references product_variants (id)
+            on delete restrict;
+create index idx_product_price_history_variant_id on product_price_history (variant_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+drop index if exists idx_product_price_history_variant_id;
+alter table product_price_history
+    drop constraint if exists fk_product_price_history_variant_id,
+    drop column if exists variant_id;
+
+drop index if exists idx_inventory_movements_variant_id;
+alter table inventory_movements
+    drop constraint if exists fk_inventory_movements_variant_id,
+    drop column if exists variant_id;
+
+drop index if exists idx_variant_option_values_option_type_id;
+drop table if exists variant_option_values;
+
+drop index if exists product_variants_options_key;
+drop index if exists product_variants_barcode_key;
+drop index if exists idx_product_variants_product_id;
+drop table if exists product_variants;
+
+drop table if exists option_types;
+-- +goose StatementEnd
@@ -0,0 +1,54 @@
+-- +goose Up
+-- +goose StatementBegin
+create table attribute_definitions
+(
+    id            bigint generated always as identity primary key,
+    category_id   bigint      not null,
+    code          text        not null,
+    name          text        not null,
+    type          text        not null check ( type in ('string', 'number', 'boolean', 'enum') ),
+    unit          text                 default null,
+    enum_values   text[]      not null default '{}',
+    is_required   boolean     not null default false,
+    is_filterable boolean     not null default true,
+    created_at    timestamptz not null default now(),
+    updated_at    timestamptz not null default now(),
+
+    constraint attribute_definitions_category_id_code_key
+        unique (category_id, code),
+    constraint fk_attribute_definitions_category_id
+        foreign key (category_id)
+            references categories (id)
+            on delete cascade
+);
+create index idx_attribute_definitions_code on attribute_definitions (code);
+
+create table product_attribute_values
+(
+    product_id   bigint not null,
+    attribute_id bigint not null,
+    value        text   not null,
+    value_number double precision default null,
+
+    primary key (product_id, attribute_id),
+
+    constraint fk_product_attribute_values_product_id
+        foreign key (product_id)
+            references products (id)
+            on delete cascade,
+    constraint fk_product_attribute_values_attribute_id
+        foreign key (attribute_id)
+            references attribute_definitions (id)
+            on delete cascade
+);
+create index idx_product_attribute_values_attribute_id_value on product_attribute_values (attribute_id, value);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+drop index if exists idx_product_attribute_values_attribute_id_value;
+drop table if exists product_attribute_values;
+
+drop index if exists idx_attribute_definitions_code;
+drop table if exists attribute_definitions;
+-- +goose StatementEnd
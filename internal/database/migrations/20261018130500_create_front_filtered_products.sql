@@ -0,0 +1,64 @@
+-- +goose Up
+-- +goose StatementBegin
+-- front_filtered_products is the filter of the storefront product list: the active products of the
+-- category subtree, of the brands and with the attribute values and ranges asked for. The filter of the
+-- except_code attribute is skipped, the facet of the attribute counts its values over the products
+-- matching every other filter.
+create function front_filtered_products(category_id bigint,
+                                        brand_ids bigint[],
+                                        filter_codes text[],
+                                        filter_pairs text[],
+                                        range_codes text[],
+                                        range_mins float8[],
+                                        range_maxs float8[],
+                                        except_code text) returns setof bigint as
+$$
+with recursive subtree as (select categories.id
+                           from categories
+                           where categories.id = front_filtered_products.category_id
+                             and categories.deleted_at is null
+                           union
+                           select categories.id
+                           from categories
+                                    join subtree on categories.parent_id = subtree.id
+                           where categories.deleted_at is null)
+select products.id
+from products
+         join brands on products.brand_id = brands.id
+         join categories on products.category_id = categories.id
+where products.deleted_at is null
+  and products.is_active = true
+  and brands.deleted_at is null
+  and categories.deleted_at is null
+  and (front_filtered_products.category_id is null or products.category_id in (select subtree.id from subtree))
+  and (cardinality(front_filtered_products.brand_ids) = 0 or products.brand_id = any (front_filtered_products.brand_ids))
+  and not exists(select 1
+                 from unnest(front_filtered_products.filter_codes) as filter(code)
+                 where filter.code is distinct from front_filtered_products.except_code
+                   and not exists(select 1
+                                  from product_attribute_values
+                                           join attribute_definitions
+                                                on product_attribute_values.attribute_id = attribute_definitions.id
+                                  where product_attribute_values.product_id = products.id
+                                    and attribute_definitions.code = filter.code
+                                    and attribute_definitions.code || ':' || product_attribute_values.value =
+                                        any (front_filtered_products.filter_pairs)))
+  and not exists(select 1
+                 from generate_subscripts(front_filtered_products.range_codes, 1) as filter(i)
+                 where front_filtered_products.range_codes[filter.i] is distinct from front_filtered_products.except_code
+                   and not exists(select 1
+                                  from product_attribute_values
+                                           join attribute_definitions
+                                                on product_attribute_values.attribute_id = attribute_definitions.id
+                                  where product_attribute_values.product_id = products.id
+                                    and attribute_definitions.code = front_filtered_products.range_codes[filter.i]
+                                    and product_attribute_values.value_number
+                                      between front_filtered_products.range_mins[filter.i]
+                                      and front_filtered_products.range_maxs[filter.i]))
+$$ language sql stable;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+drop function if exists front_filtered_products(bigint, bigint[], text[], text[], text[], float8[], float8[], text);
+-- +goose StatementEnd
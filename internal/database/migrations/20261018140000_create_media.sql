@@ -0,0 +1,44 @@
+-- +goose Up
+-- +goose StatementBegin
+create table media
+(
+    id             bigint generated always as identity primary key,
+    product_id     bigint               default null,
+    brand_id       bigint               default null,
+    storage_key    text        not null,
+    content_type   text        not null,
+    size_bytes     bigint      not null,
+    width          integer     not null,
+    height         integer     not null,
+    thumbnail_keys jsonb       not null default '{}',
+    position       integer     not null default 0,
+    is_primary     boolean     not null default false,
+    created_at     timestamptz not null default now(),
+
+    constraint media_storage_key_key
+        unique (storage_key),
+    constraint media_owner_check
+        check ( num_nonnulls(product_id, brand_id) = 1 ),
+    constraint fk_media_product_id
+        foreign key (product_id)
+            references products (id)
+            on delete cascade,
+    constraint fk_media_brand_id
+        foreign key (brand_id)
+            references brands (id)
+            on delete cascade
+);
+create index idx_media_product_id_position on media (product_id, position) where product_id is not null;
+create index idx_media_brand_id_position on media (brand_id, position) where brand_id is not null;
+create unique index media_product_id_primary_key on media (product_id) where is_primary;
+create unique index media_brand_id_primary_key on media (brand_id) where is_primary;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+drop index if exists media_brand_id_primary_key;
+drop index if exists media_product_id_primary_key;
+drop index if exists idx_media_brand_id_position;
+drop index if exists idx_media_product_id_position;
+drop table if exists media;
+-- +goose StatementEnd
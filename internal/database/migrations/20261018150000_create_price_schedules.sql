@@ -0,0 +1,80 @@
+-- +goose Up
+-- +goose StatementBegin
+create table price_schedules
+(
+    id               bigint generated always as identity primary key,
+    product_id       bigint               default null,
+    brand_id         bigint               default null,
+    category_id      bigint               default null,
+    new_price_kopeck int         not null check ( new_price_kopeck >= 0 ),
+    starts_at        timestamptz not null,
+    ends_at          timestamptz          default null,
+    status           text        not null default 'pending' check ( status in ('pending', 'applied', 'completed', 'failed') ),
+    applied_at       timestamptz          default null,
+    reverted_at      timestamptz          default null,
+    created_by       bigint      not null,
+    created_at       timestamptz not null default now(),
+    updated_at       timestamptz not null default now(),
+    -- failed attempts to apply or revert the schedule in a row, retried from retry_at on
+    attempts         int         not null default 0,
+    last_error       text                 default null,
+    retry_at         timestamptz          default null,
+    -- status of a failed schedule before it failed, pending if applying it failed and applied if reverting did
+    failed_status    text                 default null check ( failed_status in ('pending', 'applied') ),
+
+    constraint price_schedules_scope_check
+        check ( num_nonnulls(product_id, brand_id, category_id) = 1 ),
+    constraint price_schedules_period_check
+        check ( ends_at is null or ends_at > starts_at ),
+    constraint price_schedules_failed_status_check
+        check ( (status = 'failed') = (failed_status is not null) ),
+    constraint fk_price_schedules_product_id
+        foreign key (product_id)
+            references products (id)
+            on delete cascade,
+    constraint fk_price_schedules_brand_id
+        foreign key (brand_id)
+            references brands (id)
+            on delete cascade,
+    constraint fk_price_schedules_category_id
+        foreign key (category_id)
+            references categories (id)
+            on delete cascade,
+    constraint fk_price_schedules_created_by
+        foreign key (created_by)
+            references admin_users (id)
+            on delete restrict
+);
+create index idx_price_schedules_pending_starts_at on price_schedules (starts_at) where status = 'pending';
+create index idx_price_schedules_applied_ends_at on price_schedules (ends_at) where status = 'applied';
+
+-- products the schedule changed and their prices before, so the change can be reverted at ends_at
+create table price_schedule_items
+(
+    schedule_id      bigint not null,
+    product_id       bigint not null,
+    old_price_kopeck int    not null check ( old_price_kopeck >= 0 ),
+
+    primary key (schedule_id, product_id),
+
+    constraint fk_price_schedule_items_schedule_id
+        foreign key (schedule_id)
+            references price_schedules (id)
+            on delete cascade,
+    constraint fk_price_schedule_items_product_id
+        foreign key (product_id)
+            references products (id)
+            on delete cascade
+);
+create index idx_price_schedule_items_product_id on price_schedule_items (product_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+drop index if exists idx_price_schedule_items_product_id;
+drop table if exists price_schedule_items;
+
+drop index if exists idx_price_schedules_applied_ends_at;
+drop index if exists idx_price_schedules_pending_starts_at;
+drop table if exists price_schedules;
+-- +goose StatementEnd
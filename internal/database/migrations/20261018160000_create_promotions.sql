@@ -0,0 +1,50 @@
+-- +goose Up
+-- +goose StatementBegin
+create table promotions
+(
+    id           bigint generated always as identity primary key,
+    name         text        not null,
+    type         text        not null check ( type in ('percent', 'fixed') ),
+    value        int         not null check ( value > 0 ),
+    product_id   bigint               default null,
+    brand_id     bigint               default null,
+    category_id  bigint               default null,
+    starts_at    timestamptz not null,
+    ends_at      timestamptz          default null,
+    priority     int         not null default 0,
+    is_stackable boolean     not null default false,
+    is_active    boolean     not null default true,
+    created_at   timestamptz not null default now(),
+    updated_at   timestamptz not null default now(),
+
+    constraint promotions_percent_value_check
+        check ( type <> 'percent' or value <= 100 ),
+    constraint promotions_scope_check
+        check ( num_nonnulls(product_id, brand_id, category_id) = 1 ),
+    constraint promotions_period_check
+        check ( ends_at is null or ends_at > starts_at ),
+    constraint fk_promotions_product_id
+        foreign key (product_id)
+            references products (id)
+            on delete cascade,
+    constraint fk_promotions_brand_id
+        foreign key (brand_id)
+            references brands (id)
+            on delete cascade,
+    constraint fk_promotions_category_id
+        foreign key (category_id)
+            references categories (id)
+            on delete cascade
+);
+create index idx_promotions_product_id on promotions (product_id) where product_id is not null;
+create index idx_promotions_brand_id on promotions (brand_id) where brand_id is not null;
+create index idx_promotions_category_id on promotions (category_id) where category_id is not null;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+drop index if exists idx_promotions_category_id;
+drop index if exists idx_promotions_brand_id;
+drop index if exists idx_promotions_product_id;
+drop table if exists promotions;
+-- +goose StatementEnd
@@ -0,0 +1,9 @@
+-- +goose Up
+-- +goose StatementBegin
+create index idx_product_price_history_created_at on product_price_history (created_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+drop index if exists idx_product_price_history_created_at;
+-- +goose StatementEnd
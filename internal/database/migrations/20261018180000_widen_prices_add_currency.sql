@@ -0,0 +1,61 @@
+-- +goose Up
+-- +goose StatementBegin
+-- int -> bigint is a lossless widening, existing prices keep their values and become RUB amounts in kopecks
+alter table products
+    alter column price_kopeck type bigint,
+    add column currency text not null default 'RUB'
+        constraint products_currency_check check ( currency ~ '^[A-Z]{3}$' );
+alter table product_variants
+    alter column price_kopeck type bigint;
+alter table product_price_history
+    alter column old_price_kopeck type bigint,
+    alter column new_price_kopeck type bigint,
+    add column currency text not null default 'RUB'
+        constraint product_price_history_currency_check check ( currency ~ '^[A-Z]{3}$' );
+alter table price_schedules
+    alter column new_price_kopeck type bigint,
+    add column currency text not null default 'RUB'
+        constraint price_schedules_currency_check check ( currency ~ '^[A-Z]{3}$' );
+alter table price_schedule_items
+    alter column old_price_kopeck type bigint;
+alter table promotions
+    alter column value type bigint,
+    add column currency text default null
+        constraint promotions_currency_check check ( currency ~ '^[A-Z]{3}$' );
+update promotions
+set currency = 'RUB'
+where type = 'fixed';
+alter table promotions
+    add constraint promotions_fixed_currency_check check ( (type = 'fixed') = (currency is not null) );
+
+comment on column products.price_kopeck is 'price in minor units of currency';
+comment on column product_variants.price_kopeck is 'price in minor units of the product currency';
+comment on column product_price_history.old_price_kopeck is 'price in minor units of currency';
+comment on column product_price_history.new_price_kopeck is 'price in minor units of currency';
+comment on column price_schedules.new_price_kopeck is 'price in minor units of currency';
+comment on column price_schedule_items.old_price_kopeck is 'price in minor units of the product currency';
+comment on column promotions.value is 'percent, or discount in minor units of currency for fixed promotions';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+-- narrowing back fails instead of truncating if a price doesn't fit into int anymore
+alter table promotions
+    drop constraint if exists promotions_fixed_currency_check,
+    drop column if exists currency,
+    alter column value type int;
+alter table price_schedule_items
+    alter column old_price_kopeck type int;
+alter table price_schedules
+    drop column if exists currency,
+    alter column new_price_kopeck type int;
+alter table product_price_history
+    drop column if exists currency,
+    alter column old_price_kopeck type int,
+    alter column new_price_kopeck type int;
+alter table product_variants
+    alter column price_kopeck type int;
+alter table products
+    drop column if exists currency,
+    alter column price_kopeck type int;
+-- +goose StatementEnd
@@ -0,0 +1,69 @@
+-- +goose Up
+-- +goose StatementBegin
+create table exchange_rates
+(
+    id             bigint generated always as identity primary key,
+    base_currency  text           not null check ( base_currency ~ '^[A-Z]{3}$' ),
+    quote_currency text           not null check ( quote_currency ~ '^[A-Z]{3}$' ),
+    -- units of the quote currency for one unit of the base currency
+    rate           numeric(20, 10) not null check ( rate > 0 ),
+    created_at     timestamptz    not null default now(),
+    updated_at     timestamptz    not null default now(),
+
+    constraint exchange_rates_currencies_key
+        unique (base_currency, quote_currency),
+    constraint exchange_rates_distinct_check
+        check ( base_currency <> quote_currency )
+);
+
+create table price_lists
+(
+    id             bigint generated always as identity primary key,
+    code           text        not null,
+    name           text        not null,
+    currency       text        not null check ( currency ~ '^[A-Z]{3}$' ),
+    customer_group text                 default null,
+    -- derived prices are rounded to a multiple of the step in minor units, e.g. 100 for whole roubles
+    rounding_step  bigint      not null default 1 check ( rounding_step > 0 ),
+    rounding_mode  text        not null default 'half_up' check ( rounding_mode in ('half_up', 'up', 'down') ),
+    is_active      boolean     not null default true,
+    created_at     timestamptz not null default now(),
+    updated_at     timestamptz not null default now(),
+
+    constraint price_lists_code_key
+        unique (code)
+);
+
+-- the list the storefront uses when only a currency is asked for
+create unique index price_lists_default_currency_key on price_lists (currency) where customer_group is null and is_active;
+
+create table price_list_items
+(
+    price_list_id bigint      not null,
+    product_id    bigint      not null,
+    amount        bigint      not null check ( amount >= 0 ),
+    created_at    timestamptz not null default now(),
+    updated_at    timestamptz not null default now(),
+
+    primary key (price_list_id, product_id),
+    constraint fk_price_list_items_price_list_id
+        foreign key (price_list_id)
+            references price_lists (id)
+            on delete cascade,
+    constraint fk_price_list_items_product_id
+        foreign key (product_id)
+            references products (id)
+            on delete cascade
+);
+
+create index idx_price_list_items_product_id on price_list_items (product_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+drop index if exists idx_price_list_items_product_id;
+drop table if exists price_list_items;
+drop index if exists price_lists_default_currency_key;
+drop table if exists price_lists;
+drop table if exists exchange_rates;
+-- +goose StatementEnd
@@ -0,0 +1,81 @@
+-- +goose Up
+-- +goose StatementBegin
+-- a single row counter bumped by every transaction changing what the feeds are built from,
+-- so a cached feed is known to be stale without rebuilding it. The bump is part of the transaction,
+-- so nobody sees the new version before the change it stands for. Deferred triggers bump it at
+-- commit and once per transaction, so the row is locked only while the transaction commits
+create table catalog_version
+(
+    id         boolean primary key default true,
+    version    bigint      not null default 0,
+    updated_at timestamptz not null default now(),
+    constraint chk_catalog_version_single_row check (id)
+);
+
+insert into catalog_version default values;
+
+create function catalog_version_bump() returns trigger as
+$$
+begin
+    if current_setting('catalog_version.bumped', true) is distinct from 'on' then
+        perform set_config('catalog_version.bumped', 'on', true);
+        update catalog_version set version = version + 1, updated_at = now();
+    end if;
+    return null;
+end
+$$ language plpgsql;
+
+create constraint trigger trg_products_catalog_version
+    after insert or update or delete
+    on products
+    deferrable initially deferred
+    for each row
+execute function catalog_version_bump();
+
+create constraint trigger trg_brands_catalog_version
+    after insert or update or delete
+    on brands
+    deferrable initially deferred
+    for each row
+execute function catalog_version_bump();
+
+create constraint trigger trg_categories_catalog_version
+    after insert or update or delete
+    on categories
+    deferrable initially deferred
+    for each row
+execute function catalog_version_bump();
+
+create constraint trigger trg_inventory_movements_catalog_version
+    after insert or update or delete
+    on inventory_movements
+    deferrable initially deferred
+    for each row
+execute function catalog_version_bump();
+
+create constraint trigger trg_media_catalog_version
+    after insert or update or delete
+    on media
+    deferrable initially deferred
+    for each row
+execute function catalog_version_bump();
+
+create constraint trigger trg_promotions_catalog_version
+    after insert or update or delete
+    on promotions
+    deferrable initially deferred
+    for each row
+execute function catalog_version_bump();
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+drop trigger if exists trg_promotions_catalog_version on promotions;
+drop trigger if exists trg_media_catalog_version on media;
+drop trigger if exists trg_inventory_movements_catalog_version on inventory_movements;
+drop trigger if exists trg_categories_catalog_version on categories;
+drop trigger if exists trg_brands_catalog_version on brands;
+drop trigger if exists trg_products_catalog_version on products;
+drop function if exists catalog_version_bump();
+drop table if exists catalog_version;
+-- +goose StatementEnd
@@ -0,0 +1,39 @@
+-- +goose Up
+-- +goose StatementBegin
+create table slug_history
+(
+    id          bigint generated always as identity primary key,
+    slug        text        not null,
+    brand_id    bigint               default null,
+    category_id bigint               default null,
+    product_id  bigint               default null,
+    created_at  timestamptz not null default now(),
+
+    constraint slug_history_owner_check
+        check ( num_nonnulls(brand_id, category_id, product_id) = 1 ),
+    constraint fk_slug_history_brand_id
+        foreign key (brand_id)
+            references brands (id)
+            on delete cascade,
+    constraint fk_slug_history_category_id
+        foreign key (category_id)
+            references categories (id)
+            on delete cascade,
+    constraint fk_slug_history_product_id
+        foreign key (product_id)
+            references products (id)
+            on delete cascade
+);
+-- an old slug leads to one place, the current slugs are unique in their own tables
+create unique index slug_history_brand_slug_key on slug_history (slug) where brand_id is not null;
+create unique index slug_history_category_slug_key on slug_history (slug) where category_id is not null;
+create unique index slug_history_product_slug_key on slug_history (slug) where product_id is not null;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+drop index if exists slug_history_product_slug_key;
+drop index if exists slug_history_category_slug_key;
+drop index if exists slug_history_brand_slug_key;
+drop table if exists slug_history;
+-- +goose StatementEnd
@@ -0,0 +1,53 @@
+-- +goose Up
+-- +goose StatementBegin
+alter table brands
+    add column version bigint not null default 1;
+alter table categories
+    add column version bigint not null default 1;
+alter table products
+    add column version bigint not null default 1;
+
+-- every update of a row the client can see the change of gives it a new version, whatever query makes it.
+-- search_vector isn't in any response, so refreshing it after a brand or category rename is no new version
+create function bump_version() returns trigger as
+$$
+begin
+    if to_jsonb(new) - 'version' - 'search_vector' is distinct from to_jsonb(old) - 'version' - 'search_vector' then
+        new.version := old.version + 1;
+    end if;
+    return new;
+end
+$$ language plpgsql;
+
+create trigger trg_brands_version
+    before update
+    on brands
+    for each row
+execute function bump_version();
+
+create trigger trg_categories_version
+    before update
+    on categories
+    for each row
+execute function bump_version();
+
+create trigger trg_products_version
+    before update
+    on products
+    for each row
+execute function bump_version();
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+drop trigger if exists trg_products_version on products;
+drop trigger if exists trg_categories_version on categories;
+drop trigger if exists trg_brands_version on brands;
+drop function if exists bump_version();
+alter table products
+    drop column if exists version;
+alter table categories
+    drop column if exists version;
+alter table brands
+    drop column if exists version;
+-- +goose StatementEnd
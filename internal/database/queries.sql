@@ -3,16 +3,59 @@ select id,
        name,
        slug,
        created_at,
-       updated_at
+       updated_at,
+       version
+from brands
+where deleted_at is null
+  and (sqlc.narg(created_from)::timestamptz is null or created_at >= sqlc.narg(created_from))
+  and (sqlc.narg(created_to)::timestamptz is null or created_at <= sqlc.narg(created_to))
+  and (sqlc.narg(updated_from)::timestamptz is null or updated_at >= sqlc.narg(updated_from))
+  and (sqlc.narg(updated_to)::timestamptz is null or updated_at <= sqlc.narg(updated_to))
+  and (sqlc.narg(cursor_id)::bigint is null
+    or (not sqlc.arg(sort_desc)::boolean and case sqlc.arg(sort_by)::text
+                 when 'name' then (name, id) > (sqlc.narg(cursor_value)::text, sqlc.narg(cursor_id)::bigint)
+                 when 'created_at' then (created_at, id) > (sqlc.narg(cursor_value)::text::timestamptz, sqlc.narg(cursor_id)::bigint)
+                 when 'updated_at' then (updated_at, id) > (sqlc.narg(cursor_value)::text::timestamptz, sqlc.narg(cursor_id)::bigint)
+                 else id > sqlc.narg(cursor_id)::bigint end)
+    or (sqlc.arg(sort_desc)::boolean and case sqlc.arg(sort_by)::text
+                 when 'name' then (name, id) < (sqlc.narg(cursor_value)::text, sqlc.narg(cursor_id)::bigint)
+                 when 'created_at' then (created_at, id) < (sqlc.narg(cursor_value)::text::timestamptz, sqlc.narg(cursor_id)::bigint)
+                 when 'updated_at' then (updated_at, id) < (sqlc.narg(cursor_value)::text::timestamptz, sqlc.narg(cursor_id)::bigint)
+                 else id < sqlc.narg(cursor_id)::bigint end))
+order by case when sqlc.arg(sort_by)::text = 'name' and not sqlc.arg(sort_desc)::boolean then name end,
+         case when sqlc.arg(sort_by)::text = 'name' and sqlc.arg(sort_desc)::boolean then name end desc,
+         case when sqlc.arg(sort_by)::text = 'created_at' and not sqlc.arg(sort_desc)::boolean then created_at end,
+         case when sqlc.arg(sort_by)::text = 'created_at' and sqlc.arg(sort_desc)::boolean then created_at end desc,
+         case when sqlc.arg(sort_by)::text = 'updated_at' and not sqlc.arg(sort_desc)::boolean then updated_at end,
+         case when sqlc.arg(sort_by)::text = 'updated_at' and sqlc.arg(sort_desc)::boolean then updated_at end desc,
+         case when not sqlc.arg(sort_desc)::boolean then id end,
+         case when sqlc.arg(sort_desc)::boolean then id end desc
+limit sqlc.arg(page_limit);
+
+-- name: CountBrands :one
+select count(*)
+from brands
+where deleted_at is null
+  and (sqlc.narg(created_from)::timestamptz is null or created_at >= sqlc.narg(created_from))
+  and (sqlc.narg(created_to)::timestamptz is null or created_at <= sqlc.narg(created_to))
+  and (sqlc.narg(updated_from)::timestamptz is null or updated_at >= sqlc.narg(updated_from))
+  and (sqlc.narg(updated_to)::timestamptz is null or updated_at <= sqlc.narg(updated_to));
+
+-- name: GetAllFrontBrands :many
+select id,
+       name,
+       slug
 from brands
-where deleted_at is null;
+where deleted_at is null
+order by name;
 
 -- name: GetBrand :one
 select id,
        name,
        slug,
        created_at,
-       updated_at
+       updated_at,
+       version
 from brands
 where id = $1
   and deleted_at is null
@@ -27,37 +70,133 @@ returning id,
     name,
     slug,
     created_at,
-    updated_at;
+    updated_at,
+    version;
 
 -- name: UpdateBrand :one
 update brands
 SET name       = $2,
     slug       = $3,
-    updated_at = now()
+    updated_at = case when (name, slug) is distinct from ($2, $3) then now() else updated_at end
 where id = $1
   and deleted_at is null
 returning id,
     name,
     slug,
     created_at,
-    updated_at;
+    updated_at,
+    version;
 
 -- name: DeleteBrand :execrows
 update brands
-set deleted_at = now(),
-    updated_at = now()
-where id = $1
+set deleted_at  = now(),
+    deletion_id = sqlc.arg(deletion_id)::uuid,
+    updated_at  = now()
+where id = sqlc.arg(id)
   and deleted_at is null;
 
+-- name: GetTrashedBrands :many
+select id,
+       name,
+       slug,
+       created_at,
+       updated_at,
+       deleted_at::timestamptz as deleted_at
+from brands
+where deleted_at is not null
+  and (sqlc.narg(cursor_id)::bigint is null
+    or (not sqlc.arg(sort_desc)::boolean and case sqlc.arg(sort_by)::text
+                 when 'deleted_at' then (deleted_at, id) > (sqlc.narg(cursor_value)::text::timestamptz, sqlc.narg(cursor_id)::bigint)
+                 else id > sqlc.narg(cursor_id)::bigint end)
+    or (sqlc.arg(sort_desc)::boolean and case sqlc.arg(sort_by)::text
+                 when 'deleted_at' then (deleted_at, id) < (sqlc.narg(cursor_value)::text::timestamptz, sqlc.narg(cursor_id)::bigint)
+                 else id < sqlc.narg(cursor_id)::bigint end))
+order by case when sqlc.arg(sort_by)::text = 'deleted_at' and not sqlc.arg(sort_desc)::boolean then deleted_at end,
+         case when sqlc.arg(sort_by)::text = 'deleted_at' and sqlc.arg(sort_desc)::boolean then deleted_at end desc,
+         case when not sqlc.arg(sort_desc)::boolean then id end,
+         case when sqlc.arg(sort_desc)::boolean then id end desc
+limit sqlc.arg(page_limit);
+
+-- name: CountTrashedBrands :one
+select count(*)
+from brands
+where deleted_at is not null;
+
+-- name: LockTrashedBrand :one
+select deletion_id
+from brands
+where id = $1
+  and deleted_at is not null
+    for update;
+
+-- name: RestoreBrand :one
+update brands
+set deleted_at  = null,
+    deletion_id = null,
+    updated_at  = now()
+where id = $1
+  and deleted_at is not null
+returning id,
+    name,
+    slug,
+    created_at,
+    updated_at,
+    version;
+
 -- name: GetAllCategories :many
 select id,
        name,
        slug,
        parent_id,
        created_at,
-       updated_at
+       updated_at,
+       version
+from categories
+where deleted_at is null
+  and (sqlc.narg(parent_id)::bigint is null or parent_id = sqlc.narg(parent_id))
+  and (sqlc.narg(created_from)::timestamptz is null or created_at >= sqlc.narg(created_from))
+  and (sqlc.narg(created_to)::timestamptz is null or created_at <= sqlc.narg(created_to))
+  and (sqlc.narg(updated_from)::timestamptz is null or updated_at >= sqlc.narg(updated_from))
+  and (sqlc.narg(updated_to)::timestamptz is null or updated_at <= sqlc.narg(updated_to))
+  and (sqlc.narg(cursor_id)::bigint is null
+    or (not sqlc.arg(sort_desc)::boolean and case sqlc.arg(sort_by)::text
+                 when 'name' then (name, id) > (sqlc.narg(cursor_value)::text, sqlc.narg(cursor_id)::bigint)
+                 when 'created_at' then (created_at, id) > (sqlc.narg(cursor_value)::text::timestamptz, sqlc.narg(cursor_id)::bigint)
+                 when 'updated_at' then (updated_at, id) > (sqlc.narg(cursor_value)::text::timestamptz, sqlc.narg(cursor_id)::bigint)
+                 else id > sqlc.narg(cursor_id)::bigint end)
+    or (sqlc.arg(sort_desc)::boolean and case sqlc.arg(sort_by)::text
+                 when 'name' then (name, id) < (sqlc.narg(cursor_value)::text, sqlc.narg(cursor_id)::bigint)
+                 when 'created_at' then (created_at, id) < (sqlc.narg(cursor_value)::text::timestamptz, sqlc.narg(cursor_id)::bigint)
+                 when 'updated_at' then (updated_at, id) < (sqlc.narg(cursor_value)::text::timestamptz, sqlc.narg(cursor_id)::bigint)
+                 else id < sqlc.narg(cursor_id)::bigint end))
+order by case when sqlc.arg(sort_by)::text = 'name' and not sqlc.arg(sort_desc)::boolean then name end,
+         case when sqlc.arg(sort_by)::text = 'name' and sqlc.arg(sort_desc)::boolean then name end desc,
+         case when sqlc.arg(sort_by)::text = 'created_at' and not sqlc.arg(sort_desc)::boolean then created_at end,
+         case when sqlc.arg(sort_by)::text = 'created_at' and sqlc.arg(sort_desc)::boolean then created_at end desc,
+         case when sqlc.arg(sort_by)::text = 'updated_at' and not sqlc.arg(sort_desc)::boolean then updated_at end,
+         case when sqlc.arg(sort_by)::text = 'updated_at' and sqlc.arg(sort_desc)::boolean then updated_at end desc,
+         case when not sqlc.arg(sort_desc)::boolean then id end,
+         case when sqlc.arg(sort_desc)::boolean then id end desc
+limit sqlc.arg(page_limit);
+
+-- name: CountCategories :one
+select count(*)
 from categories
-where deleted_at is null;
+where deleted_at is null
+  and (sqlc.narg(parent_id)::bigint is null or parent_id = sqlc.narg(parent_id))
+  and (sqlc.narg(created_from)::timestamptz is null or created_at >= sqlc.narg(created_from))
+  and (sqlc.narg(created_to)::timestamptz is null or created_at <= sqlc.narg(created_to))
+  and (sqlc.narg(updated_from)::timestamptz is null or updated_at >= sqlc.narg(updated_from))
+  and (sqlc.narg(updated_to)::timestamptz is null or updated_at <= sqlc.narg(updated_to));
+
+-- name: GetAllFrontCategories :many
+select id,
+       name,
+       slug,
+       parent_id
+from categories
+where deleted_at is null
+order by name;
 
 -- name: GetCategory :one
 select id,
@@ -65,7 +204,8 @@ select id,
        slug,
        parent_id,
        created_at,
-       updated_at
+       updated_at,
+       version
 from categories
 where id = $1
   and deleted_at is null
@@ -83,13 +223,28 @@ returning id,
     slug,
     parent_id,
     created_at,
-    updated_at;
+    updated_at,
+    version;
 
 -- name: UpdateCategory :one
 update categories
 SET name       = $2,
     slug       = $3,
     parent_id  = $4,
+    updated_at = case when (name, slug, parent_id) is distinct from ($2, $3, $4) then now() else updated_at end
+where id = $1
+  and deleted_at is null
+returning id,
+    name,
+    slug,
+    parent_id,
+    created_at,
+    updated_at,
+    version;
+
+-- name: MoveCategory :one
+update categories
+SET parent_id  = $2,
     updated_at = now()
 where id = $1
   and deleted_at is null
@@ -98,7 +253,28 @@ returning id,
     slug,
     parent_id,
     created_at,
-    updated_at;
+    updated_at,
+    version;
+
+-- name: LockCategoryTree :exec
+select pg_advisory_xact_lock(hashtext('categories_tree'));
+
+-- name: GetCategorySubtreeHeight :one
+with recursive descendants as (select id,
+                                      1 as depth
+                               from categories
+                               where categories.parent_id = sqlc.arg(id)::bigint
+                                 and deleted_at is null
+                               union all
+                               select categories.id,
+                                      descendants.depth + 1
+                               from categories
+                                        join descendants on categories.parent_id = descendants.id
+                               where categories.deleted_at is null)
+    cycle id set is_cycle using path
+select coalesce(max(depth), 0)::int as height
+from descendants
+where not is_cycle;
 
 -- name: DeleteCategory :execrows
 update categories
@@ -107,11 +283,161 @@ set deleted_at = now(),
 where id = $1
   and deleted_at is null;
 
--- name: GetCategoriesByParentId :many
-select id
+-- name: GetTrashedCategories :many
+select id,
+       name,
+       slug,
+       parent_id,
+       created_at,
+       updated_at,
+       deleted_at::timestamptz as deleted_at
 from categories
-where parent_id = $1
-  and deleted_at is null;
+where deleted_at is not null
+  and (sqlc.narg(cursor_id)::bigint is null
+    or (not sqlc.arg(sort_desc)::boolean and case sqlc.arg(sort_by)::text
+                 when 'deleted_at' then (deleted_at, id) > (sqlc.narg(cursor_value)::text::timestamptz, sqlc.narg(cursor_id)::bigint)
+                 else id > sqlc.narg(cursor_id)::bigint end)
+    or (sqlc.arg(sort_desc)::boolean and case sqlc.arg(sort_by)::text
+                 when 'deleted_at' then (deleted_at, id) < (sqlc.narg(cursor_value)::text::timestamptz, sqlc.narg(cursor_id)::bigint)
+                 else id < sqlc.narg(cursor_id)::bigint end))
+order by case when sqlc.arg(sort_by)::text = 'deleted_at' and not sqlc.arg(sort_desc)::boolean then deleted_at end,
+         case when sqlc.arg(sort_by)::text = 'deleted_at' and sqlc.arg(sort_desc)::boolean then deleted_at end desc,
+         case when not sqlc.arg(sort_desc)::boolean then id end,
+         case when sqlc.arg(sort_desc)::boolean then id end desc
+limit sqlc.arg(page_limit);
+
+-- name: CountTrashedCategories :one
+select count(*)
+from categories
+where deleted_at is not null;
+
+-- name: LockTrashedCategory :one
+select categories.deletion_id,
+       (parents.id is not null and parents.deleted_at is not null)::boolean as parent_deleted
+from categories
+         left join categories parents on parents.id = categories.parent_id
+where categories.id = $1
+  and categories.deleted_at is not null
+    for update of categories;
+
+-- name: RestoreCategorySubtree :many
+with recursive subtree as (select id
+                           from categories
+                           where categories.id = sqlc.arg(id)
+                           union
+                           select categories.id
+                           from categories
+                                    join subtree on categories.parent_id = subtree.id
+                           where categories.deletion_id = sqlc.arg(deletion_id)::uuid)
+update categories
+set deleted_at  = null,
+    deletion_id = null,
+    updated_at  = now()
+where id in (select id from subtree)
+returning id,
+    name,
+    slug,
+    parent_id,
+    created_at,
+    updated_at,
+    version;
+
+-- name: GetCategoryTree :many
+with recursive tree as (select id,
+                               name,
+                               slug,
+                               parent_id,
+                               0 as depth
+                        from categories
+                        where parent_id is null
+                          and deleted_at is null
+                        union all
+                        select categories.id,
+                               categories.name,
+                               categories.slug,
+                               categories.parent_id,
+                               tree.depth + 1
+                        from categories
+                                 join tree on categories.parent_id = tree.id
+                        where categories.deleted_at is null)
+    cycle id set is_cycle using path
+select id,
+       name,
+       slug,
+       parent_id
+from tree
+where not is_cycle
+order by depth, name;
+
+-- name: GetCategoryAncestors :many
+with recursive ancestors as (select id,
+                                    name,
+                                    slug,
+                                    parent_id,
+                                    0 as depth
+                             from categories
+                             where categories.id = $1
+                               and deleted_at is null
+                             union all
+                             select categories.id,
+                                    categories.name,
+                                    categories.slug,
+                                    categories.parent_id,
+                                    ancestors.depth + 1
+                             from categories
+                                      join ancestors on categories.id = ancestors.parent_id
+                             where categories.deleted_at is null)
+    cycle id set is_cycle using path
+select id,
+       name,
+       slug,
+       parent_id
+from ancestors
+where not is_cycle
+order by depth desc;
+
+-- name: GetCategoryDescendants :many
+with recursive descendants as (select id,
+                                      name,
+                                      slug,
+                                      parent_id,
+                                      0 as depth
+                               from categories
+                               where categories.parent_id = sqlc.arg(id)::bigint
+                                 and deleted_at is null
+                               union all
+                               select categories.id,
+                                      categories.name,
+                                      categories.slug,
+                                      categories.parent_id,
+                                      descendants.depth + 1
+                               from categories
+                                        join descendants on categories.parent_id = descendants.id
+                               where categories.deleted_at is null)
+    cycle id set is_cycle using path
+select id,
+       name,
+       slug,
+       parent_id
+from descendants
+where not is_cycle
+order by depth, name;
+
+-- name: DeleteCategorySubtree :execrows
+with recursive subtree as (select id
+                           from categories
+                           where categories.id = $1
+                             and deleted_at is null
+                           union
+                           select categories.id
+                           from categories
+                                    join subtree on categories.parent_id = subtree.id
+                           where categories.deleted_at is null)
+update categories
+set deleted_at  = now(),
+    deletion_id = sqlc.arg(deletion_id)::uuid,
+    updated_at  = now()
+where id in (select id from subtree);
 
 -- name: GetAllProducts :many
 select id,
@@ -121,11 +447,277 @@ select id,
        slug,
        description,
        price_kopeck,
+       currency,
        is_active,
        created_at,
-       updated_at
+       updated_at,
+       version
+from products
+where deleted_at is null
+  and (sqlc.narg(brand_id)::bigint is null or brand_id = sqlc.narg(brand_id))
+  and (sqlc.narg(category_id)::bigint is null or category_id = sqlc.narg(category_id))
+  and (sqlc.narg(is_active)::boolean is null or is_active = sqlc.narg(is_active))
+  and (sqlc.narg(price_min)::bigint is null or price_kopeck >= sqlc.narg(price_min))
+  and (sqlc.narg(price_max)::bigint is null or price_kopeck <= sqlc.narg(price_max))
+  and (sqlc.narg(created_from)::timestamptz is null or created_at >= sqlc.narg(created_from))
+  and (sqlc.narg(created_to)::timestamptz is null or created_at <= sqlc.narg(created_to))
+  and (sqlc.narg(updated_from)::timestamptz is null or updated_at >= sqlc.narg(updated_from))
+  and (sqlc.narg(updated_to)::timestamptz is null or updated_at <= sqlc.narg(updated_to))
+  and (sqlc.narg(cursor_id)::bigint is null
+    or (not sqlc.arg(sort_desc)::boolean and case sqlc.arg(sort_by)::text
+                 when 'name' then (name, id) > (sqlc.narg(cursor_value)::text, sqlc.narg(cursor_id)::bigint)
+                 when 'price' then (price_kopeck, id) > (sqlc.narg(cursor_value)::text::bigint, sqlc.narg(cursor_id)::bigint)
+                 when 'created_at' then (created_at, id) > (sqlc.narg(cursor_value)::text::timestamptz, sqlc.narg(cursor_id)::bigint)
+                 when 'updated_at' then (updated_at, id) > (sqlc.narg(cursor_value)::text::timestamptz, sqlc.narg(cursor_id)::bigint)
+                 else id > sqlc.narg(cursor_id)::bigint end)
+    or (sqlc.arg(sort_desc)::boolean and case sqlc.arg(sort_by)::text
+                 when 'name' then (name, id) < (sqlc.narg(cursor_value)::text, sqlc.narg(cursor_id)::bigint)
+                 when 'price' then (price_kopeck, id) < (sqlc.narg(cursor_value)::text::bigint, sqlc.narg(cursor_id)::bigint)
+                 when 'created_at' then (created_at, id) < (sqlc.narg(cursor_value)::text::timestamptz, sqlc.narg(cursor_id)::bigint)
+                 when 'updated_at' then (updated_at, id) < (sqlc.narg(cursor_value)::text::timestamptz, sqlc.narg(cursor_id)::bigint)
+                 else id < sqlc.narg(cursor_id)::bigint end))
+order by case when sqlc.arg(sort_by)::text = 'name' and not sqlc.arg(sort_desc)::boolean then name end,
+         case when sqlc.arg(sort_by)::text = 'name' and sqlc.arg(sort_desc)::boolean then name end desc,
+         case when sqlc.arg(sort_by)::text = 'price' and not sqlc.arg(sort_desc)::boolean then price_kopeck end,
+         case when sqlc.arg(sort_by)::text = 'price' and sqlc.arg(sort_desc)::boolean then price_kopeck end desc,
+         case when sqlc.arg(sort_by)::text = 'created_at' and not sqlc.arg(sort_desc)::boolean then created_at end,
+         case when sqlc.arg(sort_by)::text = 'created_at' and sqlc.arg(sort_desc)::boolean then created_at end desc,
+         case when sqlc.arg(sort_by)::text = 'updated_at' and not sqlc.arg(sort_desc)::boolean then updated_at end,
+         case when sqlc.arg(sort_by)::text = 'updated_at' and sqlc.arg(sort_desc)::boolean then updated_at end desc,
+         case when not sqlc.arg(sort_desc)::boolean then id end,
+         case when sqlc.arg(sort_desc)::boolean then id end desc
+limit sqlc.arg(page_limit);
+
+-- name: CountProducts :one
+select count(*)
 from products
-where deleted_at is null;
+where deleted_at is null
+  and (sqlc.narg(brand_id)::bigint is null or brand_id = sqlc.narg(brand_id))
+  and (sqlc.narg(category_id)::bigint is null or category_id = sqlc.narg(category_id))
+  and (sqlc.narg(is_active)::boolean is null or is_active = sqlc.narg(is_active))
+  and (sqlc.narg(price_min)::bigint is null or price_kopeck >= sqlc.narg(price_min))
+  and (sqlc.narg(price_max)::bigint is null or price_kopeck <= sqlc.narg(price_max))
+  and (sqlc.narg(created_from)::timestamptz is null or created_at >= sqlc.narg(created_from))
+  and (sqlc.narg(created_to)::timestamptz is null or created_at <= sqlc.narg(created_to))
+  and (sqlc.narg(updated_from)::timestamptz is null or updated_at >= sqlc.narg(updated_from))
+  and (sqlc.narg(updated_to)::timestamptz is null or updated_at <= sqlc.narg(updated_to));
+
+-- name: GetFrontFilteredProducts :many
+select products.id,
+       products.brand_id,
+       brands.name     as brand_name,
+       products.category_id,
+       categories.name as category_name,
+       products.name,
+       products.slug,
+       products.description,
+       products.price_kopeck,
+       products.currency
+from front_filtered_products(sqlc.narg(category_id)::bigint,
+                             sqlc.arg(brand_ids)::bigint[],
+                             sqlc.arg(filter_codes)::text[],
+                             sqlc.arg(filter_pairs)::text[],
+                             sqlc.arg(range_codes)::text[],
+                             sqlc.arg(range_mins)::float8[],
+                             sqlc.arg(range_maxs)::float8[],
+                             null) as filtered(id)
+         join products on products.id = filtered.id
+         join brands on products.brand_id = brands.id
+         join categories on products.category_id = categories.id
+where sqlc.narg(cursor_id)::bigint is null
+   or (not sqlc.arg(sort_desc)::boolean and products.id > sqlc.narg(cursor_id))
+   or (sqlc.arg(sort_desc)::boolean and products.id < sqlc.narg(cursor_id))
+order by case when not sqlc.arg(sort_desc)::boolean then products.id end,
+         case when sqlc.arg(sort_desc)::boolean then products.id end desc
+limit sqlc.arg(page_limit);
+
+-- name: CountFrontFilteredProducts :one
+select count(*)
+from front_filtered_products(sqlc.narg(category_id)::bigint,
+                             sqlc.arg(brand_ids)::bigint[],
+                             sqlc.arg(filter_codes)::text[],
+                             sqlc.arg(filter_pairs)::text[],
+                             sqlc.arg(range_codes)::text[],
+                             sqlc.arg(range_mins)::float8[],
+                             sqlc.arg(range_maxs)::float8[],
+                             null);
+
+-- name: GetFrontBrandFacets :many
+select brands.id,
+       brands.name,
+       count(*) as count
+from front_filtered_products(sqlc.narg(category_id)::bigint,
+                             sqlc.arg(brand_ids)::bigint[],
+                             sqlc.arg(filter_codes)::text[],
+                             sqlc.arg(filter_pairs)::text[],
+                             sqlc.arg(range_codes)::text[],
+                             sqlc.arg(range_mins)::float8[],
+                             sqlc.arg(range_maxs)::float8[],
+                             null) as filtered(id)
+         join products on products.id = filtered.id
+         join brands on products.brand_id = brands.id
+group by brands.id, brands.name
+order by brands.name;
+
+-- name: GetFrontAttributeFacets :many
+with filtered_codes as (select distinct codes.code
+                        from unnest(sqlc.arg(filter_codes)::text[] || sqlc.arg(range_codes)::text[]) as codes(code)),
+     filtered as (select matched.id,
+                         null::text as except_code
+                  from front_filtered_products(sqlc.narg(category_id)::bigint,
+                                               sqlc.arg(brand_ids)::bigint[],
+                                               sqlc.arg(filter_codes)::text[],
+                                               sqlc.arg(filter_pairs)::text[],
+                                               sqlc.arg(range_codes)::text[],
+                                               sqlc.arg(range_mins)::float8[],
+                                               sqlc.arg(range_maxs)::float8[],
+                                               null) as matched(id)
+                  union all
+                  select matched.id,
+                         filtered_codes.code
+                  from filtered_codes
+                           cross join lateral front_filtered_products(sqlc.narg(category_id)::bigint,
+                                                                      sqlc.arg(brand_ids)::bigint[],
+                                                                      sqlc.arg(filter_codes)::text[],
+                                                                      sqlc.arg(filter_pairs)::text[],
+                                                                      sqlc.arg(range_codes)::text[],
+                                                                      sqlc.arg(range_mins)::float8[],
+                                                                      sqlc.arg(range_maxs)::float8[],
+                                                                      filtered_codes.code) as matched(id))
+select attribute_definitions.id,
+       attribute_definitions.code,
+       attribute_definitions.name,
+       attribute_definitions.type,
+       attribute_definitions.unit,
+       product_attribute_values.value,
+       count(*) as count
+from filtered
+         join product_attribute_values on product_attribute_values.product_id = filtered.id
+         join attribute_definitions on product_attribute_values.attribute_id = attribute_definitions.id
+where attribute_definitions.is_filterable = true
+  and (filtered.except_code = attribute_definitions.code
+    or (filtered.except_code is null
+        and attribute_definitions.code not in (select filtered_codes.code from filtered_codes)))
+group by attribute_definitions.id,
+         attribute_definitions.code,
+         attribute_definitions.name,
+         attribute_definitions.type,
+         attribute_definitions.unit,
+         product_attribute_values.value,
+         product_attribute_values.value_number
+order by attribute_definitions.name,
+         attribute_definitions.id,
+         product_attribute_values.value_number,
+         product_attribute_values.value;
+
+-- name: SearchProducts :many
+select products.id,
+       products.brand_id,
+       brands.name     as brand_name,
+       products.category_id,
+       categories.name as category_name,
+       products.name,
+       products.slug,
+       products.description,
+       products.price_kopeck,
+       products.currency,
+       products.is_active,
+       products.created_at,
+       products.updated_at,
+       products.version
+from products
+         join brands on products.brand_id = brands.id
+         join categories on products.category_id = categories.id
+where products.deleted_at is null
+  and brands.deleted_at is null
+  and categories.deleted_at is null
+  and (sqlc.narg(is_active)::boolean is null or products.is_active = sqlc.narg(is_active))
+  and (products.search_vector @@ websearch_to_tsquery('russian', sqlc.arg(query)::text)
+    or sqlc.arg(query)::text <% products.name)
+order by ts_rank(products.search_vector, websearch_to_tsquery('russian', sqlc.arg(query)::text)) +
+         word_similarity(sqlc.arg(query)::text, products.name) desc,
+         products.id
+limit sqlc.arg(page_limit) offset sqlc.arg(page_offset);
+
+-- name: CountSearchProducts :one
+select count(*)
+from products
+         join brands on products.brand_id = brands.id
+         join categories on products.category_id = categories.id
+where products.deleted_at is null
+  and brands.deleted_at is null
+  and categories.deleted_at is null
+  and (sqlc.narg(is_active)::boolean is null or products.is_active = sqlc.narg(is_active))
+  and (products.search_vector @@ websearch_to_tsquery('russian', sqlc.arg(query)::text)
+    or sqlc.arg(query)::text <% products.name);
+
+-- name: SuggestProducts :many
+select products.id,
+       products.name,
+       products.slug
+from products
+         join brands on products.brand_id = brands.id
+         join categories on products.category_id = categories.id
+where products.deleted_at is null
+  and products.is_active = true
+  and brands.deleted_at is null
+  and categories.deleted_at is null
+  and products.search_vector @@ to_tsquery('russian', sqlc.arg(prefix_query)::text)
+order by ts_rank(products.search_vector, to_tsquery('russian', sqlc.arg(prefix_query)::text)) desc,
+         products.id
+limit sqlc.arg(page_limit);
+
+-- name: GetFrontProductsByCategorySubtree :many
+with recursive subtree as (select id
+                           from categories
+                           where categories.id = sqlc.arg(category_id)
+                             and deleted_at is null
+                           union
+                           select categories.id
+                           from categories
+                                    join subtree on categories.parent_id = subtree.id
+                           where categories.deleted_at is null)
+select products.id,
+       products.brand_id,
+       brands.name     as brand_name,
+       products.category_id,
+       categories.name as category_name,
+       products.name,
+       products.slug,
+       products.description,
+       products.price_kopeck,
+       products.currency
+from products
+         join brands on products.brand_id = brands.id
+         join categories on products.category_id = categories.id
+where products.category_id in (select id from subtree)
+  and products.deleted_at is null
+  and products.is_active = true
+  and brands.deleted_at is null
+  and (sqlc.narg(cursor_id)::bigint is null
+    or (not sqlc.arg(sort_desc)::boolean and products.id > sqlc.narg(cursor_id))
+    or (sqlc.arg(sort_desc)::boolean and products.id < sqlc.narg(cursor_id)))
+order by case when not sqlc.arg(sort_desc)::boolean then products.id end,
+         case when sqlc.arg(sort_desc)::boolean then products.id end desc
+limit sqlc.arg(page_limit);
+
+-- name: CountFrontProductsByCategorySubtree :one
+with recursive subtree as (select id
+                           from categories
+                           where categories.id = sqlc.arg(category_id)
+                             and deleted_at is null
+                           union
+                           select categories.id
+                           from categories
+                                    join subtree on categories.parent_id = subtree.id
+                           where categories.deleted_at is null)
+select count(*)
+from products
+         join brands on products.brand_id = brands.id
+where products.category_id in (select id from subtree)
+  and products.deleted_at is null
+  and products.is_active = true
+  and brands.deleted_at is null;
 
 -- name: GetProduct :one
 select id,
@@ -135,14 +727,44 @@ select id,
        slug,
        description,
        price_kopeck,
+       currency,
        is_active,
        created_at,
-       updated_at
+       updated_at,
+       version
 from products
 where id = $1
   and deleted_at is null
 limit 1;
 
+-- name: GetProductsBySlugs :many
+select id,
+       brand_id,
+       category_id,
+       name,
+       slug,
+       description,
+       price_kopeck,
+       currency,
+       is_active,
+       deleted_at
+from products
+where slug = any (sqlc.arg(slugs)::text[]);
+
+-- name: GetBrandIdsBySlugs :many
+select id,
+       slug
+from brands
+where slug = any (sqlc.arg(slugs)::text[])
+  and deleted_at is null;
+
+-- name: GetCategoryIdsBySlugs :many
+select id,
+       slug
+from categories
+where slug = any (sqlc.arg(slugs)::text[])
+  and deleted_at is null;
+
 -- name: CreateProduct :one
 insert into products (brand_id,
                       category_id,
@@ -150,6 +772,7 @@ insert into products (brand_id,
                       slug,
                       description,
                       price_kopeck,
+                      currency,
                       is_active)
 VALUES ($1,
         $2,
@@ -157,7 +780,8 @@ VALUES ($1,
         $4,
         $5,
         $6,
-        $7)
+        $7,
+        $8)
 returning id,
     brand_id,
     category_id,
@@ -165,9 +789,11 @@ returning id,
     slug,
     description,
     price_kopeck,
+    currency,
     is_active,
     created_at,
-    updated_at;
+    updated_at,
+    version;
 
 -- name: UpdateProduct :one
 update products
@@ -178,7 +804,10 @@ SET brand_id     = $2,
     description  = $6,
     price_kopeck = $7,
     is_active    = $8,
-    updated_at   = now()
+    updated_at   = case
+                       when (brand_id, category_id, name, slug, description, price_kopeck, is_active)
+                           is distinct from ($2, $3, $4, $5, $6, $7, $8) then now()
+                       else updated_at end
 where id = $1
   and deleted_at is null
 returning id,
@@ -188,54 +817,1414 @@ returning id,
     slug,
     description,
     price_kopeck,
+    currency,
     is_active,
     created_at,
-    updated_at;
+    updated_at,
+    version;
 
 -- name: DeleteProduct :execrows
 update products
-set deleted_at = now(),
-    updated_at = now()
-where id = $1
+set deleted_at  = now(),
+    deletion_id = sqlc.arg(deletion_id)::uuid,
+    updated_at  = now()
+where id = sqlc.arg(id)
   and deleted_at is null;
 
 -- name: DeleteProductsByBrandId :execrows
 update products
-set deleted_at = now(),
-    updated_at = now()
-where brand_id = $1
+set deleted_at  = now(),
+    deletion_id = sqlc.arg(deletion_id)::uuid,
+    updated_at  = now()
+where brand_id = sqlc.arg(brand_id)
   and deleted_at is null;
 
--- name: DeleteProductsByCategoryId :execrows
+-- name: DeleteProductsByCategorySubtree :execrows
+with recursive subtree as (select id
+                           from categories
+                           where categories.id = $1
+                             and deleted_at is null
+                           union
+                           select categories.id
+                           from categories
+                                    join subtree on categories.parent_id = subtree.id
+                           where categories.deleted_at is null)
 update products
-set deleted_at = now(),
-    updated_at = now()
-where category_id = $1
+set deleted_at  = now(),
+    deletion_id = sqlc.arg(deletion_id)::uuid,
+    updated_at  = now()
+where category_id in (select id from subtree)
   and deleted_at is null;
 
+-- name: GetTrashedProducts :many
+select id,
+       brand_id,
+       category_id,
+       name,
+       slug,
+       description,
+       price_kopeck,
+       currency,
+       is_active,
+       created_at,
+       updated_at,
+       deleted_at::timestamptz as deleted_at
+from products
+where deleted_at is not null
+  and (sqlc.narg(cursor_id)::bigint is null
+    or (not sqlc.arg(sort_desc)::boolean and case sqlc.arg(sort_by)::text
+                 when 'deleted_at' then (deleted_at, id) > (sqlc.narg(cursor_value)::text::timestamptz, sqlc.narg(cursor_id)::bigint)
+                 else id > sqlc.narg(cursor_id)::bigint end)
+    or (sqlc.arg(sort_desc)::boolean and case sqlc.arg(sort_by)::text
+                 when 'deleted_at' then (deleted_at, id) < (sqlc.narg(cursor_value)::text::timestamptz, sqlc.narg(cursor_id)::bigint)
+                 else id < sqlc.narg(cursor_id)::bigint end))
+order by case when sqlc.arg(sort_by)::text = 'deleted_at' and not sqlc.arg(sort_desc)::boolean then deleted_at end,
+         case when sqlc.arg(sort_by)::text = 'deleted_at' and sqlc.arg(sort_desc)::boolean then deleted_at end desc,
+         case when not sqlc.arg(sort_desc)::boolean then id end,
+         case when sqlc.arg(sort_desc)::boolean then id end desc
+limit sqlc.arg(page_limit);
+
+-- name: CountTrashedProducts :one
+select count(*)
+from products
+where deleted_at is not null;
+
+-- name: LockTrashedProduct :one
+select products.id,
+       (brands.deleted_at is not null)::boolean     as brand_deleted,
+       (categories.deleted_at is not null)::boolean as category_deleted
+from products
+         join brands on brands.id = products.brand_id
+         join categories on categories.id = products.category_id
+where products.id = $1
+  and products.deleted_at is not null
+    for update of products;
+
+-- name: RestoreProduct :one
+update products
+set deleted_at  = null,
+    deletion_id = null,
+    updated_at  = now()
+where id = $1
+  and deleted_at is not null
+returning id,
+    brand_id,
+    category_id,
+    name,
+    slug,
+    description,
+    price_kopeck,
+    currency,
+    is_active,
+    created_at,
+    updated_at,
+    version;
+
+-- name: RestoreProductsByDeletionId :execrows
+update products
+set deleted_at  = null,
+    deletion_id = null,
+    updated_at  = now()
+where products.deletion_id = $1
+  and products.deleted_at is not null
+  and exists(select 1 from brands where brands.id = products.brand_id and brands.deleted_at is null)
+  and exists(select 1 from categories where categories.id = products.category_id and categories.deleted_at is null);
+
+-- name: PurgeInventoryMovements :execrows
+delete
+from inventory_movements
+where product_id in (select id from products where deleted_at < sqlc.arg(deleted_before)::timestamptz)
+   or variant_id in (select id from product_variants where deleted_at < sqlc.arg(deleted_before)::timestamptz);
+
+-- name: PurgeProductPriceHistory :execrows
+delete
+from product_price_history
+where product_id in (select id from products where deleted_at < sqlc.arg(deleted_before)::timestamptz)
+   or variant_id in (select id from product_variants where deleted_at < sqlc.arg(deleted_before)::timestamptz);
+
+-- name: PurgeProductVariants :execrows
+delete
+from product_variants
+where deleted_at < sqlc.arg(deleted_before)::timestamptz
+   or product_id in (select id from products where deleted_at < sqlc.arg(deleted_before)::timestamptz);
+
+-- name: PurgeProducts :execrows
+delete
+from products
+where deleted_at < sqlc.arg(deleted_before)::timestamptz;
+
+-- name: PurgeCategories :execrows
+delete
+from categories
+where deleted_at < sqlc.arg(deleted_before)::timestamptz
+  and not exists(select 1 from products where products.category_id = categories.id)
+  and not exists(select 1 from categories children where children.parent_id = categories.id);
+
+-- name: PurgeBrands :execrows
+delete
+from brands
+where deleted_at < sqlc.arg(deleted_before)::timestamptz
+  and not exists(select 1 from products where products.brand_id = brands.id);
+
 -- name: CreateProductPriceHistory :one
 insert into product_price_history (product_id,
+                                   variant_id,
                                    old_price_kopeck,
                                    new_price_kopeck,
+                                   currency,
                                    updated_by)
 VALUES ($1,
         $2,
         $3,
-        $4)
+        $4,
+        $5,
+        $6)
 returning *;
 
--- name: GetProductPriceHistoryByProductId :many
+-- name: CreateInheritedVariantPriceHistory :execrows
+insert into product_price_history (product_id,
+                                   variant_id,
+                                   old_price_kopeck,
+                                   new_price_kopeck,
+                                   currency,
+                                   updated_by)
+select product_variants.product_id,
+       product_variants.id,
+       sqlc.arg(old_price_kopeck)::bigint,
+       sqlc.arg(new_price_kopeck)::bigint,
+       sqlc.arg(currency)::text,
+       sqlc.arg(updated_by)::bigint
+from product_variants
+where product_variants.product_id = sqlc.arg(product_id)
+  and product_variants.price_kopeck is null
+  and product_variants.deleted_at is null;
+
+-- name: GetPriceHistory :many
 select product_price_history.id,
        product_price_history.product_id,
+       product_price_history.variant_id,
+       products.name     as product_name,
        product_price_history.old_price_kopeck,
        product_price_history.new_price_kopeck,
-       product_price_history.created_at,
-       product_price_history.updated_by
+       product_price_history.currency,
+       product_price_history.updated_by,
+       admin_users.email as updated_by_email,
+       product_price_history.created_at
+from product_price_history
+         join products on product_price_history.product_id = products.id
+         join admin_users on product_price_history.updated_by = admin_users.id
+where products.deleted_at is null
+  and (sqlc.narg(product_id)::bigint is null or product_price_history.product_id = sqlc.narg(product_id))
+  and (sqlc.narg(variant_id)::bigint is null or product_price_history.variant_id = sqlc.narg(variant_id))
+  and (sqlc.narg(updated_by)::bigint is null or product_price_history.updated_by = sqlc.narg(updated_by))
+  and (sqlc.narg(created_from)::timestamptz is null or product_price_history.created_at >= sqlc.narg(created_from))
+  and (sqlc.narg(created_to)::timestamptz is null or product_price_history.created_at <= sqlc.narg(created_to))
+  and (sqlc.narg(cursor_id)::bigint is null
+    or (not sqlc.arg(sort_desc)::boolean and case sqlc.arg(sort_by)::text
+                 when 'created_at' then (product_price_history.created_at, product_price_history.id) > (sqlc.narg(cursor_value)::text::timestamptz, sqlc.narg(cursor_id)::bigint)
+                 else product_price_history.id > sqlc.narg(cursor_id)::bigint end)
+    or (sqlc.arg(sort_desc)::boolean and case sqlc.arg(sort_by)::text
+                 when 'created_at' then (product_price_history.created_at, product_price_history.id) < (sqlc.narg(cursor_value)::text::timestamptz, sqlc.narg(cursor_id)::bigint)
+                 else product_price_history.id < sqlc.narg(cursor_id)::bigint end))
+order by case when sqlc.arg(sort_by)::text = 'created_at' and not sqlc.arg(sort_desc)::boolean then product_price_history.created_at end,
+         case when sqlc.arg(sort_by)::text = 'created_at' and sqlc.arg(sort_desc)::boolean then product_price_history.created_at end desc,
+         case when not sqlc.arg(sort_desc)::boolean then product_price_history.id end,
+         case when sqlc.arg(sort_desc)::boolean then product_price_history.id end desc
+limit sqlc.arg(page_limit);
+
+-- name: CountPriceHistory :one
+select count(*)
 from product_price_history
          join products on product_price_history.product_id = products.id
-where product_price_history.product_id = $1
+where products.deleted_at is null
+  and (sqlc.narg(product_id)::bigint is null or product_price_history.product_id = sqlc.narg(product_id))
+  and (sqlc.narg(variant_id)::bigint is null or product_price_history.variant_id = sqlc.narg(variant_id))
+  and (sqlc.narg(updated_by)::bigint is null or product_price_history.updated_by = sqlc.narg(updated_by))
+  and (sqlc.narg(created_from)::timestamptz is null or product_price_history.created_at >= sqlc.narg(created_from))
+  and (sqlc.narg(created_to)::timestamptz is null or product_price_history.created_at <= sqlc.narg(created_to));
+
+-- name: GetPriceHistoryEntry :one
+select id,
+       product_id,
+       variant_id,
+       old_price_kopeck,
+       new_price_kopeck,
+       currency
+from product_price_history
+where id = sqlc.arg(id)
+  and product_id = sqlc.arg(product_id);
+
+-- name: UpdateProductPrice :one
+update products
+set price_kopeck = $2,
+    updated_at   = now()
+where id = $1
+  and deleted_at is null
+returning id, brand_id, category_id, name, slug, description, price_kopeck, currency, is_active, created_at, updated_at, version;
+
+-- name: LockProduct :one
+select version
+from products
+where id = $1
+  and deleted_at is null
+for update;
+
+-- name: BumpProductVersion :one
+update products
+set version = version + 1
+where id = $1
+  and deleted_at is null
+returning version;
+
+-- name: GetProductStock :one
+select coalesce(sum(delta), 0)::bigint as quantity
+from inventory_movements
+where product_id = $1;
+
+-- name: GetProductStocks :many
+select product_id,
+       coalesce(sum(delta), 0)::bigint as quantity
+from inventory_movements
+where product_id = any (sqlc.arg(product_ids)::bigint[])
+group by product_id;
+
+-- name: CreateInventoryMovement :one
+insert into inventory_movements (product_id,
+                                 variant_id,
+                                 delta,
+                                 description)
+VALUES ($1,
+        $2,
+        $3,
+        $4)
+returning *;
+
+-- name: GetInventoryMovementsByProductId :many
+select id,
+       product_id,
+       delta,
+       description,
+       created_at,
+       variant_id
+from inventory_movements
+where product_id = sqlc.arg(product_id)
+  and (sqlc.narg(cursor_id)::bigint is null
+    or (not sqlc.arg(sort_desc)::boolean and id > sqlc.narg(cursor_id))
+    or (sqlc.arg(sort_desc)::boolean and id < sqlc.narg(cursor_id)))
+order by case when not sqlc.arg(sort_desc)::boolean then id end,
+         case when sqlc.arg(sort_desc)::boolean then id end desc
+limit sqlc.arg(page_limit);
+
+-- name: CountInventoryMovementsByProductId :one
+select count(*)
+from inventory_movements
+where product_id = $1;
+
+-- name: LockProductVariant :one
+select id,
+       price_kopeck
+from product_variants
+where id = sqlc.arg(id)
+  and product_id = sqlc.arg(product_id)
+  and deleted_at is null
+    for update;
+
+-- name: GetVariantStock :one
+select coalesce(sum(delta), 0)::bigint as quantity
+from inventory_movements
+where variant_id = $1;
+
+-- name: GetProductOwnStock :one
+select coalesce(sum(delta), 0)::bigint as quantity
+from inventory_movements
+where product_id = $1
+  and variant_id is null;
+
+-- name: CountProductVariants :one
+select count(*)
+from product_variants
+where product_id = sqlc.arg(product_id)
+  and id <> sqlc.arg(exclude_id)
+  and deleted_at is null;
+
+-- name: GetProductOptionTypeNames :many
+select distinct option_types.name
+from variant_option_values
+         join option_types on variant_option_values.option_type_id = option_types.id
+         join product_variants on variant_option_values.variant_id = product_variants.id
+where product_variants.product_id = sqlc.arg(product_id)
+  and product_variants.id <> sqlc.arg(exclude_id)
+  and product_variants.deleted_at is null
+order by option_types.name;
+
+-- name: UpsertOptionType :one
+insert into option_types (name)
+VALUES ($1)
+on conflict (name) do update set name = excluded.name
+returning id;
+
+-- name: CreateProductVariant :one
+insert into product_variants (product_id,
+                              sku,
+                              barcode,
+                              price_kopeck,
+                              is_active,
+                              options_key)
+VALUES ($1,
+        $2,
+        $3,
+        $4,
+        $5,
+        $6)
+returning id;
+
+-- name: UpdateProductVariant :one
+update product_variants
+SET sku          = $3,
+    barcode      = $4,
+    price_kopeck = $5,
+    is_active    = $6,
+    options_key  = $7,
+    updated_at   = now()
+where id = $1
+  and product_id = $2
+  and deleted_at is null
+returning id;
+
+-- name: DeleteVariantOptionValues :exec
+delete
+from variant_option_values
+where variant_id = $1;
+
+-- name: CreateVariantOptionValue :exec
+insert into variant_option_values (variant_id,
+                                   option_type_id,
+                                   value)
+VALUES ($1,
+        $2,
+        $3);
+
+-- name: GetProductVariants :many
+select product_variants.id,
+       product_variants.product_id,
+       product_variants.sku,
+       product_variants.barcode,
+       product_variants.price_kopeck,
+       coalesce(product_variants.price_kopeck, products.price_kopeck)::bigint as effective_price_kopeck,
+       products.currency,
+       product_variants.is_active,
+       (select coalesce(sum(delta), 0)
+        from inventory_movements
+        where inventory_movements.variant_id = product_variants.id)::bigint as stock,
+       product_variants.created_at,
+       product_variants.updated_at
+from product_variants
+         join products on product_variants.product_id = products.id
+where product_variants.product_id = $1
+  and product_variants.deleted_at is null
+  and products.deleted_at is null
+order by product_variants.id;
+
+-- name: GetProductVariant :one
+select product_variants.id,
+       product_variants.product_id,
+       product_variants.sku,
+       product_variants.barcode,
+       product_variants.price_kopeck,
+       coalesce(product_variants.price_kopeck, products.price_kopeck)::bigint as effective_price_kopeck,
+       products.currency,
+       product_variants.is_active,
+       (select coalesce(sum(delta), 0)
+        from inventory_movements
+        where inventory_movements.variant_id = product_variants.id)::bigint as stock,
+       product_variants.created_at,
+       product_variants.updated_at
+from product_variants
+         join products on product_variants.product_id = products.id
+where product_variants.id = sqlc.arg(id)
+  and product_variants.product_id = sqlc.arg(product_id)
+  and product_variants.deleted_at is null
+  and products.deleted_at is null
+limit 1;
+
+-- name: GetVariantOptionValuesByProductId :many
+select variant_option_values.variant_id,
+       option_types.name,
+       variant_option_values.value
+from variant_option_values
+         join option_types on variant_option_values.option_type_id = option_types.id
+         join product_variants on variant_option_values.variant_id = product_variants.id
+where product_variants.product_id = $1
+  and product_variants.deleted_at is null
+order by option_types.name, variant_option_values.value;
+
+-- name: DeleteProductVariant :execrows
+update product_variants
+set deleted_at = now(),
+    updated_at = now()
+where id = $1
+  and product_id = $2
+  and deleted_at is null;
+
+-- name: GetFrontProductVariants :many
+select product_variants.id,
+       product_variants.sku,
+       coalesce(product_variants.price_kopeck, products.price_kopeck)::bigint as price_kopeck,
+       products.currency,
+       (product_variants.price_kopeck is not null)::boolean as has_own_price,
+       (select coalesce(sum(delta), 0)
+        from inventory_movements
+        where inventory_movements.variant_id = product_variants.id)::bigint as stock
+from product_variants
+         join products on product_variants.product_id = products.id
+         join brands on products.brand_id = brands.id
+         join categories on products.category_id = categories.id
+where product_variants.product_id = $1
+  and product_variants.deleted_at is null
+  and product_variants.is_active = true
+  and products.deleted_at is null
+  and products.is_active = true
+  and brands.deleted_at is null
+  and categories.deleted_at is null
+order by product_variants.id;
+
+-- name: GetFrontProduct :one
+select products.id
+from products
+         join brands on products.brand_id = brands.id
+         join categories on products.category_id = categories.id
+where products.id = $1
+  and products.deleted_at is null
+  and products.is_active = true
+  and brands.deleted_at is null
+  and categories.deleted_at is null
+limit 1;
+
+-- name: GetCategoryAttributeDefinitions :many
+with recursive ancestors as (select id,
+                                    parent_id
+                             from categories
+                             where categories.id = $1
+                               and deleted_at is null
+                             union
+                             select categories.id,
+                                    categories.parent_id
+                             from categories
+                                      join ancestors on categories.id = ancestors.parent_id
+                             where categories.deleted_at is null)
+select id,
+       category_id,
+       code,
+       name,
+       type,
+       unit,
+       enum_values,
+       is_required,
+       is_filterable,
+       created_at,
+       updated_at
+from attribute_definitions
+where category_id in (select id from ancestors)
+order by name, id;
+
+-- name: GetAttributeDefinition :one
+select id,
+       category_id,
+       code,
+       name,
+       type,
+       unit,
+       enum_values,
+       is_required,
+       is_filterable,
+       created_at,
+       updated_at
+from attribute_definitions
+where id = sqlc.arg(id)
+  and category_id = sqlc.arg(category_id)
+limit 1;
+
+-- name: CountAttributeCodeConflicts :one
+with recursive ancestors as (select id,
+                                    parent_id
+                             from categories
+                             where categories.id = sqlc.arg(category_id)
+                             union
+                             select categories.id,
+                                    categories.parent_id
+                             from categories
+                                      join ancestors on categories.id = ancestors.parent_id),
+               descendants as (select id
+                               from categories
+                               where categories.id = sqlc.arg(category_id)
+                               union
+                               select categories.id
+                               from categories
+                                        join descendants on categories.parent_id = descendants.id)
+select count(*)
+from attribute_definitions
+where attribute_definitions.code = sqlc.arg(code)
+  and attribute_definitions.id <> sqlc.arg(exclude_id)
+  and (attribute_definitions.category_id in (select ancestors.id from ancestors)
+    or attribute_definitions.category_id in (select descendants.id from descendants));
+
+-- name: GetMovedAttributeCodeConflicts :many
+with recursive ancestors as (select id,
+                                    parent_id
+                             from categories
+                             where categories.id = sqlc.narg(parent_id)
+                             union
+                             select categories.id,
+                                    categories.parent_id
+                             from categories
+                                      join ancestors on categories.id = ancestors.parent_id),
+               descendants as (select id
+                               from categories
+                               where categories.id = sqlc.arg(category_id)
+                               union
+                               select categories.id
+                               from categories
+                                        join descendants on categories.parent_id = descendants.id)
+select distinct moved.code
+from attribute_definitions moved
+         join attribute_definitions inherited on inherited.code = moved.code
+where moved.category_id in (select descendants.id from descendants)
+  and inherited.category_id in (select ancestors.id from ancestors)
+order by moved.code;
+
+-- name: CreateAttributeDefinition :one
+insert into attribute_definitions (category_id,
+                                   code,
+                                   name,
+                                   type,
+                                   unit,
+                                   enum_values,
+                                   is_required,
+                                   is_filterable)
+VALUES ($1,
+        $2,
+        $3,
+        $4,
+        $5,
+        $6,
+        $7,
+        $8)
+returning id,
+    category_id,
+    code,
+    name,
+    type,
+    unit,
+    enum_values,
+    is_required,
+    is_filterable,
+    created_at,
+    updated_at;
+
+-- name: UpdateAttributeDefinition :one
+update attribute_definitions
+SET code          = $3,
+    name          = $4,
+    type          = $5,
+    unit          = $6,
+    enum_values   = $7,
+    is_required   = $8,
+    is_filterable = $9,
+    updated_at    = now()
+where id = $1
+  and category_id = $2
+returning id,
+    category_id,
+    code,
+    name,
+    type,
+    unit,
+    enum_values,
+    is_required,
+    is_filterable,
+    created_at,
+    updated_at;
+
+-- name: DeleteAttributeDefinition :execrows
+delete
+from attribute_definitions
+where id = $1
+  and category_id = $2;
+
+-- name: CountAttributeValues :one
+select count(*)
+from product_attribute_values
+where attribute_id = $1;
+
+-- name: CountAttributeValuesNotIn :one
+select count(*)
+from product_attribute_values
+where attribute_id = sqlc.arg(attribute_id)
+  and value <> all (sqlc.arg(allowed)::text[]);
+
+-- name: GetProductAttributeValues :many
+select attribute_definitions.id,
+       attribute_definitions.code,
+       attribute_definitions.name,
+       attribute_definitions.type,
+       attribute_definitions.unit,
+       product_attribute_values.value,
+       product_attribute_values.value_number
+from product_attribute_values
+         join attribute_definitions on product_attribute_values.attribute_id = attribute_definitions.id
+where product_attribute_values.product_id = $1
+order by attribute_definitions.name, attribute_definitions.id;
+
+-- name: DeleteProductAttributeValues :exec
+delete
+from product_attribute_values
+where product_id = $1;
+
+-- name: CreateProductAttributeValue :exec
+insert into product_attribute_values (product_id,
+                                      attribute_id,
+                                      value,
+                                      value_number)
+VALUES ($1,
+        $2,
+        $3,
+        $4);
+
+-- name: DeleteInapplicableProductAttributeValues :execrows
+with recursive ancestors as (select id,
+                                    parent_id
+                             from categories
+                             where categories.id = (select products.category_id from products where products.id = sqlc.arg(product_id))
+                             union
+                             select categories.id,
+                                    categories.parent_id
+                             from categories
+                                      join ancestors on categories.id = ancestors.parent_id)
+delete
+from product_attribute_values
+where product_id = sqlc.arg(product_id)
+  and attribute_id not in (select attribute_definitions.id
+                           from attribute_definitions
+                           where attribute_definitions.category_id in (select ancestors.id from ancestors));
+
+-- name: DeleteInapplicableSubtreeAttributeValues :execrows
+with recursive descendants as (select id
+                               from categories
+                               where categories.id = sqlc.arg(category_id)
+                               union
+                               select categories.id
+                               from categories
+                                        join descendants on categories.parent_id = descendants.id),
+               paths as (select descendants.id as category_id,
+                                descendants.id as ancestor_id
+                         from descendants
+                         union
+                         select paths.category_id,
+                                categories.parent_id
+                         from paths
+                                  join categories on categories.id = paths.ancestor_id
+                         where categories.parent_id is not null)
+delete
+from product_attribute_values
+    using products
+where products.id = product_attribute_values.product_id
+  and products.category_id in (select descendants.id from descendants)
+  and not exists(select 1
+                 from attribute_definitions
+                          join paths on paths.ancestor_id = attribute_definitions.category_id
+                 where attribute_definitions.id = product_attribute_values.attribute_id
+                   and paths.category_id = products.category_id);
+
+-- name: LockBrand :one
+select version
+from brands
+where id = $1
+  and deleted_at is null
+for update;
+
+-- name: BumpBrandVersion :one
+update brands
+set version = version + 1
+where id = $1
+  and deleted_at is null
+returning version;
+
+-- name: GetMediaByProductIds :many
+select id,
+       product_id,
+       brand_id,
+       storage_key,
+       content_type,
+       size_bytes,
+       width,
+       height,
+       thumbnail_keys,
+       position,
+       is_primary,
+       created_at
+from media
+where product_id = any (sqlc.arg(product_ids)::bigint[])
+order by product_id, position, id;
+
+-- name: GetMediaByBrandIds :many
+select id,
+       product_id,
+       brand_id,
+       storage_key,
+       content_type,
+       size_bytes,
+       width,
+       height,
+       thumbnail_keys,
+       position,
+       is_primary,
+       created_at
+from media
+where brand_id = any (sqlc.arg(brand_ids)::bigint[])
+order by brand_id, position, id;
+
+-- name: GetMediaIds :many
+select id
+from media
+where product_id = sqlc.narg(product_id)
+   or brand_id = sqlc.narg(brand_id)
+order by position, id;
+
+-- name: GetNextMediaPosition :one
+select coalesce(max(position) + 1, 0)::integer
+from media
+where product_id = sqlc.narg(product_id)
+   or brand_id = sqlc.narg(brand_id);
+
+-- name: HasPrimaryMedia :one
+select exists(select 1
+              from media
+              where (product_id = sqlc.narg(product_id) or brand_id = sqlc.narg(brand_id))
+                and is_primary);
+
+-- name: CreateMedia :one
+insert into media (product_id,
+                   brand_id,
+                   storage_key,
+                   content_type,
+                   size_bytes,
+                   width,
+                   height,
+                   thumbnail_keys,
+                   position,
+                   is_primary)
+VALUES ($1,
+        $2,
+        $3,
+        $4,
+        $5,
+        $6,
+        $7,
+        $8,
+        $9,
+        $10)
+returning id, product_id, brand_id, storage_key, content_type, size_bytes, width, height, thumbnail_keys, position, is_primary, created_at;
+
+-- name: DeleteMedia :one
+delete
+from media
+where id = sqlc.arg(id)
+  and (product_id = sqlc.narg(product_id) or brand_id = sqlc.narg(brand_id))
+returning storage_key, thumbnail_keys, is_primary;
+
+-- name: ClearPrimaryMedia :exec
+update media
+set is_primary = false
+where (product_id = sqlc.narg(product_id) or brand_id = sqlc.narg(brand_id))
+  and is_primary;
+
+-- name: SetPrimaryMedia :execrows
+update media
+set is_primary = true
+where id = sqlc.arg(id)
+  and (product_id = sqlc.narg(product_id) or brand_id = sqlc.narg(brand_id));
+
+-- name: PromoteFirstMedia :exec
+update media
+set is_primary = true
+where id = (select first.id
+            from media first
+            where first.product_id = sqlc.narg(product_id)
+               or first.brand_id = sqlc.narg(brand_id)
+            order by first.position, first.id
+            limit 1);
+
+-- name: ReorderMedia :execrows
+update media
+set position = array_position(sqlc.arg(ids)::bigint[], id) - 1
+where (product_id = sqlc.narg(product_id) or brand_id = sqlc.narg(brand_id))
+  and id = any (sqlc.arg(ids)::bigint[]);
+
+-- name: PurgeMedia :many
+delete
+from media
+where product_id in (select id from products where deleted_at < sqlc.arg(deleted_before)::timestamptz)
+   or brand_id in (select brands.id
+                   from brands
+                   where brands.deleted_at < sqlc.arg(deleted_before)::timestamptz
+                     and not exists(select 1
+                                    from products
+                                    where products.brand_id = brands.id
+                                      and (products.deleted_at is null or
+                                           products.deleted_at >= sqlc.arg(deleted_before)::timestamptz)))
+returning storage_key, thumbnail_keys;
+
+-- name: GetAllPriceSchedules :many
+select id,
+       product_id,
+       brand_id,
+       category_id,
+       new_price_kopeck,
+       starts_at,
+       ends_at,
+       status,
+       applied_at,
+       reverted_at,
+       created_by,
+       created_at,
+       updated_at,
+       attempts,
+       last_error,
+       retry_at,
+       failed_status,
+       currency
+from price_schedules
+where true
+  and (sqlc.narg(status)::text is null or status = sqlc.narg(status))
+  and (sqlc.narg(product_id)::bigint is null or product_id = sqlc.narg(product_id))
+  and (sqlc.narg(brand_id)::bigint is null or brand_id = sqlc.narg(brand_id))
+  and (sqlc.narg(category_id)::bigint is null or category_id = sqlc.narg(category_id))
+  and (sqlc.narg(cursor_id)::bigint is null
+    or (not sqlc.arg(sort_desc)::boolean and case sqlc.arg(sort_by)::text
+                 when 'starts_at' then (starts_at, id) > (sqlc.narg(cursor_value)::text::timestamptz, sqlc.narg(cursor_id)::bigint)
+                 else id > sqlc.narg(cursor_id)::bigint end)
+    or (sqlc.arg(sort_desc)::boolean and case sqlc.arg(sort_by)::text
+                 when 'starts_at' then (starts_at, id) < (sqlc.narg(cursor_value)::text::timestamptz, sqlc.narg(cursor_id)::bigint)
+                 else id < sqlc.narg(cursor_id)::bigint end))
+order by case when sqlc.arg(sort_by)::text = 'starts_at' and not sqlc.arg(sort_desc)::boolean then starts_at end,
+         case when sqlc.arg(sort_by)::text = 'starts_at' and sqlc.arg(sort_desc)::boolean then starts_at end desc,
+         case when not sqlc.arg(sort_desc)::boolean then id end,
+         case when sqlc.arg(sort_desc)::boolean then id end desc
+limit sqlc.arg(page_limit);
+
+-- name: CountPriceSchedules :one
+select count(*)
+from price_schedules
+where true
+  and (sqlc.narg(status)::text is null or status = sqlc.narg(status))
+  and (sqlc.narg(product_id)::bigint is null or product_id = sqlc.narg(product_id))
+  and (sqlc.narg(brand_id)::bigint is null or brand_id = sqlc.narg(brand_id))
+  and (sqlc.narg(category_id)::bigint is null or category_id = sqlc.narg(category_id));
+
+-- name: GetPriceSchedule :one
+select id,
+       product_id,
+       brand_id,
+       category_id,
+       new_price_kopeck,
+       starts_at,
+       ends_at,
+       status,
+       applied_at,
+       reverted_at,
+       created_by,
+       created_at,
+       updated_at,
+       attempts,
+       last_error,
+       retry_at,
+       failed_status,
+       currency
+from price_schedules
+where id = $1;
+
+-- name: LockPriceSchedule :one
+select status,
+       failed_status
+from price_schedules
+where id = $1
+for update;
+
+-- name: CreatePriceSchedule :one
+insert into price_schedules (product_id,
+                             brand_id,
+                             category_id,
+                             new_price_kopeck,
+                             currency,
+                             starts_at,
+                             ends_at,
+                             created_by)
+VALUES ($1,
+        $2,
+        $3,
+        $4,
+        $5,
+        $6,
+        $7,
+        $8)
+returning id, product_id, brand_id, category_id, new_price_kopeck, starts_at, ends_at, status, applied_at, reverted_at, created_by, created_at, updated_at, attempts, last_error, retry_at, failed_status, currency;
+
+-- name: UpdatePriceSchedule :one
+update price_schedules
+set product_id       = $2,
+    brand_id         = $3,
+    category_id      = $4,
+    new_price_kopeck = $5,
+    currency         = $6,
+    starts_at        = $7,
+    ends_at          = $8,
+    status           = 'pending',
+    attempts         = 0,
+    last_error       = null,
+    retry_at         = null,
+    failed_status    = null,
+    updated_at       = now()
+where id = $1
+  and 'pending' in (status, failed_status)
+returning id, product_id, brand_id, category_id, new_price_kopeck, starts_at, ends_at, status, applied_at, reverted_at, created_by, created_at, updated_at, attempts, last_error, retry_at, failed_status, currency;
+
+-- name: DeletePriceSchedule :execrows
+delete
+from price_schedules
+where id = $1
+  and status in ('pending', 'failed');
+
+-- name: GetDuePriceSchedule :one
+select id,
+       product_id,
+       brand_id,
+       category_id,
+       new_price_kopeck,
+       currency,
+       created_by
+from price_schedules
+where status = 'pending'
+  and starts_at <= now()
+  and (retry_at is null or retry_at <= now())
+order by starts_at, id
+limit 1 for update skip locked;
+
+-- name: GetExpiredPriceSchedule :one
+select id,
+       new_price_kopeck,
+       currency,
+       created_by
+from price_schedules
+where status = 'applied'
+  and ends_at <= now()
+  and (retry_at is null or retry_at <= now())
+order by ends_at, id
+limit 1 for update skip locked;
+
+-- name: ApplyPriceSchedule :execrows
+with recursive subtree as (select categories.id
+                           from categories
+                           where categories.id = sqlc.narg(category_id)
+                             and categories.deleted_at is null
+                           union
+                           select categories.id
+                           from categories
+                                    join subtree on categories.parent_id = subtree.id
+                           where categories.deleted_at is null),
+               targets as (select products.id,
+                                  products.price_kopeck
+                           from products
+                           where products.deleted_at is null
+                             and products.price_kopeck <> sqlc.arg(new_price_kopeck)::bigint
+                             and products.currency = sqlc.arg(currency)::text
+                             and (products.id = sqlc.narg(product_id)
+                               or products.brand_id = sqlc.narg(brand_id)
+                               or products.category_id in (select subtree.id from subtree))
+                           for update),
+               updated as (update products
+                   set price_kopeck = sqlc.arg(new_price_kopeck)::bigint,
+                       updated_at = now()
+                   from targets
+                   where products.id = targets.id
+                   returning products.id, targets.price_kopeck as old_price_kopeck),
+               items as (insert into price_schedule_items (schedule_id, product_id, old_price_kopeck)
+                   select sqlc.arg(schedule_id)::bigint, updated.id, updated.old_price_kopeck
+                   from updated),
+               variant_history as (insert into product_price_history (product_id, variant_id, old_price_kopeck, new_price_kopeck, currency, updated_by)
+                   select updated.id, product_variants.id, updated.old_price_kopeck, sqlc.arg(new_price_kopeck)::bigint, sqlc.arg(currency)::text, sqlc.arg(updated_by)::bigint
+                   from updated
+                            join product_variants on product_variants.product_id = updated.id
+                   where product_variants.price_kopeck is null
+                     and product_variants.deleted_at is null)
+insert
+into product_price_history (product_id, old_price_kopeck, new_price_kopeck, currency, updated_by)
+select updated.id, updated.old_price_kopeck, sqlc.arg(new_price_kopeck)::bigint, sqlc.arg(currency)::text, sqlc.arg(updated_by)::bigint
+from updated;
+
+-- name: RevertPriceSchedule :execrows
+with targets as (select products.id,
+                        price_schedule_items.old_price_kopeck
+                 from price_schedule_items
+                          join products on products.id = price_schedule_items.product_id
+                 where price_schedule_items.schedule_id = sqlc.arg(schedule_id)::bigint
+                   and products.deleted_at is null
+                   and products.price_kopeck = sqlc.arg(new_price_kopeck)::bigint
+                   and products.currency = sqlc.arg(currency)::text
+                 for update of products),
+     updated as (update products
+         set price_kopeck = targets.old_price_kopeck,
+             updated_at = now()
+         from targets
+         where products.id = targets.id
+         returning products.id, targets.old_price_kopeck),
+     variant_history as (insert into product_price_history (product_id, variant_id, old_price_kopeck, new_price_kopeck, currency, updated_by)
+         select updated.id, product_variants.id, sqlc.arg(new_price_kopeck)::bigint, updated.old_price_kopeck, sqlc.arg(currency)::text, sqlc.arg(updated_by)::bigint
+         from updated
+                  join product_variants on product_variants.product_id = updated.id
+         where product_variants.price_kopeck is null
+           and product_variants.deleted_at is null)
+insert
+into product_price_history (product_id, old_price_kopeck, new_price_kopeck, currency, updated_by)
+select updated.id, sqlc.arg(new_price_kopeck)::bigint, updated.old_price_kopeck, sqlc.arg(currency)::text, sqlc.arg(updated_by)::bigint
+from updated;
+
+-- name: MarkPriceScheduleApplied :exec
+update price_schedules
+set status     = case when ends_at is null then 'completed' else 'applied' end,
+    applied_at = now(),
+    attempts   = 0,
+    last_error = null,
+    retry_at   = null,
+    updated_at = now()
+where id = $1;
+
+-- name: MarkPriceScheduleReverted :exec
+update price_schedules
+set status      = 'completed',
+    reverted_at = now(),
+    attempts    = 0,
+    last_error  = null,
+    retry_at    = null,
+    updated_at  = now()
+where id = $1;
+
+-- name: RecordPriceScheduleFailure :exec
+update price_schedules
+set attempts      = attempts + 1,
+    last_error    = sqlc.arg(last_error)::text,
+    retry_at      = sqlc.arg(retry_at)::timestamptz,
+    status        = case when attempts + 1 >= sqlc.arg(max_attempts)::int then 'failed' else status end,
+    failed_status = case when attempts + 1 >= sqlc.arg(max_attempts)::int then status end,
+    updated_at    = now()
+where id = sqlc.arg(id);
+
+-- name: RetryPriceSchedule :one
+update price_schedules
+set status        = failed_status,
+    failed_status = null,
+    attempts      = 0,
+    last_error    = null,
+    retry_at      = null,
+    updated_at    = now()
+where id = $1
+  and status = 'failed'
+returning id, product_id, brand_id, category_id, new_price_kopeck, starts_at, ends_at, status, applied_at, reverted_at, created_by, created_at, updated_at, attempts, last_error, retry_at, failed_status, currency;
+
+-- name: GetAllPromotions :many
+select id,
+       name,
+       type,
+       value,
+       product_id,
+       brand_id,
+       category_id,
+       starts_at,
+       ends_at,
+       priority,
+       is_stackable,
+       is_active,
+       created_at,
+       updated_at,
+       currency
+from promotions
+where true
+  and (sqlc.narg(is_active)::boolean is null or is_active = sqlc.narg(is_active))
+  and (sqlc.narg(product_id)::bigint is null or product_id = sqlc.narg(product_id))
+  and (sqlc.narg(brand_id)::bigint is null or brand_id = sqlc.narg(brand_id))
+  and (sqlc.narg(category_id)::bigint is null or category_id = sqlc.narg(category_id))
+  and (sqlc.narg(cursor_id)::bigint is null
+    or (not sqlc.arg(sort_desc)::boolean and case sqlc.arg(sort_by)::text
+                 when 'priority' then (priority, id) > (sqlc.narg(cursor_value)::text::int, sqlc.narg(cursor_id)::bigint)
+                 when 'starts_at' then (starts_at, id) > (sqlc.narg(cursor_value)::text::timestamptz, sqlc.narg(cursor_id)::bigint)
+                 else id > sqlc.narg(cursor_id)::bigint end)
+    or (sqlc.arg(sort_desc)::boolean and case sqlc.arg(sort_by)::text
+                 when 'priority' then (priority, id) < (sqlc.narg(cursor_value)::text::int, sqlc.narg(cursor_id)::bigint)
+                 when 'starts_at' then (starts_at, id) < (sqlc.narg(cursor_value)::text::timestamptz, sqlc.narg(cursor_id)::bigint)
+                 else id < sqlc.narg(cursor_id)::bigint end))
+order by case when sqlc.arg(sort_by)::text = 'priority' and not sqlc.arg(sort_desc)::boolean then priority end,
+         case when sqlc.arg(sort_by)::text = 'priority' and sqlc.arg(sort_desc)::boolean then priority end desc,
+         case when sqlc.arg(sort_by)::text = 'starts_at' and not sqlc.arg(sort_desc)::boolean then starts_at end,
+         case when sqlc.arg(sort_by)::text = 'starts_at' and sqlc.arg(sort_desc)::boolean then starts_at end desc,
+         case when not sqlc.arg(sort_desc)::boolean then id end,
+         case when sqlc.arg(sort_desc)::boolean then id end desc
+limit sqlc.arg(page_limit);
+
+-- name: CountPromotions :one
+select count(*)
+from promotions
+where true
+  and (sqlc.narg(is_active)::boolean is null or is_active = sqlc.narg(is_active))
+  and (sqlc.narg(product_id)::bigint is null or product_id = sqlc.narg(product_id))
+  and (sqlc.narg(brand_id)::bigint is null or brand_id = sqlc.narg(brand_id))
+  and (sqlc.narg(category_id)::bigint is null or category_id = sqlc.narg(category_id));
+
+-- name: GetPromotion :one
+select id,
+       name,
+       type,
+       value,
+       product_id,
+       brand_id,
+       category_id,
+       starts_at,
+       ends_at,
+       priority,
+       is_stackable,
+       is_active,
+       created_at,
+       updated_at,
+       currency
+from promotions
+where id = $1;
+
+-- name: CreatePromotion :one
+insert into promotions (name,
+                        type,
+                        value,
+                        currency,
+                        product_id,
+                        brand_id,
+                        category_id,
+                        starts_at,
+                        ends_at,
+                        priority,
+                        is_stackable,
+                        is_active)
+VALUES ($1,
+        $2,
+        $3,
+        $4,
+        $5,
+        $6,
+        $7,
+        $8,
+        $9,
+        $10,
+        $11,
+        $12)
+returning id, name, type, value, product_id, brand_id, category_id, starts_at, ends_at, priority, is_stackable, is_active, created_at, updated_at, currency;
+
+-- name: UpdatePromotion :one
+update promotions
+set name         = $2,
+    type         = $3,
+    value        = $4,
+    currency     = $5,
+    product_id   = $6,
+    brand_id     = $7,
+    category_id  = $8,
+    starts_at    = $9,
+    ends_at      = $10,
+    priority     = $11,
+    is_stackable = $12,
+    is_active    = $13,
+    updated_at   = now()
+where id = $1
+returning id, name, type, value, product_id, brand_id, category_id, starts_at, ends_at, priority, is_stackable, is_active, created_at, updated_at, currency;
+
+-- name: DeletePromotion :execrows
+delete
+from promotions
+where id = $1;
+
+-- name: GetApplicablePromotions :many
+with recursive product_categories as (select products.id as product_id,
+                                             products.category_id
+                                      from products
+                                      where products.id = any (sqlc.arg(product_ids)::bigint[])
+                                      union
+                                      select product_categories.product_id,
+                                             categories.parent_id
+                                      from product_categories
+                                               join categories on categories.id = product_categories.category_id
+                                      where categories.parent_id is not null)
+select products.id as product_id,
+       promotions.id,
+       promotions.name,
+       promotions.type,
+       promotions.value,
+       promotions.currency,
+       promotions.priority,
+       promotions.is_stackable
+from products
+         join promotions on promotions.product_id = products.id
+    or promotions.brand_id = products.brand_id
+    or promotions.category_id in (select product_categories.category_id
+                                  from product_categories
+                                  where product_categories.product_id = products.id)
+where products.id = any (sqlc.arg(product_ids)::bigint[])
+  and promotions.is_active
+  and promotions.starts_at <= now()
+  and (promotions.ends_at is null or promotions.ends_at > now())
+order by products.id, promotions.priority desc, promotions.id;
+
+-- name: GetAllPriceLists :many
+select *
+from price_lists
+where true
+  and (sqlc.narg(currency)::text is null or currency = sqlc.narg(currency))
+  and (sqlc.narg(customer_group)::text is null or customer_group = sqlc.narg(customer_group))
+  and (sqlc.narg(is_active)::boolean is null or is_active = sqlc.narg(is_active))
+  and (sqlc.narg(cursor_id)::bigint is null
+    or (not sqlc.arg(sort_desc)::boolean and case sqlc.arg(sort_by)::text
+                 when 'name' then (name, id) > (sqlc.narg(cursor_value)::text, sqlc.narg(cursor_id)::bigint)
+                 else id > sqlc.narg(cursor_id)::bigint end)
+    or (sqlc.arg(sort_desc)::boolean and case sqlc.arg(sort_by)::text
+                 when 'name' then (name, id) < (sqlc.narg(cursor_value)::text, sqlc.narg(cursor_id)::bigint)
+                 else id < sqlc.narg(cursor_id)::bigint end))
+order by case when sqlc.arg(sort_by)::text = 'name' and not sqlc.arg(sort_desc)::boolean then name end,
+         case when sqlc.arg(sort_by)::text = 'name' and sqlc.arg(sort_desc)::boolean then name end desc,
+         case when not sqlc.arg(sort_desc)::boolean then id end,
+         case when sqlc.arg(sort_desc)::boolean then id end desc
+limit sqlc.arg(page_limit);
+
+-- name: CountPriceLists :one
+select count(*)
+from price_lists
+where true
+  and (sqlc.narg(currency)::text is null or currency = sqlc.narg(currency))
+  and (sqlc.narg(customer_group)::text is null or customer_group = sqlc.narg(customer_group))
+  and (sqlc.narg(is_active)::boolean is null or is_active = sqlc.narg(is_active));
+
+-- name: GetPriceList :one
+select *
+from price_lists
+where id = $1;
+
+-- name: GetActivePriceListByCode :one
+select *
+from price_lists
+where code = $1
+  and is_active;
+
+-- name: GetDefaultPriceList :one
+select *
+from price_lists
+where currency = $1
+  and customer_group is null
+  and is_active;
+
+-- name: CreatePriceList :one
+insert into price_lists (code,
+                         name,
+                         currency,
+                         customer_group,
+                         rounding_step,
+                         rounding_mode,
+                         is_active)
+VALUES ($1,
+        $2,
+        $3,
+        $4,
+        $5,
+        $6,
+        $7)
+returning *;
+
+-- name: UpdatePriceList :one
+update price_lists
+set code           = $2,
+    name           = $3,
+    currency       = $4,
+    customer_group = $5,
+    rounding_step  = $6,
+    rounding_mode  = $7,
+    is_active      = $8,
+    updated_at     = now()
+where id = $1
+returning *;
+
+-- name: DeletePriceList :execrows
+delete
+from price_lists
+where id = $1;
+
+-- name: CountPriceListItems :one
+select count(*)
+from price_list_items
+         join products on price_list_items.product_id = products.id
+where price_list_items.price_list_id = $1
   and products.deleted_at is null;
 
+-- name: GetPriceListItems :many
+select price_list_items.product_id,
+       products.name as product_name,
+       price_list_items.amount,
+       price_list_items.created_at,
+       price_list_items.updated_at
+from price_list_items
+         join products on price_list_items.product_id = products.id
+where price_list_items.price_list_id = sqlc.arg(price_list_id)
+  and products.deleted_at is null
+  and (sqlc.narg(cursor_id)::bigint is null
+    or (not sqlc.arg(sort_desc)::boolean and price_list_items.product_id > sqlc.narg(cursor_id))
+    or (sqlc.arg(sort_desc)::boolean and price_list_items.product_id < sqlc.narg(cursor_id)))
+order by case when not sqlc.arg(sort_desc)::boolean then price_list_items.product_id end,
+         case when sqlc.arg(sort_desc)::boolean then price_list_items.product_id end desc
+limit sqlc.arg(page_limit);
+
+-- name: GetPriceListItemsByProductIds :many
+select product_id,
+       amount
+from price_list_items
+where price_list_id = sqlc.arg(price_list_id)
+  and product_id = any (sqlc.arg(product_ids)::bigint[]);
+
+-- name: UpsertPriceListItem :one
+insert into price_list_items (price_list_id, product_id, amount)
+VALUES ($1, $2, $3)
+on conflict (price_list_id, product_id) do update set amount     = excluded.amount,
+                                                      updated_at = now()
+returning *;
+
+-- name: DeletePriceListItem :execrows
+delete
+from price_list_items
+where price_list_id = $1
+  and product_id = $2;
+
+-- name: GetExchangeRates :many
+select id,
+       base_currency,
+       quote_currency,
+       rate::text as rate,
+       created_at,
+       updated_at
+from exchange_rates
+order by base_currency, quote_currency;
+
+-- name: UpsertExchangeRate :one
+insert into exchange_rates (base_currency, quote_currency, rate)
+VALUES (sqlc.arg(base_currency), sqlc.arg(quote_currency), sqlc.arg(rate)::text::numeric)
+on conflict (base_currency, quote_currency) do update set rate       = excluded.rate,
+                                                          updated_at = now()
+returning id, base_currency, quote_currency, rate::text as rate, created_at, updated_at;
+
+-- name: DeleteExchangeRate :execrows
+delete
+from exchange_rates
+where id = $1;
+
 -- name: CreateAdminUser :one
 insert into admin_users (email, password_hash)
 VALUES ($1, $2)
@@ -246,3 +2235,253 @@ select id, email, password_hash, created_at, updated_at
 from admin_users
 where email = $1
   and deleted_at is null;
+
+-- name: GetCatalogVersion :one
+select catalog_version.version,
+       coalesce((select string_agg(promotions.id::text, ',' order by promotions.id)
+                 from promotions
+                 where promotions.is_active
+                   and promotions.starts_at <= now()
+                   and (promotions.ends_at is null or promotions.ends_at > now())), '')::text as promotions
+from catalog_version;
+
+-- name: GetFeedProducts :many
+select products.id,
+       products.name,
+       products.slug,
+       products.description,
+       products.price_kopeck,
+       products.currency,
+       products.category_id,
+       brands.name as brand_name,
+       coalesce((select sum(inventory_movements.delta)
+                 from inventory_movements
+                 where inventory_movements.product_id = products.id), 0)::bigint as quantity
+from products
+         join brands on products.brand_id = brands.id
+         join categories on products.category_id = categories.id
+where products.deleted_at is null
+  and products.is_active = true
+  and brands.deleted_at is null
+  and categories.deleted_at is null
+  and (sqlc.narg(cursor_id)::bigint is null or products.id > sqlc.narg(cursor_id))
+order by products.id
+limit sqlc.arg(page_limit);
+
+-- name: GetTakenBrandSlugs :many
+select slug
+from brands
+where id <> sqlc.arg(id)
+  and (slug = sqlc.arg(slug) or left(slug, length(sqlc.arg(slug)) + 1) = sqlc.arg(slug) || '-');
+
+-- name: GetTakenCategorySlugs :many
+select slug
+from categories
+where id <> sqlc.arg(id)
+  and (slug = sqlc.arg(slug) or left(slug, length(sqlc.arg(slug)) + 1) = sqlc.arg(slug) || '-');
+
+-- name: GetTakenProductSlugs :many
+select slug
+from products
+where id <> sqlc.arg(id)
+  and (slug = sqlc.arg(slug) or left(slug, length(sqlc.arg(slug)) + 1) = sqlc.arg(slug) || '-');
+
+-- name: CreateSlugHistory :exec
+insert into slug_history (slug, brand_id, category_id, product_id)
+VALUES (sqlc.arg(slug), sqlc.narg(brand_id), sqlc.narg(category_id), sqlc.narg(product_id));
+
+-- name: DeleteBrandSlugHistory :exec
+delete
+from slug_history
+where brand_id is not null
+  and slug = $1;
+
+-- name: DeleteCategorySlugHistory :exec
+delete
+from slug_history
+where category_id is not null
+  and slug = $1;
+
+-- name: DeleteProductSlugHistory :exec
+delete
+from slug_history
+where product_id is not null
+  and slug = $1;
+
+-- name: ResolveBrandSlug :one
+select resolved.id,
+       resolved.slug
+from (select brands.id, brands.slug, 0 as rank
+      from brands
+      where brands.slug = sqlc.arg(slug)
+        and brands.deleted_at is null
+      union all
+      select brands.id, brands.slug, 1 as rank
+      from slug_history
+               join brands on slug_history.brand_id = brands.id
+      where slug_history.slug = sqlc.arg(slug)
+        and slug_history.brand_id is not null
+        and brands.deleted_at is null) as resolved
+order by resolved.rank
+limit 1;
+
+-- name: ResolveCategorySlug :one
+select resolved.id,
+       resolved.slug
+from (select categories.id, categories.slug, 0 as rank
+      from categories
+      where categories.slug = sqlc.arg(slug)
+        and categories.deleted_at is null
+      union all
+      select categories.id, categories.slug, 1 as rank
+      from slug_history
+               join categories on slug_history.category_id = categories.id
+      where slug_history.slug = sqlc.arg(slug)
+        and slug_history.category_id is not null
+        and categories.deleted_at is null) as resolved
+order by resolved.rank
+limit 1;
+
+-- name: ResolveProductSlug :one
+select resolved.id,
+       resolved.slug
+from (select products.id, products.slug, 0 as rank
+      from products
+      where products.slug = sqlc.arg(slug)
+      union all
+      select products.id, products.slug, 1 as rank
+      from slug_history
+               join products on slug_history.product_id = products.id
+      where slug_history.slug = sqlc.arg(slug)
+        and slug_history.product_id is not null) as resolved
+         join products on resolved.id = products.id
+         join brands on products.brand_id = brands.id
+         join categories on products.category_id = categories.id
+where products.deleted_at is null
+  and products.is_active = true
+  and brands.deleted_at is null
+  and categories.deleted_at is null
+order by resolved.rank
+limit 1;
+
+-- name: GetFrontProductBySlug :one
+select products.id,
+       products.name,
+       products.slug,
+       products.description,
+       products.price_kopeck,
+       products.currency,
+       brands.id            as brand_id,
+       brands.name          as brand_name,
+       brands.slug          as brand_slug,
+       categories.id        as category_id,
+       categories.name      as category_name,
+       categories.slug      as category_slug,
+       categories.parent_id as category_parent_id
+from products
+         join brands on products.brand_id = brands.id
+         join categories on products.category_id = categories.id
+where products.slug = $1
+  and products.deleted_at is null
+  and products.is_active = true
+  and brands.deleted_at is null
+  and categories.deleted_at is null
+limit 1;
+
+-- name: GetFrontBrandBySlug :one
+select id,
+       name,
+       slug
+from brands
+where slug = $1
+  and deleted_at is null
+limit 1;
+
+-- name: GetFrontCategoryBySlug :one
+select id,
+       name,
+       slug,
+       parent_id
+from categories
+where slug = $1
+  and deleted_at is null
+limit 1;
+
+-- name: PatchBrand :one
+update brands
+SET name       = coalesce(sqlc.narg(name), name),
+    slug       = coalesce(sqlc.narg(slug), slug),
+    updated_at = case
+                     when (name, slug) is distinct from (coalesce(sqlc.narg(name), name), coalesce(sqlc.narg(slug), slug))
+                         then now()
+                     else updated_at end
+where id = sqlc.arg(id)
+  and deleted_at is null
+returning id,
+    name,
+    slug,
+    created_at,
+    updated_at,
+    version;
+
+-- name: PatchCategory :one
+update categories
+SET name       = coalesce(sqlc.narg(name), name),
+    slug       = coalesce(sqlc.narg(slug), slug),
+    parent_id  = case when sqlc.arg(set_parent_id)::boolean then sqlc.narg(parent_id) else parent_id end,
+    updated_at = case
+                     when (name, slug, parent_id) is distinct from
+                          (coalesce(sqlc.narg(name), name), coalesce(sqlc.narg(slug), slug),
+                           case when sqlc.arg(set_parent_id)::boolean then sqlc.narg(parent_id) else parent_id end)
+                         then now()
+                     else updated_at end
+where id = sqlc.arg(id)
+  and deleted_at is null
+returning id,
+    name,
+    slug,
+    parent_id,
+    created_at,
+    updated_at,
+    version;
+
+-- name: PatchProduct :one
+update products
+SET brand_id     = coalesce(sqlc.narg(brand_id), brand_id),
+    category_id  = coalesce(sqlc.narg(category_id), category_id),
+    name         = coalesce(sqlc.narg(name), name),
+    slug         = coalesce(sqlc.narg(slug), slug),
+    description  = coalesce(sqlc.narg(description), description),
+    price_kopeck = coalesce(sqlc.narg(price_kopeck), price_kopeck),
+    is_active    = coalesce(sqlc.narg(is_active), is_active),
+    updated_at   = case
+                       when (brand_id, category_id, name, slug, description, price_kopeck, is_active)
+                           is distinct from (coalesce(sqlc.narg(brand_id), brand_id),
+                                             coalesce(sqlc.narg(category_id), category_id),
+                                             coalesce(sqlc.narg(name), name),
+                                             coalesce(sqlc.narg(slug), slug),
+                                             coalesce(sqlc.narg(description), description),
+                                             coalesce(sqlc.narg(price_kopeck), price_kopeck),
+                                             coalesce(sqlc.narg(is_active), is_active)) then now()
+                       else updated_at end
+where id = sqlc.arg(id)
+  and deleted_at is null
+returning id,
+    brand_id,
+    category_id,
+    name,
+    slug,
+    description,
+    price_kopeck,
+    currency,
+    is_active,
+    created_at,
+    updated_at,
+    version;
+
+-- name: LockCategory :one
+select version
+from categories
+where id = $1
+  and deleted_at is null
+for update;
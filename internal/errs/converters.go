@@ -2,8 +2,26 @@ package errs
 
 import (
 	"net/http"
+	"strings"
 )
 
+// ProblemTypePrefix starts the type URI of every problem, the name of the code follows it.
+const ProblemTypePrefix = "urn:go-with-tools:problem:"
+
+// codes are all the codes, so a name can be parsed back.
+var codes = []Code{
+	InternalErrCode,
+	UnauthorizedErrCode,
+	BadRequestErrCode,
+	UnprocessableEntityErrCode,
+	ConflictErrCode,
+	NotFoundErrCode,
+	PreconditionFailedErrCode,
+	ValidationErrCode,
+	ServiceUnavailableErrCode,
+	GatewayTimeoutErrCode,
+}
+
 func (e *AppError) HttpCode() int {
 	switch e.Code {
 	case UnauthorizedErrCode:
@@ -16,7 +34,82 @@ func (e *AppError) HttpCode() int {
 		return http.StatusConflict
 	case NotFoundErrCode:
 		return http.StatusNotFound
+	case PreconditionFailedErrCode:
+		return http.StatusPreconditionFailed
+	case ValidationErrCode:
+		return http.StatusBadRequest
+	case ServiceUnavailableErrCode:
+		return http.StatusServiceUnavailable
+	case GatewayTimeoutErrCode:
+		return http.StatusGatewayTimeout
 	default:
 		return http.StatusInternalServerError
 	}
 }
+
+// String is the machine-readable name of the code clients can rely on.
+func (c Code) String() string {
+	switch c {
+	case UnauthorizedErrCode:
+		return "unauthorized"
+	case BadRequestErrCode:
+		return "bad_request"
+	case UnprocessableEntityErrCode:
+		return "unprocessable_entity"
+	case ConflictErrCode:
+		return "conflict"
+	case NotFoundErrCode:
+		return "not_found"
+	case PreconditionFailedErrCode:
+		return "precondition_failed"
+	case ValidationErrCode:
+		return "validation_failed"
+	case ServiceUnavailableErrCode:
+		return "service_unavailable"
+	case GatewayTimeoutErrCode:
+		return "gateway_timeout"
+	default:
+		return "internal_error"
+	}
+}
+
+// ParseCode returns the code with the name String gives it.
+func ParseCode(name string) (Code, bool) {
+	for _, code := range codes {
+		if code.String() == name {
+			return code, true
+		}
+	}
+	return InternalErrCode, false
+}
+
+// Type is the stable URI identifying the kind of problem, e.g. urn:go-with-tools:problem:not-found.
+func (c Code) Type() string {
+	return ProblemTypePrefix + strings.ReplaceAll(c.String(), "_", "-")
+}
+
+// Title is the short human-readable summary of the kind of problem, it doesn't change between occurrences.
+func (c Code) Title() string {
+	switch c {
+	case UnauthorizedErrCode:
+		return "Unauthorized"
+	case BadRequestErrCode:
+		return "Bad request"
+	case UnprocessableEntityErrCode:
+		return "Unprocessable entity"
+	case ConflictErrCode:
+		return "Conflict"
+	case NotFoundErrCode:
+		return "Not found"
+	case PreconditionFailedErrCode:
+		return "Precondition failed"
+	case ValidationErrCode:
+		return "Validation failed"
+	case ServiceUnavailableErrCode:
+		return "Service unavailable"
+	case GatewayTimeoutErrCode:
+		return "Gateway timeout"
+	default:
+		return "Internal server error"
+	}
+}
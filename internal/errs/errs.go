@@ -1,5 +1,10 @@
 package errs
 
+import (
+	"errors"
+	"strings"
+)
+
 type Code int
 
 const (
@@ -9,24 +14,58 @@ const (
 	UnprocessableEntityErrCode
 	ConflictErrCode
 	NotFoundErrCode
+	PreconditionFailedErrCode
+	ValidationErrCode
+	ServiceUnavailableErrCode
+	GatewayTimeoutErrCode
 )
 
 type AppError struct {
 	Code Code
 	Err  error
+	// Violations are the invalid fields of a request that failed validation.
+	Violations []Violation
+	// Extensions are the members added to the problem the error is rendered as, e.g. the current
+	// version of a record on 412.
+	Extensions map[string]any
+}
+
+// Violation is a field of a request that broke a validation rule. Field is the path of the field
+// in the request, e.g. price.amount, Rule is the name of the broken rule, e.g. required.
+type Violation struct {
+	Field   string
+	Rule    string
+	Message string
 }
 
+// Error is the message shown to the client, the errors of the server and of the services it calls
+// hide their cause, which only goes to the log.
 func (e *AppError) Error() string {
-	if e.Code == InternalErrCode {
+	switch e.Code {
+	case InternalErrCode:
 		return "internal server error"
+	case ServiceUnavailableErrCode:
+		return "service is temporarily unavailable"
+	case GatewayTimeoutErrCode:
+		return "service didn't answer in time"
+	default:
+		return e.Err.Error()
 	}
-	return e.Err.Error()
 }
 
 func (e *AppError) Unwrap() error {
 	return e.Err
 }
 
+// With adds an extension member to the problem the error is rendered as.
+func (e *AppError) With(key string, value any) *AppError {
+	if e.Extensions == nil {
+		e.Extensions = make(map[string]any)
+	}
+	e.Extensions[key] = value
+	return e
+}
+
 func Internal(err error) *AppError {
 	return &AppError{
 		Code: InternalErrCode,
@@ -68,3 +107,37 @@ func NotFound(err error) *AppError {
 		Err:  err,
 	}
 }
+
+func PreconditionFailed(err error) *AppError {
+	return &AppError{
+		Code: PreconditionFailedErrCode,
+		Err:  err,
+	}
+}
+
+func ServiceUnavailable(err error) *AppError {
+	return &AppError{
+		Code: ServiceUnavailableErrCode,
+		Err:  err,
+	}
+}
+
+func GatewayTimeout(err error) *AppError {
+	return &AppError{
+		Code: GatewayTimeoutErrCode,
+		Err:  err,
+	}
+}
+
+// Validation is the error of a request with invalid fields, its message lists all of them.
+func Validation(violations []Violation) *AppError {
+	messages := make([]string, len(violations))
+	for i, violation := range violations {
+		messages[i] = violation.Field + " " + violation.Message
+	}
+	return &AppError{
+		Code:       ValidationErrCode,
+		Err:        errors.New(strings.Join(messages, "; ")),
+		Violations: violations,
+	}
+}
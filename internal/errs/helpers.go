@@ -16,6 +16,26 @@ func UnprocessableEntityFromConstraint(constraint string) *AppError {
 		return BadRequest(errors.New("there is no brand with such id"))
 	case "fk_products_category_id":
 		return BadRequest(errors.New("there is no category with such id"))
+	case "fk_attribute_definitions_category_id":
+		return BadRequest(errors.New("there is no category with such id"))
+	case "fk_product_variants_product_id":
+		return BadRequest(errors.New("there is no product with such id"))
+	case "fk_price_schedules_product_id":
+		return BadRequest(errors.New("there is no product with such id"))
+	case "fk_price_schedules_brand_id":
+		return BadRequest(errors.New("there is no brand with such id"))
+	case "fk_price_schedules_category_id":
+		return BadRequest(errors.New("there is no category with such id"))
+	case "fk_promotions_product_id":
+		return BadRequest(errors.New("there is no product with such id"))
+	case "fk_promotions_brand_id":
+		return BadRequest(errors.New("there is no brand with such id"))
+	case "fk_promotions_category_id":
+		return BadRequest(errors.New("there is no category with such id"))
+	case "fk_price_list_items_price_list_id":
+		return BadRequest(errors.New("there is no price list with such id"))
+	case "fk_price_list_items_product_id":
+		return BadRequest(errors.New("there is no product with such id"))
 	default:
 		return Internal(errors.New("constraint\"" + constraint + "\"not handled in BadRequestFromConstraint function"))
 	}
@@ -31,6 +51,24 @@ func ConflictFromConstraint(constraint string) *AppError {
 		return Conflict(errors.New("category's slug already exists"))
 	case "products_slug_key":
 		return Conflict(errors.New("product's slug already exists"))
+	case "slug_history_brand_slug_key":
+		return Conflict(errors.New("brand's old slug already exists"))
+	case "slug_history_category_slug_key":
+		return Conflict(errors.New("category's old slug already exists"))
+	case "slug_history_product_slug_key":
+		return Conflict(errors.New("product's old slug already exists"))
+	case "product_variants_sku_key":
+		return Conflict(errors.New("variant's sku already exists"))
+	case "product_variants_barcode_key":
+		return Conflict(errors.New("variant's barcode already exists"))
+	case "product_variants_options_key":
+		return Conflict(errors.New("product already has a variant with such options"))
+	case "attribute_definitions_category_id_code_key":
+		return Conflict(errors.New("category already has an attribute with such code"))
+	case "price_lists_code_key":
+		return Conflict(errors.New("price list's code already exists"))
+	case "price_lists_default_currency_key":
+		return Conflict(errors.New("there is already an active price list without customer group in this currency"))
 	case "admin_users_email_key":
 		return Conflict(errors.New("admin_user's email already exists"))
 	default:
@@ -0,0 +1,41 @@
+package export
+
+import (
+	"encoding/csv"
+	"io"
+)
+
+type csvWriter struct {
+	w   *csv.Writer
+	row []string
+}
+
+func newCsvWriter(w io.Writer) *csvWriter {
+	return &csvWriter{w: csv.NewWriter(w)}
+}
+
+func (w *csvWriter) WriteHeader(columns ...string) error {
+	return w.w.Write(columns)
+}
+
+func (w *csvWriter) WriteRow(values ...any) error {
+	w.row = w.row[:0]
+	for _, value := range values {
+		text, _ := cell(value)
+		w.row = append(w.row, text)
+	}
+	return w.w.Write(w.row)
+}
+
+func (w *csvWriter) Close() error {
+	w.w.Flush()
+	return w.w.Error()
+}
+
+func (w *csvWriter) ContentType() string {
+	return "text/csv; charset=utf-8"
+}
+
+func (w *csvWriter) Extension() string {
+	return FormatCSV
+}
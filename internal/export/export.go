@@ -0,0 +1,94 @@
+package export
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/Aoladiy/go-with-tools/internal/errs"
+)
+
+const (
+	FormatCSV   = "csv"
+	FormatJSONL = "jsonl"
+	FormatXLSX  = "xlsx"
+)
+
+// Writer writes a table row by row straight to the underlying writer. Nothing is written before
+// WriteHeader, so an error found before it can still be answered normally. The output is only
+// complete after Close.
+type Writer interface {
+	WriteHeader(columns ...string) error
+	WriteRow(values ...any) error
+	Close() error
+	ContentType() string
+	Extension() string
+}
+
+// Decimal is a number kept as text, like a price, it's written as a number where the format has them.
+type Decimal string
+
+// NewWriter returns the writer of the format, csv if it's empty. name is the sheet name in xlsx.
+func NewWriter(format, name string, w io.Writer) (Writer, *errs.AppError) {
+	switch format {
+	case "", FormatCSV:
+		return newCsvWriter(w), nil
+	case FormatJSONL:
+		return newJsonlWriter(w), nil
+	case FormatXLSX:
+		return newXlsxWriter(w, name), nil
+	default:
+		return nil, errs.BadRequest(fmt.Errorf("unknown format %q, expected csv, jsonl or xlsx", format))
+	}
+}
+
+type kind int
+
+const (
+	kindEmpty kind = iota
+	kindString
+	kindNumber
+	kindBool
+)
+
+// cell returns the text of the value and what it is, pointers are dereferenced and nil ones are empty.
+func cell(value any) (string, kind) {
+	switch v := value.(type) {
+	case nil:
+		return "", kindEmpty
+	case string:
+		return v, kindString
+	case Decimal:
+		return string(v), kindNumber
+	case int:
+		return strconv.Itoa(v), kindNumber
+	case int32:
+		return strconv.FormatInt(int64(v), 10), kindNumber
+	case int64:
+		return strconv.FormatInt(v, 10), kindNumber
+	case bool:
+		return strconv.FormatBool(v), kindBool
+	case time.Time:
+		return v.Format(time.RFC3339), kindString
+	case *string:
+		return deref(v)
+	case *int32:
+		return deref(v)
+	case *int64:
+		return deref(v)
+	case *bool:
+		return deref(v)
+	case *time.Time:
+		return deref(v)
+	default:
+		return fmt.Sprint(v), kindString
+	}
+}
+
+func deref[T any](pointer *T) (string, kind) {
+	if pointer == nil {
+		return "", kindEmpty
+	}
+	return cell(*pointer)
+}
@@ -0,0 +1,66 @@
+package export
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+)
+
+// jsonlWriter writes every row as an object with the columns as keys, in the order of the columns.
+type jsonlWriter struct {
+	w       *bufio.Writer
+	columns []string
+	line    []byte
+}
+
+func newJsonlWriter(w io.Writer) *jsonlWriter {
+	return &jsonlWriter{w: bufio.NewWriter(w)}
+}
+
+func (w *jsonlWriter) WriteHeader(columns ...string) error {
+	w.columns = columns
+	return nil
+}
+
+func (w *jsonlWriter) WriteRow(values ...any) error {
+	w.line = append(w.line[:0], '{')
+	for i, value := range values {
+		if i > 0 {
+			w.line = append(w.line, ',')
+		}
+		key, err := json.Marshal(w.columns[i])
+		if err != nil {
+			return err
+		}
+		w.line = append(append(w.line, key...), ':')
+
+		text, kind := cell(value)
+		switch kind {
+		case kindEmpty:
+			w.line = append(w.line, "null"...)
+		case kindNumber, kindBool:
+			w.line = append(w.line, text...)
+		default:
+			quoted, err := json.Marshal(text)
+			if err != nil {
+				return err
+			}
+			w.line = append(w.line, quoted...)
+		}
+	}
+	w.line = append(w.line, '}', '\n')
+	_, err := w.w.Write(w.line)
+	return err
+}
+
+func (w *jsonlWriter) Close() error {
+	return w.w.Flush()
+}
+
+func (w *jsonlWriter) ContentType() string {
+	return "application/x-ndjson"
+}
+
+func (w *jsonlWriter) Extension() string {
+	return FormatJSONL
+}
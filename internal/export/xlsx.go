@@ -0,0 +1,145 @@
+package export
+
+import (
+	"archive/zip"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+const (
+	xlsxContentTypes = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types"><Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/><Default Extension="xml" ContentType="application/xml"/><Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/><Override PartName="/xl/worksheets/sheet1.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/></Types>`
+	xlsxRels = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships"><Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/></Relationships>`
+	xlsxWorkbook = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships"><sheets><sheet name="%s" sheetId="1" r:id="rId1"/></sheets></workbook>`
+	xlsxWorkbookRels = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships"><Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet1.xml"/></Relationships>`
+	xlsxSheetStart = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>`
+	xlsxSheetEnd = `</sheetData></worksheet>`
+
+	// xlsxMaxRows is the row limit of an Excel sheet, the header included.
+	xlsxMaxRows = 1048576
+)
+
+// xlsxWriter writes a workbook with a single sheet. The sheet is the last entry of the zip and its
+// rows are compressed as they come, with inline strings instead of a shared strings table that
+// would have to be kept until the end.
+type xlsxWriter struct {
+	zip   *zip.Writer
+	sheet io.Writer
+	name  string
+	rows  int
+}
+
+func newXlsxWriter(w io.Writer, name string) *xlsxWriter {
+	return &xlsxWriter{zip: zip.NewWriter(w), name: name}
+}
+
+func (w *xlsxWriter) WriteHeader(columns ...string) error {
+	var name strings.Builder
+	err := xml.EscapeText(&name, []byte(w.name))
+	if err != nil {
+		return err
+	}
+	parts := []struct{ path, content string }{
+		{"[Content_Types].xml", xlsxContentTypes},
+		{"_rels/.rels", xlsxRels},
+		{"xl/workbook.xml", fmt.Sprintf(xlsxWorkbook, name.String())},
+		{"xl/_rels/workbook.xml.rels", xlsxWorkbookRels},
+	}
+	for _, part := range parts {
+		entry, err := w.zip.Create(part.path)
+		if err != nil {
+			return err
+		}
+		_, err = io.WriteString(entry, part.content)
+		if err != nil {
+			return err
+		}
+	}
+
+	sheet, err := w.zip.Create("xl/worksheets/sheet1.xml")
+	if err != nil {
+		return err
+	}
+	w.sheet = sheet
+	_, err = io.WriteString(w.sheet, xlsxSheetStart)
+	if err != nil {
+		return err
+	}
+
+	values := make([]any, len(columns))
+	for i, column := range columns {
+		values[i] = column
+	}
+	return w.WriteRow(values...)
+}
+
+// WriteRow fails past xlsxMaxRows rather than writing a sheet Excel refuses to open.
+func (w *xlsxWriter) WriteRow(values ...any) error {
+	if w.rows == xlsxMaxRows {
+		return fmt.Errorf("xlsx sheet holds at most %d rows, export as csv or jsonl instead", xlsxMaxRows)
+	}
+	w.rows++
+	row := []byte(`<row r="` + strconv.Itoa(w.rows) + `">`)
+	for i, value := range values {
+		text, kind := cell(value)
+		ref := columnName(i) + strconv.Itoa(w.rows)
+		switch kind {
+		case kindEmpty:
+			continue
+		case kindNumber:
+			row = append(row, `<c r="`+ref+`"><v>`+text+`</v></c>`...)
+		case kindBool:
+			bit := "0"
+			if text == "true" {
+				bit = "1"
+			}
+			row = append(row, `<c r="`+ref+`" t="b"><v>`+bit+`</v></c>`...)
+		default:
+			var escaped strings.Builder
+			err := xml.EscapeText(&escaped, []byte(text))
+			if err != nil {
+				return err
+			}
+			row = append(row, `<c r="`+ref+`" t="inlineStr"><is><t xml:space="preserve">`+escaped.String()+`</t></is></c>`...)
+		}
+	}
+	row = append(row, `</row>`...)
+	_, err := w.sheet.Write(row)
+	return err
+}
+
+func (w *xlsxWriter) Close() error {
+	if w.sheet == nil {
+		return errors.New("xlsx header is not written")
+	}
+	_, err := io.WriteString(w.sheet, xlsxSheetEnd)
+	if err != nil {
+		return err
+	}
+	return w.zip.Close()
+}
+
+func (w *xlsxWriter) ContentType() string {
+	return "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+}
+
+func (w *xlsxWriter) Extension() string {
+	return FormatXLSX
+}
+
+// columnName returns the letters of the zero based column index: A, B, ..., Z, AA, AB and so on.
+func columnName(index int) string {
+	name := ""
+	for index++; index > 0; index = (index - 1) / 26 {
+		name = string(rune('A'+(index-1)%26)) + name
+	}
+	return name
+}
@@ -0,0 +1,251 @@
+package feed
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/xml"
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Aoladiy/go-with-tools/internal/DTO"
+	"github.com/Aoladiy/go-with-tools/internal/database/queries"
+	"github.com/Aoladiy/go-with-tools/internal/errs"
+	"github.com/Aoladiy/go-with-tools/internal/helpers"
+	"github.com/Aoladiy/go-with-tools/internal/media"
+	"github.com/Aoladiy/go-with-tools/internal/money"
+	"github.com/Aoladiy/go-with-tools/internal/promotion"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const (
+	FormatYandex = "yandex"
+	FormatGoogle = "google"
+
+	// maxPictures is the most pictures an offer of either feed may have.
+	maxPictures = 10
+
+	generateTimeout = time.Minute
+)
+
+// Shop describes the storefront the feeds are published for.
+type Shop struct {
+	Name    string
+	Company string
+	URL     string
+}
+
+// Feed is a generated feed document.
+type Feed struct {
+	Body        []byte
+	ETag        string
+	GeneratedAt time.Time
+}
+
+type Service struct {
+	q         *queries.Queries
+	p         *pgxpool.Pool
+	media     *media.Service
+	promotion *promotion.Service
+	shop      Shop
+
+	mu    sync.Mutex
+	cache map[string]cachedFeed
+}
+
+type cachedFeed struct {
+	version string
+	feed    Feed
+}
+
+func New(q *queries.Queries, p *pgxpool.Pool, media *media.Service, promotion *promotion.Service, shop Shop) *Service {
+	return &Service{
+		q:         q,
+		p:         p,
+		media:     media,
+		promotion: promotion,
+		shop:      shop,
+		cache:     make(map[string]cachedFeed),
+	}
+}
+
+// Get returns the feed in the format, generating it only if the catalog has changed since the last time.
+// Any write to products, brands, categories, inventory, media or promotions changes the catalog, and so
+// does a promotion starting or ending.
+func (s *Service) Get(ctx context.Context, format string) (Feed, *errs.AppError) {
+	var generate func(catalog catalog) ([]byte, error)
+	switch format {
+	case FormatYandex:
+		generate = s.yandex
+	case FormatGoogle:
+		generate = s.google
+	default:
+		return Feed{}, errs.NotFound(fmt.Errorf("feed %q not found", format))
+	}
+
+	version, err := s.q.GetCatalogVersion(ctx)
+	if err != nil {
+		return Feed{}, errs.Internal(err)
+	}
+	key := fmt.Sprintf("%d:%s", version.Version, version.Promotions)
+
+	// generating under the lock keeps concurrent requests from building the same feed at once
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if cached, ok := s.cache[format]; ok && cached.version == key {
+		return cached.feed, nil
+	}
+
+	timeout, cancel := context.WithTimeout(ctx, generateTimeout)
+	defer cancel()
+	catalog, appErr := s.load(timeout)
+	if appErr != nil {
+		return Feed{}, appErr
+	}
+	body, err := generate(catalog)
+	if err != nil {
+		return Feed{}, errs.Internal(err)
+	}
+
+	feed := Feed{
+		Body:        body,
+		ETag:        fmt.Sprintf(`"%s-%x"`, format, sha256.Sum256([]byte(key))),
+		GeneratedAt: catalog.generatedAt,
+	}
+	s.cache[format] = cachedFeed{version: key, feed: feed}
+	return feed, nil
+}
+
+// catalog is everything the feeds are built from.
+type catalog struct {
+	generatedAt time.Time
+	categories  []queries.GetAllFrontCategoriesRow
+	offers      []offer
+	currencies  []string
+}
+
+// offer is an active product ready to be put into a feed.
+type offer struct {
+	id          int64
+	name        string
+	description string
+	url         string
+	categoryId  int64
+	vendor      string
+	quantity    int64
+	price       money.Money
+	oldPrice    *money.Money
+	pictures    []string
+}
+
+// load reads the active products in batches together with their pictures, promotions in effect and stock.
+func (s *Service) load(ctx context.Context) (catalog, *errs.AppError) {
+	categories, err := s.q.GetAllFrontCategories(ctx)
+	if err != nil {
+		return catalog{}, errs.Internal(err)
+	}
+	loaded := catalog{
+		generatedAt: time.Now(),
+		categories:  categories,
+	}
+
+	var (
+		pictures [][]DTO.MediaResponse
+		rules    map[int64][]promotion.Rule
+		index    map[int64]int
+	)
+	appErr := helpers.Walk(helpers.Page{}, func(page helpers.Page) ([]queries.GetFeedProductsRow, *errs.AppError) {
+		products, err := s.q.GetFeedProducts(ctx, queries.GetFeedProductsParams{
+			CursorID:  page.CursorId,
+			PageLimit: page.Limit + 1,
+		})
+		if err != nil {
+			return nil, errs.Internal(err)
+		}
+		ids := make([]int64, len(products))
+		index = make(map[int64]int, len(products))
+		for i, product := range products {
+			ids[i] = product.ID
+			index[product.ID] = i
+		}
+		var appErr *errs.AppError
+		pictures, appErr = s.media.ForProducts(ctx, ids)
+		if appErr != nil {
+			return nil, appErr
+		}
+		rules, appErr = s.promotion.Rules(ctx, ids)
+		if appErr != nil {
+			return nil, appErr
+		}
+		return products, nil
+	}, func(product queries.GetFeedProductsRow) (string, int64) {
+		return "", product.ID
+	}, func(product queries.GetFeedProductsRow) error {
+		resolved := promotion.Resolve(money.New(product.PriceKopeck, product.Currency), rules[product.ID])
+		item := offer{
+			id:          product.ID,
+			name:        product.Name,
+			description: product.Description,
+			url:         s.productURL(product.Slug),
+			categoryId:  product.CategoryID,
+			vendor:      product.BrandName,
+			quantity:    max(product.Quantity, 0),
+			price:       resolved.EffectivePrice,
+			pictures:    pictureURLs(pictures[index[product.ID]]),
+		}
+		if resolved.EffectivePrice.Amount < resolved.BasePrice.Amount {
+			item.oldPrice = &resolved.BasePrice
+		}
+		loaded.offers = append(loaded.offers, item)
+		if !slices.Contains(loaded.currencies, product.Currency) {
+			loaded.currencies = append(loaded.currencies, product.Currency)
+		}
+		return nil
+	})
+	if appErr != nil {
+		return catalog{}, appErr
+	}
+	return loaded, nil
+}
+
+func (s *Service) productURL(slug string) string {
+	return strings.TrimRight(s.shop.URL, "/") + "/products/" + url.PathEscape(slug)
+}
+
+// pictureURLs returns the urls of the media with the primary one first.
+func pictureURLs(media []DTO.MediaResponse) []string {
+	urls := make([]string, 0, min(len(media), maxPictures))
+	for _, item := range media {
+		if item.IsPrimary {
+			urls = append(urls, item.Url)
+		}
+	}
+	for _, item := range media {
+		if !item.IsPrimary && len(urls) < maxPictures {
+			urls = append(urls, item.Url)
+		}
+	}
+	return urls
+}
+
+// encode writes the xml declaration followed by the indented document.
+func encode(document any) ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteString(xml.Header)
+	encoder := xml.NewEncoder(&buf)
+	encoder.Indent("", "  ")
+	err := encoder.Encode(document)
+	if err != nil {
+		return nil, err
+	}
+	err = encoder.Close()
+	if err != nil {
+		return nil, err
+	}
+	buf.WriteByte('\n')
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,113 @@
+package feed
+
+import (
+	"encoding/xml"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+const googleNamespace = "http://base.google.com/ns/1.0"
+
+// googleRss is a Google Merchant Center feed, an RSS 2.0 document with product attributes
+// in the g namespace. The prefix is part of the element names, encoding/xml would otherwise
+// declare the namespace on every element.
+type googleRss struct {
+	XMLName xml.Name      `xml:"rss"`
+	Version string        `xml:"version,attr"`
+	XmlnsG  string        `xml:"xmlns:g,attr"`
+	Channel googleChannel `xml:"channel"`
+}
+
+type googleChannel struct {
+	Title       string       `xml:"title"`
+	Link        string       `xml:"link"`
+	Description string       `xml:"description"`
+	Items       []googleItem `xml:"item"`
+}
+
+type googleItem struct {
+	Id                   string   `xml:"g:id"`
+	Title                string   `xml:"title"`
+	Description          string   `xml:"description"`
+	Link                 string   `xml:"link"`
+	ImageLink            string   `xml:"g:image_link,omitempty"`
+	AdditionalImageLinks []string `xml:"g:additional_image_link"`
+	Availability         string   `xml:"g:availability"`
+	Price                string   `xml:"g:price"`
+	SalePrice            string   `xml:"g:sale_price,omitempty"`
+	Brand                string   `xml:"g:brand"`
+	ProductType          string   `xml:"g:product_type,omitempty"`
+	Condition            string   `xml:"g:condition"`
+}
+
+// google builds the Merchant Center feed. The price is the base one and the sale price is the
+// price after promotions if there are any, the product type is the path of the category.
+func (s *Service) google(catalog catalog) ([]byte, error) {
+	document := googleRss{
+		Version: "2.0",
+		XmlnsG:  googleNamespace,
+		Channel: googleChannel{
+			Title:       s.shop.Name,
+			Link:        s.shop.URL,
+			Description: s.shop.Company,
+		},
+	}
+
+	paths := categoryPaths(catalog)
+	for _, offer := range catalog.offers {
+		item := googleItem{
+			Id:           strconv.FormatInt(offer.id, 10),
+			Title:        offer.name,
+			Description:  offer.description,
+			Link:         offer.url,
+			Availability: "out_of_stock",
+			Price:        offer.price.String(),
+			Brand:        offer.vendor,
+			ProductType:  paths[offer.categoryId],
+			Condition:    "new",
+		}
+		if item.Description == "" {
+			item.Description = offer.name
+		}
+		if len(offer.pictures) > 0 {
+			item.ImageLink = offer.pictures[0]
+			item.AdditionalImageLinks = offer.pictures[1:]
+		}
+		if offer.quantity > 0 {
+			item.Availability = "in_stock"
+		}
+		if offer.oldPrice != nil {
+			item.Price = offer.oldPrice.String()
+			item.SalePrice = offer.price.String()
+		}
+		document.Channel.Items = append(document.Channel.Items, item)
+	}
+	return encode(document)
+}
+
+// categoryPaths returns the names of every category from the root joined with " > " keyed by id.
+func categoryPaths(catalog catalog) map[int64]string {
+	parents := make(map[int64]*int64, len(catalog.categories))
+	names := make(map[int64]string, len(catalog.categories))
+	for _, category := range catalog.categories {
+		parents[category.ID] = category.ParentID
+		names[category.ID] = category.Name
+	}
+
+	paths := make(map[int64]string, len(catalog.categories))
+	for _, category := range catalog.categories {
+		var path []string
+		// the depth is bounded by the number of categories in case the tree is broken
+		for id := &category.ID; id != nil && len(path) < len(catalog.categories); id = parents[*id] {
+			name, ok := names[*id]
+			if !ok {
+				break
+			}
+			path = append(path, name)
+		}
+		slices.Reverse(path)
+		paths[category.ID] = strings.Join(path, " > ")
+	}
+	return paths
+}
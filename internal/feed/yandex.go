@@ -0,0 +1,99 @@
+package feed
+
+import (
+	"encoding/xml"
+	"time"
+
+	"github.com/Aoladiy/go-with-tools/internal/money"
+)
+
+// ymlCatalog is a Yandex Market YML document.
+type ymlCatalog struct {
+	XMLName xml.Name `xml:"yml_catalog"`
+	Date    string   `xml:"date,attr"`
+	Shop    ymlShop  `xml:"shop"`
+}
+
+type ymlShop struct {
+	Name       string        `xml:"name"`
+	Company    string        `xml:"company"`
+	URL        string        `xml:"url"`
+	Currencies []ymlCurrency `xml:"currencies>currency"`
+	Categories []ymlCategory `xml:"categories>category"`
+	Offers     []ymlOffer    `xml:"offers>offer"`
+}
+
+type ymlCurrency struct {
+	Id   string `xml:"id,attr"`
+	Rate string `xml:"rate,attr"`
+}
+
+type ymlCategory struct {
+	Id       int64  `xml:"id,attr"`
+	ParentId *int64 `xml:"parentId,attr,omitempty"`
+	Name     string `xml:",chardata"`
+}
+
+type ymlOffer struct {
+	Id          int64    `xml:"id,attr"`
+	Available   bool     `xml:"available,attr"`
+	URL         string   `xml:"url"`
+	Price       string   `xml:"price"`
+	OldPrice    string   `xml:"oldprice,omitempty"`
+	CurrencyId  string   `xml:"currencyId"`
+	CategoryId  int64    `xml:"categoryId"`
+	Pictures    []string `xml:"picture"`
+	Name        string   `xml:"name"`
+	Vendor      string   `xml:"vendor"`
+	Description string   `xml:"description,omitempty"`
+	Count       int64    `xml:"count"`
+}
+
+// yandex builds the YML feed. The default currency has the rate of 1, the others are converted
+// by the marketplace at the rate of the Central Bank.
+func (s *Service) yandex(catalog catalog) ([]byte, error) {
+	document := ymlCatalog{
+		Date: catalog.generatedAt.Format(time.RFC3339),
+		Shop: ymlShop{
+			Name:    s.shop.Name,
+			Company: s.shop.Company,
+			URL:     s.shop.URL,
+		},
+	}
+
+	document.Shop.Currencies = []ymlCurrency{{Id: money.DefaultCurrency, Rate: "1"}}
+	for _, currency := range catalog.currencies {
+		if currency != money.DefaultCurrency {
+			document.Shop.Currencies = append(document.Shop.Currencies, ymlCurrency{Id: currency, Rate: "CBRF"})
+		}
+	}
+
+	for _, category := range catalog.categories {
+		document.Shop.Categories = append(document.Shop.Categories, ymlCategory{
+			Id:       category.ID,
+			ParentId: category.ParentID,
+			Name:     category.Name,
+		})
+	}
+
+	for _, offer := range catalog.offers {
+		item := ymlOffer{
+			Id:          offer.id,
+			Available:   offer.quantity > 0,
+			URL:         offer.url,
+			Price:       offer.price.Decimal(),
+			CurrencyId:  offer.price.Currency,
+			CategoryId:  offer.categoryId,
+			Pictures:    offer.pictures,
+			Name:        offer.name,
+			Vendor:      offer.vendor,
+			Description: offer.description,
+			Count:       offer.quantity,
+		}
+		if offer.oldPrice != nil {
+			item.OldPrice = offer.oldPrice.Decimal()
+		}
+		document.Shop.Offers = append(document.Shop.Offers, item)
+	}
+	return encode(document)
+}
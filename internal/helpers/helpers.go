@@ -10,6 +10,8 @@ import (
 	"github.com/Aoladiy/go-with-tools/internal/database/queries"
 	"github.com/Aoladiy/go-with-tools/internal/errs"
 
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
@@ -21,6 +23,13 @@ func DerefString(pointer *string, defaultValue string) (result string) {
 	return defaultValue
 }
 
+func DerefInt64(pointer *int64, defaultValue int64) (result int64) {
+	if pointer != nil {
+		return *pointer
+	}
+	return defaultValue
+}
+
 func DerefBool(pointer *bool, defaultValue bool) (result bool) {
 	if pointer != nil {
 		return *pointer
@@ -28,6 +37,59 @@ func DerefBool(pointer *bool, defaultValue bool) (result bool) {
 	return defaultValue
 }
 
+// CheckVersion compares the version of a record with the one the client expects it to have,
+// a nil expected version matches any.
+func CheckVersion(expected *int64, actual int64) *errs.AppError {
+	if expected != nil && *expected != actual {
+		return errs.PreconditionFailed(fmt.Errorf("version %d doesn't match the current version %d", *expected, actual)).With("version", actual)
+	}
+	return nil
+}
+
+// ValidateScope checks that exactly one of the product, the brand and the category a price rule
+// applies to is set and that it exists. The product is returned when the rule applies to one, so the
+// caller can check the rule against it.
+func ValidateScope(ctx context.Context, q *queries.Queries, productId, brandId, categoryId *int64) (*queries.GetProductRow, *errs.AppError) {
+	scopes := 0
+	for _, id := range []*int64{productId, brandId, categoryId} {
+		if id != nil {
+			scopes++
+		}
+	}
+	if scopes != 1 {
+		return nil, errs.BadRequest(errors.New("exactly one of product_id, brand_id and category_id must be set"))
+	}
+
+	switch {
+	case productId != nil:
+		product, err := q.GetProduct(ctx, *productId)
+		if err != nil {
+			return nil, errs.NotFound(fmt.Errorf("product with id=%d not found | %w", *productId, err))
+		}
+		return &product, nil
+	case brandId != nil:
+		_, err := q.GetBrand(ctx, *brandId)
+		if err != nil {
+			return nil, errs.NotFound(fmt.Errorf("brand with id=%d not found | %w", *brandId, err))
+		}
+	default:
+		_, err := q.GetCategory(ctx, *categoryId)
+		if err != nil {
+			return nil, errs.NotFound(fmt.Errorf("category with id=%d not found | %w", *categoryId, err))
+		}
+	}
+	return nil, nil
+}
+
+// Pointers returns pointers to the elements of items, so a helper can fill them in place.
+func Pointers[T any](items []T) []*T {
+	pointers := make([]*T, len(items))
+	for i := range items {
+		pointers[i] = &items[i]
+	}
+	return pointers
+}
+
 func ParsePgTimestamptz(timestamptz pgtype.Timestamptz) (time *time.Time) {
 	if timestamptz.Valid {
 		time = &timestamptz.Time
@@ -57,6 +119,28 @@ func ToPgInt8(in *int) (out pgtype.Int8) {
 	return out
 }
 
+func ToPgTimestamptz(in *time.Time) (out pgtype.Timestamptz) {
+	out = pgtype.Timestamptz{
+		Valid: false,
+	}
+	if in != nil {
+		out = pgtype.Timestamptz{
+			Time:  *in,
+			Valid: true,
+		}
+	}
+	return out
+}
+
+// NewDeletionId returns a fresh id that marks every row soft-deleted by one operation,
+// so a restore can bring back exactly the rows that operation cascaded to.
+func NewDeletionId() pgtype.UUID {
+	return pgtype.UUID{
+		Bytes: uuid.New(),
+		Valid: true,
+	}
+}
+
 func SafeGetUserID(ctx context.Context) (int64, error) {
 	val := ctx.Value(config.UserIdKey)
 
@@ -77,7 +161,12 @@ func SafeGetUserID(ctx context.Context) (int64, error) {
 }
 
 func WithTx(ctx context.Context, pool *pgxpool.Pool, q *queries.Queries, fn func(timeout context.Context, q *queries.Queries) *errs.AppError) *errs.AppError {
-	timeout, cancel := context.WithTimeout(ctx, 3*time.Second)
+	return WithTxTimeout(ctx, pool, q, 3*time.Second, fn)
+}
+
+// WithTxTimeout is WithTx for bulk operations that need more time than a single request does.
+func WithTxTimeout(ctx context.Context, pool *pgxpool.Pool, q *queries.Queries, duration time.Duration, fn func(timeout context.Context, q *queries.Queries) *errs.AppError) *errs.AppError {
+	timeout, cancel := context.WithTimeout(ctx, duration)
 	defer cancel()
 
 	tx, err := pool.Begin(timeout)
@@ -97,3 +186,55 @@ func WithTx(ctx context.Context, pool *pgxpool.Pool, q *queries.Queries, fn func
 	}
 	return nil
 }
+
+// snapshotTimeout bounds how long an export may keep its snapshot open. It matches the write
+// timeout of the server, past which the response could not be written anyway.
+const snapshotTimeout = 30 * time.Second
+
+// snapshotIdleTimeout makes postgres end the transaction of an export whose client stopped reading
+// between two pages, so the snapshot does not hold back vacuum until snapshotTimeout.
+const snapshotIdleTimeout = "10s"
+
+// snapshots caps the exports that run at the same time, each of them takes a pool connection.
+var snapshots = make(chan struct{}, 4)
+
+// WithSnapshot runs fn in a read-only repeatable read transaction, so all the queries of fn see
+// the same snapshot. Exports Walk the list in it to get neither skipped nor duplicated rows when
+// the list is changed in the middle of the export. At most cap(snapshots) of them run at the same
+// time and each of them for at most snapshotTimeout.
+func WithSnapshot(ctx context.Context, pool *pgxpool.Pool, q *queries.Queries, fn func(timeout context.Context, q *queries.Queries) *errs.AppError) *errs.AppError {
+	select {
+	case snapshots <- struct{}{}:
+		defer func() { <-snapshots }()
+	default:
+		return errs.ServiceUnavailable(errors.New("too many exports are running, try again later"))
+	}
+
+	timeout, cancel := context.WithTimeout(ctx, snapshotTimeout)
+	defer cancel()
+
+	tx, err := pool.BeginTx(timeout, pgx.TxOptions{IsoLevel: pgx.RepeatableRead, AccessMode: pgx.ReadOnly})
+	if err != nil {
+		return errs.Internal(err)
+	}
+
+	defer tx.Rollback(timeout)
+
+	_, err = tx.Exec(timeout, "select set_config('idle_in_transaction_session_timeout', $1, true)", snapshotIdleTimeout)
+	if err != nil {
+		return errs.Internal(err)
+	}
+
+	if appErr := fn(timeout, q.WithTx(tx)); appErr != nil {
+		if errors.Is(timeout.Err(), context.DeadlineExceeded) {
+			return errs.GatewayTimeout(fmt.Errorf("export took longer than %s | %w", snapshotTimeout, appErr))
+		}
+		return appErr
+	}
+
+	err = tx.Commit(timeout)
+	if err != nil {
+		return errs.Internal(err)
+	}
+	return nil
+}
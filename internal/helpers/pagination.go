@@ -0,0 +1,191 @@
+package helpers
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/Aoladiy/go-with-tools/internal/DTO"
+	"github.com/Aoladiy/go-with-tools/internal/errs"
+)
+
+const (
+	DefaultPageLimit = 20
+	MaxPageLimit     = 100
+	WalkBatchSize    = 500
+	SortById         = "id"
+	SortByDeletedAt  = "deleted_at"
+)
+
+// Page is a validated DTO.PageRequest ready to be passed to the list queries.
+// CursorValue and CursorId are nil on the first page.
+type Page struct {
+	Limit       int32
+	SortBy      string
+	SortDesc    bool
+	CursorValue *string
+	CursorId    *int64
+}
+
+type cursor struct {
+	SortBy   string `json:"s"`
+	SortDesc bool   `json:"d"`
+	Value    string `json:"v"`
+	Id       int64  `json:"i"`
+}
+
+// ParsePage validates limit, sort and cursor of the request. sortFields lists the columns
+// the entity may be sorted by in addition to id.
+func ParsePage(request DTO.PageRequest, sortFields ...string) (Page, *errs.AppError) {
+	page := Page{
+		Limit:  request.Limit,
+		SortBy: request.SortBy,
+	}
+	if page.Limit == 0 {
+		page.Limit = DefaultPageLimit
+	}
+	if page.Limit < 0 || page.Limit > MaxPageLimit {
+		return Page{}, errs.BadRequest(fmt.Errorf("limit must be between 1 and %d", MaxPageLimit))
+	}
+
+	if page.SortBy == "" {
+		page.SortBy = SortById
+	}
+	if page.SortBy != SortById && !slices.Contains(sortFields, page.SortBy) {
+		return Page{}, errs.BadRequest(fmt.Errorf("unknown sort_by %q", page.SortBy))
+	}
+
+	switch request.Order {
+	case "", "asc":
+	case "desc":
+		page.SortDesc = true
+	default:
+		return Page{}, errs.BadRequest(fmt.Errorf("unknown order %q, expected asc or desc", request.Order))
+	}
+
+	if request.Cursor == "" {
+		return page, nil
+	}
+	raw, err := base64.RawURLEncoding.DecodeString(request.Cursor)
+	if err != nil {
+		return Page{}, errs.BadRequest(fmt.Errorf("invalid cursor: %w", err))
+	}
+	var c cursor
+	if err = json.Unmarshal(raw, &c); err != nil {
+		return Page{}, errs.BadRequest(fmt.Errorf("invalid cursor: %w", err))
+	}
+	if c.SortBy != page.SortBy || c.SortDesc != page.SortDesc {
+		return Page{}, errs.BadRequest(errors.New("cursor was issued for a different sort_by or order"))
+	}
+	page.CursorValue = &c.Value
+	page.CursorId = &c.Id
+	return page, nil
+}
+
+// Paginate trims items fetched with a limit of page.Limit+1 (the extra row only signals
+// that there is a next page) and builds the page info. cursorOf returns the sort value
+// (as text) and id of an item.
+func Paginate[T any](items []T, total int64, page Page, cursorOf func(item T) (string, int64)) ([]T, DTO.PageInfo) {
+	pageInfo := DTO.PageInfo{
+		Total: total,
+	}
+	if len(items) <= int(page.Limit) {
+		return items, pageInfo
+	}
+
+	items = items[:page.Limit]
+	value, id := cursorOf(items[len(items)-1])
+	raw, _ := json.Marshal(cursor{
+		SortBy:   page.SortBy,
+		SortDesc: page.SortDesc,
+		Value:    value,
+		Id:       id,
+	})
+	nextCursor := base64.RawURLEncoding.EncodeToString(raw)
+	pageInfo.NextCursor = &nextCursor
+	return items, pageInfo
+}
+
+// Walk passes every item of the list to fn from the first page on, ignoring the cursor of the page.
+// fetch gets pages of WalkBatchSize and fetches page.Limit+1 items like for Paginate, so the whole
+// list is never held in memory. The pages are separate queries, run Walk in WithSnapshot for them
+// to see the same rows.
+func Walk[T any](page Page, fetch func(page Page) ([]T, *errs.AppError), cursorOf func(item T) (string, int64), fn func(item T) error) *errs.AppError {
+	page.Limit = WalkBatchSize
+	page.CursorValue = nil
+	page.CursorId = nil
+	for {
+		items, appErr := fetch(page)
+		if appErr != nil {
+			return appErr
+		}
+		for _, item := range items[:min(len(items), int(page.Limit))] {
+			err := fn(item)
+			if err != nil {
+				return errs.Internal(err)
+			}
+		}
+		if len(items) <= int(page.Limit) {
+			return nil
+		}
+
+		value, id := cursorOf(items[page.Limit-1])
+		page.CursorValue = &value
+		page.CursorId = &id
+	}
+}
+
+// OffsetPage is a validated DTO.PageRequest for lists without a stable sort key,
+// such as search results ordered by rank.
+type OffsetPage struct {
+	Limit  int32
+	Offset int32
+}
+
+type offsetCursor struct {
+	Offset int32 `json:"o"`
+}
+
+func ParseOffsetPage(request DTO.PageRequest) (OffsetPage, *errs.AppError) {
+	page := OffsetPage{
+		Limit: request.Limit,
+	}
+	if page.Limit == 0 {
+		page.Limit = DefaultPageLimit
+	}
+	if page.Limit < 0 || page.Limit > MaxPageLimit {
+		return OffsetPage{}, errs.BadRequest(fmt.Errorf("limit must be between 1 and %d", MaxPageLimit))
+	}
+
+	if request.Cursor == "" {
+		return page, nil
+	}
+	raw, err := base64.RawURLEncoding.DecodeString(request.Cursor)
+	if err != nil {
+		return OffsetPage{}, errs.BadRequest(fmt.Errorf("invalid cursor: %w", err))
+	}
+	var c offsetCursor
+	if err = json.Unmarshal(raw, &c); err != nil || c.Offset < 0 {
+		return OffsetPage{}, errs.BadRequest(errors.New("invalid cursor"))
+	}
+	page.Offset = c.Offset
+	return page, nil
+}
+
+// PaginateOffset is Paginate for an OffsetPage.
+func PaginateOffset[T any](items []T, total int64, page OffsetPage) ([]T, DTO.PageInfo) {
+	pageInfo := DTO.PageInfo{
+		Total: total,
+	}
+	if len(items) <= int(page.Limit) {
+		return items, pageInfo
+	}
+
+	items = items[:page.Limit]
+	raw, _ := json.Marshal(offsetCursor{Offset: page.Offset + page.Limit})
+	nextCursor := base64.RawURLEncoding.EncodeToString(raw)
+	pageInfo.NextCursor = &nextCursor
+	return items, pageInfo
+}
@@ -0,0 +1,105 @@
+package helpers
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/Aoladiy/go-with-tools/internal/errs"
+
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+)
+
+// Violations returns the violations of the fields when err is a validation error of the binding tags.
+func Violations(err error) ([]errs.Violation, bool) {
+	var fieldErrs validator.ValidationErrors
+	if !errors.As(err, &fieldErrs) {
+		return nil, false
+	}
+	violations := make([]errs.Violation, len(fieldErrs))
+	for i, fieldErr := range fieldErrs {
+		violations[i] = errs.Violation{
+			Field:   violationField(fieldErr.Namespace()),
+			Rule:    fieldErr.Tag(),
+			Message: violationMessage(fieldErr),
+		}
+	}
+	return violations, true
+}
+
+// ValidateStruct checks the binding tags of a request that doesn't come from binding, like a row of
+// an imported file. The error lists the violations as "field message" separated by semicolons.
+func ValidateStruct(request any) error {
+	err := binding.Validator.ValidateStruct(request)
+	if err == nil {
+		return nil
+	}
+	violations, ok := Violations(err)
+	if !ok {
+		return err
+	}
+	messages := make([]string, len(violations))
+	for i, violation := range violations {
+		messages[i] = violation.Field + " " + violation.Message
+	}
+	return errors.New(strings.Join(messages, "; "))
+}
+
+// violationField is the path of the field in the request. The namespace starts with the name of the
+// request struct and has the Go names of the embedded structs, the only parts without a json or form
+// name, so they start with an uppercase letter.
+func violationField(namespace string) string {
+	parts := strings.Split(namespace, ".")[1:]
+	field := make([]string, 0, len(parts))
+	for _, part := range parts {
+		if part != "" && part[0] >= 'A' && part[0] <= 'Z' {
+			continue
+		}
+		field = append(field, part)
+	}
+	return strings.Join(field, ".")
+}
+
+func violationMessage(fieldErr validator.FieldError) string {
+	param := fieldErr.Param()
+	var unit string
+	switch fieldErr.Kind() {
+	case reflect.String:
+		unit = " characters"
+	case reflect.Slice, reflect.Map, reflect.Array:
+		unit = " items"
+	}
+
+	switch fieldErr.Tag() {
+	case "required":
+		return "is required"
+	case "notblank":
+		return "must not be blank"
+	case "min", "gte":
+		return fmt.Sprintf("must be at least %s%s", param, unit)
+	case "max", "lte":
+		return fmt.Sprintf("must be at most %s%s", param, unit)
+	case "gt":
+		return fmt.Sprintf("must be greater than %s%s", param, unit)
+	case "lt":
+		return fmt.Sprintf("must be less than %s%s", param, unit)
+	case "len":
+		return fmt.Sprintf("must be exactly %s%s", param, unit)
+	case "oneof":
+		return "must be one of " + strings.Join(strings.Fields(param), ", ")
+	case "unique":
+		return "must not contain duplicates"
+	case "email":
+		return "must be a valid email address"
+	case "slug":
+		return "must contain only lowercase latin letters and digits separated by single dashes"
+	case "currency":
+		return "must be a supported ISO 4217 currency code like RUB"
+	case "attribute_code":
+		return "must start with a latin letter and contain only lowercase latin letters, digits and underscores"
+	default:
+		return fmt.Sprintf("must satisfy %s", fieldErr.Tag())
+	}
+}
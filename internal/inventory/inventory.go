@@ -0,0 +1,139 @@
+package inventory
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/Aoladiy/go-with-tools/internal/DTO"
+	"github.com/Aoladiy/go-with-tools/internal/database/queries"
+	"github.com/Aoladiy/go-with-tools/internal/errs"
+	"github.com/Aoladiy/go-with-tools/internal/helpers"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type Service struct {
+	q *queries.Queries
+	p *pgxpool.Pool
+}
+
+func New(q *queries.Queries, p *pgxpool.Pool) *Service {
+	return &Service{q: q, p: p}
+}
+
+func (s *Service) Create(ctx context.Context, request DTO.InventoryMovementRequest) (DTO.InventoryMovementResponse, *errs.AppError) {
+	if request.Delta == 0 {
+		return DTO.InventoryMovementResponse{}, errs.BadRequest(errors.New("delta must not be zero"))
+	}
+	if strings.TrimSpace(request.Description) == "" {
+		return DTO.InventoryMovementResponse{}, errs.BadRequest(errors.New("description must not be empty"))
+	}
+
+	var movement queries.InventoryMovement
+	appErr := helpers.WithTx(ctx, s.p, s.q, func(timeout context.Context, q *queries.Queries) *errs.AppError {
+		// the product row lock serializes concurrent movements of the same product,
+		// so the stock can't go negative between the check and the insert
+		_, err := q.LockProduct(timeout, request.ProductId)
+		if err != nil {
+			appErr := errs.FromPgErr(err)
+			if appErr.Code == errs.NotFoundErrCode {
+				return errs.NotFound(fmt.Errorf("product with id=%d not found | %w", request.ProductId, err))
+			}
+			return appErr
+		}
+
+		// products with variants keep their stock per variant
+		var stock int64
+		if request.VariantId == nil {
+			variants, err := q.CountProductVariants(timeout, queries.CountProductVariantsParams{ProductID: request.ProductId})
+			if err != nil {
+				return errs.Internal(err)
+			}
+			if variants > 0 {
+				return errs.UnprocessableEntity(fmt.Errorf("product with id=%d has variants, variant_id is required", request.ProductId))
+			}
+			stock, err = q.GetProductStock(timeout, request.ProductId)
+			if err != nil {
+				return errs.Internal(err)
+			}
+		} else {
+			_, err = q.LockProductVariant(timeout, queries.LockProductVariantParams{ID: *request.VariantId, ProductID: request.ProductId})
+			if err != nil {
+				appErr := errs.FromPgErr(err)
+				if appErr.Code == errs.NotFoundErrCode {
+					return errs.NotFound(fmt.Errorf("variant with id=%d of product with id=%d not found | %w", *request.VariantId, request.ProductId, err))
+				}
+				return appErr
+			}
+			stock, err = q.GetVariantStock(timeout, request.VariantId)
+			if err != nil {
+				return errs.Internal(err)
+			}
+		}
+		if stock+int64(request.Delta) < 0 {
+			return errs.UnprocessableEntity(fmt.Errorf("not enough stock: %d on hand, %d requested", stock, -request.Delta))
+		}
+
+		movement, err = q.CreateInventoryMovement(timeout, mapRequestToCreateParams(request))
+		if err != nil {
+			return errs.FromPgErr(err)
+		}
+		return nil
+	})
+	if appErr != nil {
+		return DTO.InventoryMovementResponse{}, appErr
+	}
+
+	return mapMovementToResponse(movement), nil
+}
+
+func (s *Service) GetStock(ctx context.Context, productId int64) (DTO.StockResponse, *errs.AppError) {
+	_, err := s.q.GetProduct(ctx, productId)
+	if err != nil {
+		return DTO.StockResponse{}, errs.FromPgErr(err)
+	}
+
+	stock, err := s.q.GetProductStock(ctx, productId)
+	if err != nil {
+		return DTO.StockResponse{}, errs.Internal(err)
+	}
+
+	return DTO.StockResponse{ProductId: productId, Quantity: stock}, nil
+}
+
+func (s *Service) GetMovements(ctx context.Context, productId int64, request DTO.PageRequest) (DTO.InventoryMovementPageResponse, *errs.AppError) {
+	page, appErr := helpers.ParsePage(request)
+	if appErr != nil {
+		return DTO.InventoryMovementPageResponse{}, appErr
+	}
+
+	_, err := s.q.GetProduct(ctx, productId)
+	if err != nil {
+		return DTO.InventoryMovementPageResponse{}, errs.FromPgErr(err)
+	}
+
+	movements, err := s.q.GetInventoryMovementsByProductId(ctx, queries.GetInventoryMovementsByProductIdParams{
+		ProductID: productId,
+		CursorID:  page.CursorId,
+		SortDesc:  page.SortDesc,
+		PageLimit: page.Limit + 1,
+	})
+	if err != nil {
+		return DTO.InventoryMovementPageResponse{}, errs.Internal(err)
+	}
+	total, err := s.q.CountInventoryMovementsByProductId(ctx, productId)
+	if err != nil {
+		return DTO.InventoryMovementPageResponse{}, errs.Internal(err)
+	}
+
+	movementsResponse := make([]DTO.InventoryMovementResponse, len(movements))
+	for i, movement := range movements {
+		movementsResponse[i] = mapMovementToResponse(movement)
+	}
+	items, pageInfo := helpers.Paginate(movementsResponse, total, page, func(movement DTO.InventoryMovementResponse) (string, int64) {
+		return "", movement.Id
+	})
+	return DTO.InventoryMovementPageResponse{Items: items, PageInfo: pageInfo}, nil
+}
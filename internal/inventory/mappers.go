@@ -0,0 +1,26 @@
+package inventory
+
+import (
+	"github.com/Aoladiy/go-with-tools/internal/DTO"
+	"github.com/Aoladiy/go-with-tools/internal/database/queries"
+)
+
+func mapRequestToCreateParams(request DTO.InventoryMovementRequest) queries.CreateInventoryMovementParams {
+	return queries.CreateInventoryMovementParams{
+		ProductID:   request.ProductId,
+		VariantID:   request.VariantId,
+		Delta:       request.Delta,
+		Description: request.Description,
+	}
+}
+
+func mapMovementToResponse(movement queries.InventoryMovement) DTO.InventoryMovementResponse {
+	return DTO.InventoryMovementResponse{
+		Id:          movement.ID,
+		ProductId:   movement.ProductID,
+		VariantId:   movement.VariantID,
+		Delta:       movement.Delta,
+		Description: movement.Description,
+		CreatedAt:   movement.CreatedAt,
+	}
+}
@@ -0,0 +1,34 @@
+package media
+
+import (
+	"encoding/json"
+	"log/slog"
+
+	"github.com/Aoladiy/go-with-tools/internal/DTO"
+	"github.com/Aoladiy/go-with-tools/internal/database/queries"
+)
+
+func (s *Service) mapMediaToResponse(media queries.Medium) DTO.MediaResponse {
+	var thumbnailKeys map[string]string
+	err := json.Unmarshal(media.ThumbnailKeys, &thumbnailKeys)
+	if err != nil {
+		slog.Error("cannot decode media thumbnail keys", "key", media.StorageKey, "error", err)
+	}
+	thumbnails := make(map[string]string, len(thumbnailKeys))
+	for size, key := range thumbnailKeys {
+		thumbnails[size] = s.storage.URL(key)
+	}
+
+	return DTO.MediaResponse{
+		Id:          media.ID,
+		Url:         s.storage.URL(media.StorageKey),
+		Thumbnails:  thumbnails,
+		ContentType: media.ContentType,
+		SizeBytes:   media.SizeBytes,
+		Width:       media.Width,
+		Height:      media.Height,
+		Position:    media.Position,
+		IsPrimary:   media.IsPrimary,
+		CreatedAt:   media.CreatedAt,
+	}
+}
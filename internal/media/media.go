@@ -0,0 +1,418 @@
+package media
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"image"
+	_ "image/gif"
+	_ "image/jpeg"
+	_ "image/png"
+	"io"
+	"log/slog"
+	"net/http"
+
+	"github.com/Aoladiy/go-with-tools/internal/DTO"
+	"github.com/Aoladiy/go-with-tools/internal/database/queries"
+	"github.com/Aoladiy/go-with-tools/internal/errs"
+	"github.com/Aoladiy/go-with-tools/internal/helpers"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type Service struct {
+	q       *queries.Queries
+	p       *pgxpool.Pool
+	storage Storage
+	maxSize int64
+}
+
+// maxPixels keeps a small file with huge dimensions from being decoded into gigabytes of memory.
+const maxPixels = 40_000_000
+
+var extensions = map[string]string{
+	"image/jpeg": ".jpg",
+	"image/png":  ".png",
+	"image/gif":  ".gif",
+}
+
+// Owner is a product or a brand the media belongs to.
+type Owner struct {
+	productId *int64
+	brandId   *int64
+}
+
+func Product(id int64) Owner {
+	return Owner{productId: &id}
+}
+
+func Brand(id int64) Owner {
+	return Owner{brandId: &id}
+}
+
+func New(q *queries.Queries, p *pgxpool.Pool, storage Storage, maxSize int64) *Service {
+	return &Service{q: q, p: p, storage: storage, maxSize: maxSize}
+}
+
+// Upload validates the image, stores it together with its thumbnails and appends it to the owner's media.
+// The first media of an owner becomes its primary one.
+func (s *Service) Upload(ctx context.Context, owner Owner, r io.Reader) (DTO.MediaResponse, *errs.AppError) {
+	data, err := io.ReadAll(io.LimitReader(r, s.maxSize+1))
+	if err != nil {
+		return DTO.MediaResponse{}, errs.BadRequest(fmt.Errorf("cannot read file | %w", err))
+	}
+	if int64(len(data)) > s.maxSize {
+		return DTO.MediaResponse{}, errs.BadRequest(fmt.Errorf("file is larger than %d bytes", s.maxSize))
+	}
+	contentType := http.DetectContentType(data)
+	extension, ok := extensions[contentType]
+	if !ok {
+		return DTO.MediaResponse{}, errs.BadRequest(fmt.Errorf("content type %s is not supported, expected jpeg, png or gif", contentType))
+	}
+	config, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return DTO.MediaResponse{}, errs.BadRequest(fmt.Errorf("file is not a valid image | %w", err))
+	}
+	if config.Width*config.Height > maxPixels {
+		return DTO.MediaResponse{}, errs.BadRequest(fmt.Errorf("image is larger than %d pixels", maxPixels))
+	}
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return DTO.MediaResponse{}, errs.BadRequest(fmt.Errorf("file is not a valid image | %w", err))
+	}
+
+	baseKey := owner.keyPrefix() + uuid.NewString()
+	keys, thumbnailKeys, appErr := s.store(ctx, baseKey, extension, contentType, data, img)
+	if appErr != nil {
+		return DTO.MediaResponse{}, appErr
+	}
+	encodedThumbnailKeys, err := json.Marshal(thumbnailKeys)
+	if err != nil {
+		s.deleteFiles(ctx, keys)
+		return DTO.MediaResponse{}, errs.Internal(err)
+	}
+
+	var media queries.Medium
+	appErr = helpers.WithTx(ctx, s.p, s.q, func(timeout context.Context, q *queries.Queries) *errs.AppError {
+		appErr := lockOwner(timeout, q, owner)
+		if appErr != nil {
+			return appErr
+		}
+		position, err := q.GetNextMediaPosition(timeout, queries.GetNextMediaPositionParams{ProductID: owner.productId, BrandID: owner.brandId})
+		if err != nil {
+			return errs.Internal(err)
+		}
+		hasPrimary, err := q.HasPrimaryMedia(timeout, queries.HasPrimaryMediaParams{ProductID: owner.productId, BrandID: owner.brandId})
+		if err != nil {
+			return errs.Internal(err)
+		}
+
+		media, err = q.CreateMedia(timeout, queries.CreateMediaParams{
+			ProductID:     owner.productId,
+			BrandID:       owner.brandId,
+			StorageKey:    keys[0],
+			ContentType:   contentType,
+			SizeBytes:     int64(len(data)),
+			Width:         int32(config.Width),
+			Height:        int32(config.Height),
+			ThumbnailKeys: encodedThumbnailKeys,
+			Position:      position,
+			IsPrimary:     !hasPrimary,
+		})
+		if err != nil {
+			return errs.FromPgErr(err)
+		}
+		return nil
+	})
+	if appErr != nil {
+		s.deleteFiles(ctx, keys)
+		return DTO.MediaResponse{}, appErr
+	}
+
+	return s.mapMediaToResponse(media), nil
+}
+
+func (s *Service) GetAll(ctx context.Context, owner Owner) ([]DTO.MediaResponse, *errs.AppError) {
+	appErr := s.checkOwner(ctx, owner)
+	if appErr != nil {
+		return nil, appErr
+	}
+	return s.getAll(ctx, s.q, owner)
+}
+
+// Reorder sets the positions of the owner's media to the order of the ids, which must list all of them.
+func (s *Service) Reorder(ctx context.Context, owner Owner, request DTO.MediaOrderRequest) ([]DTO.MediaResponse, *errs.AppError) {
+	var response []DTO.MediaResponse
+	appErr := helpers.WithTx(ctx, s.p, s.q, func(timeout context.Context, q *queries.Queries) *errs.AppError {
+		appErr := lockOwner(timeout, q, owner)
+		if appErr != nil {
+			return appErr
+		}
+		ids, err := q.GetMediaIds(timeout, queries.GetMediaIdsParams{ProductID: owner.productId, BrandID: owner.brandId})
+		if err != nil {
+			return errs.Internal(err)
+		}
+		if !sameIds(ids, request.Ids) {
+			return errs.UnprocessableEntity(errors.New("ids must list every media of the owner exactly once"))
+		}
+
+		_, err = q.ReorderMedia(timeout, queries.ReorderMediaParams{Ids: request.Ids, ProductID: owner.productId, BrandID: owner.brandId})
+		if err != nil {
+			return errs.Internal(err)
+		}
+		response, appErr = s.getAll(timeout, q, owner)
+		return appErr
+	})
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	return response, nil
+}
+
+func (s *Service) SetPrimary(ctx context.Context, owner Owner, id int64) ([]DTO.MediaResponse, *errs.AppError) {
+	var response []DTO.MediaResponse
+	appErr := helpers.WithTx(ctx, s.p, s.q, func(timeout context.Context, q *queries.Queries) *errs.AppError {
+		appErr := lockOwner(timeout, q, owner)
+		if appErr != nil {
+			return appErr
+		}
+		// the old primary is cleared first, the unique index allows one primary media per owner at any moment
+		err := q.ClearPrimaryMedia(timeout, queries.ClearPrimaryMediaParams{ProductID: owner.productId, BrandID: owner.brandId})
+		if err != nil {
+			return errs.Internal(err)
+		}
+		rows, err := q.SetPrimaryMedia(timeout, queries.SetPrimaryMediaParams{ID: id, ProductID: owner.productId, BrandID: owner.brandId})
+		if err != nil {
+			return errs.Internal(err)
+		}
+		if rows == 0 {
+			return errs.NotFound(fmt.Errorf("media with id=%d not found", id))
+		}
+		response, appErr = s.getAll(timeout, q, owner)
+		return appErr
+	})
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	return response, nil
+}
+
+// Delete removes the media and its files. When the primary media is deleted the first remaining one takes its place.
+func (s *Service) Delete(ctx context.Context, owner Owner, id int64) *errs.AppError {
+	var media queries.DeleteMediaRow
+	appErr := helpers.WithTx(ctx, s.p, s.q, func(timeout context.Context, q *queries.Queries) *errs.AppError {
+		appErr := lockOwner(timeout, q, owner)
+		if appErr != nil {
+			return appErr
+		}
+		var err error
+		media, err = q.DeleteMedia(timeout, queries.DeleteMediaParams{ID: id, ProductID: owner.productId, BrandID: owner.brandId})
+		if err != nil {
+			appErr := errs.FromPgErr(err)
+			if appErr.Code == errs.NotFoundErrCode {
+				return errs.NotFound(fmt.Errorf("media with id=%d not found | %w", id, err))
+			}
+			return appErr
+		}
+		if media.IsPrimary {
+			err = q.PromoteFirstMedia(timeout, queries.PromoteFirstMediaParams{ProductID: owner.productId, BrandID: owner.brandId})
+			if err != nil {
+				return errs.Internal(err)
+			}
+		}
+		return nil
+	})
+	if appErr != nil {
+		return appErr
+	}
+
+	s.deleteFiles(ctx, fileKeys(media.StorageKey, media.ThumbnailKeys))
+	return nil
+}
+
+// ForProducts returns the media of every product in the order of the ids, with one query for all of them.
+func (s *Service) ForProducts(ctx context.Context, ids []int64) ([][]DTO.MediaResponse, *errs.AppError) {
+	media, err := s.q.GetMediaByProductIds(ctx, ids)
+	if err != nil {
+		return nil, errs.Internal(err)
+	}
+	return s.groupByOwner(ids, media, func(media queries.Medium) *int64 { return media.ProductID }), nil
+}
+
+// ForBrands returns the media of every brand in the order of the ids, with one query for all of them.
+func (s *Service) ForBrands(ctx context.Context, ids []int64) ([][]DTO.MediaResponse, *errs.AppError) {
+	media, err := s.q.GetMediaByBrandIds(ctx, ids)
+	if err != nil {
+		return nil, errs.Internal(err)
+	}
+	return s.groupByOwner(ids, media, func(media queries.Medium) *int64 { return media.BrandID }), nil
+}
+
+// DeletePurgedFiles removes the files of media rows already deleted from the database by the trash purge.
+func (s *Service) DeletePurgedFiles(ctx context.Context, purged []queries.PurgeMediaRow) {
+	for _, media := range purged {
+		s.deleteFiles(ctx, fileKeys(media.StorageKey, media.ThumbnailKeys))
+	}
+}
+
+func (s *Service) getAll(ctx context.Context, q *queries.Queries, owner Owner) ([]DTO.MediaResponse, *errs.AppError) {
+	var (
+		media []queries.Medium
+		err   error
+	)
+	if owner.productId != nil {
+		media, err = q.GetMediaByProductIds(ctx, []int64{*owner.productId})
+	} else {
+		media, err = q.GetMediaByBrandIds(ctx, []int64{*owner.brandId})
+	}
+	if err != nil {
+		return nil, errs.Internal(err)
+	}
+
+	mediaResponse := make([]DTO.MediaResponse, len(media))
+	for i, m := range media {
+		mediaResponse[i] = s.mapMediaToResponse(m)
+	}
+	return mediaResponse, nil
+}
+
+func (s *Service) groupByOwner(ids []int64, media []queries.Medium, ownerOf func(queries.Medium) *int64) [][]DTO.MediaResponse {
+	byOwner := make(map[int64][]DTO.MediaResponse, len(ids))
+	for _, m := range media {
+		ownerId := *ownerOf(m)
+		byOwner[ownerId] = append(byOwner[ownerId], s.mapMediaToResponse(m))
+	}
+
+	grouped := make([][]DTO.MediaResponse, len(ids))
+	for i, id := range ids {
+		grouped[i] = byOwner[id]
+		if grouped[i] == nil {
+			grouped[i] = []DTO.MediaResponse{}
+		}
+	}
+	return grouped
+}
+
+// store puts the original and its thumbnails into the storage. It returns every stored key, the original first,
+// and the thumbnail keys by size name. Nothing stays in the storage when it fails.
+func (s *Service) store(ctx context.Context, baseKey, extension, contentType string, data []byte, img image.Image) ([]string, map[string]string, *errs.AppError) {
+	keys := []string{baseKey + extension}
+	err := s.storage.Put(ctx, keys[0], contentType, bytes.NewReader(data))
+	if err != nil {
+		return nil, nil, errs.Internal(fmt.Errorf("cannot store media | %w", err))
+	}
+
+	thumbnailKeys := make(map[string]string, len(thumbnailSizes))
+	scaled := toRGBA(img)
+	for _, size := range thumbnailSizes {
+		scaled = thumbnail(scaled, size.size)
+		encoded, thumbnailContentType, err := encodeThumbnail(scaled, contentType)
+		if err != nil {
+			s.deleteFiles(ctx, keys)
+			return nil, nil, errs.Internal(fmt.Errorf("cannot encode %s thumbnail | %w", size.name, err))
+		}
+
+		key := baseKey + "_" + size.name + extensions[thumbnailContentType]
+		err = s.storage.Put(ctx, key, thumbnailContentType, bytes.NewReader(encoded))
+		if err != nil {
+			s.deleteFiles(ctx, keys)
+			return nil, nil, errs.Internal(fmt.Errorf("cannot store %s thumbnail | %w", size.name, err))
+		}
+		keys = append(keys, key)
+		thumbnailKeys[size.name] = key
+	}
+	return keys, thumbnailKeys, nil
+}
+
+// deleteFiles is best effort: the database is the source of truth, a file left behind is only logged.
+func (s *Service) deleteFiles(ctx context.Context, keys []string) {
+	for _, key := range keys {
+		err := s.storage.Delete(ctx, key)
+		if err != nil {
+			slog.Error("cannot delete media file", "key", key, "error", err)
+		}
+	}
+}
+
+func (s *Service) checkOwner(ctx context.Context, owner Owner) *errs.AppError {
+	var err error
+	if owner.productId != nil {
+		_, err = s.q.GetProduct(ctx, *owner.productId)
+	} else {
+		_, err = s.q.GetBrand(ctx, *owner.brandId)
+	}
+	if err != nil {
+		return ownerErr(owner, err)
+	}
+	return nil
+}
+
+// lockOwner gives the product or brand a new version, media are part of its response. The update locks
+// the row, which serializes the changes of its media until commit.
+func lockOwner(timeout context.Context, q *queries.Queries, owner Owner) *errs.AppError {
+	var err error
+	if owner.productId != nil {
+		_, err = q.BumpProductVersion(timeout, *owner.productId)
+	} else {
+		_, err = q.BumpBrandVersion(timeout, *owner.brandId)
+	}
+	if err != nil {
+		return ownerErr(owner, err)
+	}
+	return nil
+}
+
+func ownerErr(owner Owner, err error) *errs.AppError {
+	appErr := errs.FromPgErr(err)
+	if appErr.Code != errs.NotFoundErrCode {
+		return appErr
+	}
+	if owner.productId != nil {
+		return errs.NotFound(fmt.Errorf("product with id=%d not found | %w", *owner.productId, err))
+	}
+	return errs.NotFound(fmt.Errorf("brand with id=%d not found | %w", *owner.brandId, err))
+}
+
+func (o Owner) keyPrefix() string {
+	if o.productId != nil {
+		return fmt.Sprintf("products/%d/", *o.productId)
+	}
+	return fmt.Sprintf("brands/%d/", *o.brandId)
+}
+
+func fileKeys(storageKey string, encodedThumbnailKeys []byte) []string {
+	keys := []string{storageKey}
+	var thumbnailKeys map[string]string
+	err := json.Unmarshal(encodedThumbnailKeys, &thumbnailKeys)
+	if err != nil {
+		slog.Error("cannot decode media thumbnail keys", "key", storageKey, "error", err)
+		return keys
+	}
+	for _, key := range thumbnailKeys {
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+func sameIds(ids, requested []int64) bool {
+	if len(ids) != len(requested) {
+		return false
+	}
+	seen := make(map[int64]bool, len(ids))
+	for _, id := range ids {
+		seen[id] = false
+	}
+	for _, id := range requested {
+		listed, ok := seen[id]
+		if !ok || listed {
+			return false
+		}
+		seen[id] = true
+	}
+	return true
+}
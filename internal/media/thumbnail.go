@@ -0,0 +1,90 @@
+package media
+
+import (
+	"bytes"
+	"image"
+	"image/draw"
+	"image/jpeg"
+	"image/png"
+)
+
+type thumbnailSize struct {
+	name string
+	size int
+}
+
+// thumbnailSizes go from the largest to the smallest, every thumbnail is scaled down from the previous one.
+var thumbnailSizes = []thumbnailSize{
+	{name: "large", size: 1200},
+	{name: "medium", size: 600},
+	{name: "small", size: 200},
+}
+
+const jpegQuality = 85
+
+// thumbnail fits img into a size x size square keeping the aspect ratio. Images that already fit are returned as is.
+func thumbnail(img *image.RGBA, size int) *image.RGBA {
+	srcBounds := img.Bounds()
+	srcW, srcH := srcBounds.Dx(), srcBounds.Dy()
+	if srcW <= size && srcH <= size {
+		return img
+	}
+
+	dstW, dstH := size, size
+	if srcW > srcH {
+		dstH = max(1, srcH*size/srcW)
+	} else {
+		dstW = max(1, srcW*size/srcH)
+	}
+
+	// box filter: every destination pixel is the average of the source pixels it covers
+	dst := image.NewRGBA(image.Rect(0, 0, dstW, dstH))
+	for y := 0; y < dstH; y++ {
+		y0 := y * srcH / dstH
+		y1 := max(y0+1, (y+1)*srcH/dstH)
+		for x := 0; x < dstW; x++ {
+			x0 := x * srcW / dstW
+			x1 := max(x0+1, (x+1)*srcW/dstW)
+
+			var r, g, b, a, n uint64
+			for sy := y0; sy < y1; sy++ {
+				offset := img.PixOffset(srcBounds.Min.X+x0, srcBounds.Min.Y+sy)
+				for sx := x0; sx < x1; sx++ {
+					r += uint64(img.Pix[offset])
+					g += uint64(img.Pix[offset+1])
+					b += uint64(img.Pix[offset+2])
+					a += uint64(img.Pix[offset+3])
+					offset += 4
+					n++
+				}
+			}
+
+			offset := dst.PixOffset(x, y)
+			dst.Pix[offset] = uint8(r / n)
+			dst.Pix[offset+1] = uint8(g / n)
+			dst.Pix[offset+2] = uint8(b / n)
+			dst.Pix[offset+3] = uint8(a / n)
+		}
+	}
+	return dst
+}
+
+func toRGBA(img image.Image) *image.RGBA {
+	if rgba, ok := img.(*image.RGBA); ok {
+		return rgba
+	}
+	rgba := image.NewRGBA(image.Rect(0, 0, img.Bounds().Dx(), img.Bounds().Dy()))
+	draw.Draw(rgba, rgba.Bounds(), img, img.Bounds().Min, draw.Src)
+	return rgba
+}
+
+// encodeThumbnail keeps jpeg thumbnails jpeg, everything else becomes png.
+func encodeThumbnail(img image.Image, contentType string) ([]byte, string, error) {
+	var buf bytes.Buffer
+	if contentType == "image/jpeg" {
+		err := jpeg.Encode(&buf, img, &jpeg.Options{Quality: jpegQuality})
+		return buf.Bytes(), "image/jpeg", err
+	}
+	err := png.Encode(&buf, img)
+	return buf.Bytes(), "image/png", err
+}
@@ -0,0 +1,152 @@
+package money
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// DefaultCurrency is the currency of the prices stored before currencies were introduced.
+const DefaultCurrency = "RUB"
+
+// minorUnits maps the supported ISO 4217 codes to the number of digits after the decimal point.
+var minorUnits = map[string]int{
+	"AED": 2,
+	"AMD": 2,
+	"AZN": 2,
+	"BHD": 3,
+	"BYN": 2,
+	"CHF": 2,
+	"CNY": 2,
+	"CZK": 2,
+	"EUR": 2,
+	"GBP": 2,
+	"GEL": 2,
+	"INR": 2,
+	"JPY": 0,
+	"KGS": 2,
+	"KRW": 0,
+	"KWD": 3,
+	"KZT": 2,
+	"PLN": 2,
+	"RUB": 2,
+	"TJS": 2,
+	"TRY": 2,
+	"UAH": 2,
+	"USD": 2,
+	"UZS": 2,
+}
+
+// Money is an amount in minor units of a currency, e.g. {12345 RUB} is 123.45 roubles.
+type Money struct {
+	Amount   int64  `json:"amount" example:"12345" binding:"min=0"`
+	Currency string `json:"currency" example:"RUB" binding:"required,currency"`
+}
+
+func New(amount int64, currency string) Money {
+	return Money{Amount: amount, Currency: currency}
+}
+
+// MinorUnits returns the number of digits after the decimal point of the currency.
+func MinorUnits(currency string) (int, bool) {
+	digits, ok := minorUnits[currency]
+	return digits, ok
+}
+
+// ValidateCurrency checks that the code is an uppercase ISO 4217 code of a supported currency.
+func ValidateCurrency(currency string) error {
+	if currency == "" {
+		return errors.New("currency is required")
+	}
+	if _, ok := minorUnits[currency]; !ok {
+		return fmt.Errorf("unsupported currency %q, expected an ISO 4217 code like RUB", currency)
+	}
+	return nil
+}
+
+// Validate checks the currency and that the amount isn't negative, which is what every price needs.
+func (m Money) Validate() error {
+	err := ValidateCurrency(m.Currency)
+	if err != nil {
+		return err
+	}
+	if m.Amount < 0 {
+		return errors.New("amount must not be negative")
+	}
+	return nil
+}
+
+// Decimal formats the amount in major units, e.g. "123.45" for {12345 RUB} or "500" for {500 JPY}.
+func (m Money) Decimal() string {
+	digits := minorUnits[m.Currency]
+	sign := ""
+	amount := m.Amount
+	if amount < 0 {
+		sign = "-"
+	}
+	// the magnitude of math.MinInt64 doesn't fit into int64, so it is taken as uint64
+	magnitude := uint64(amount)
+	if amount < 0 {
+		magnitude = -magnitude
+	}
+	if digits == 0 {
+		return sign + strconv.FormatUint(magnitude, 10)
+	}
+	scale := uint64(math.Pow10(digits))
+	return fmt.Sprintf("%s%d.%0*d", sign, magnitude/scale, digits, magnitude%scale)
+}
+
+// String formats the money as the decimal amount followed by the currency, e.g. "123.45 RUB".
+func (m Money) String() string {
+	return m.Decimal() + " " + m.Currency
+}
+
+// Parse reads a decimal amount in major units like "123.45" or "123,45". More digits after
+// the decimal point than the currency has is an error rather than a silent rounding.
+func Parse(decimal, currency string) (Money, error) {
+	currency = strings.ToUpper(strings.TrimSpace(currency))
+	err := ValidateCurrency(currency)
+	if err != nil {
+		return Money{}, err
+	}
+	digits := minorUnits[currency]
+
+	raw := strings.ReplaceAll(strings.TrimSpace(decimal), ",", ".")
+	negative := strings.HasPrefix(raw, "-")
+	raw = strings.TrimPrefix(raw, "-")
+	whole, fraction, _ := strings.Cut(raw, ".")
+	if whole == "" || len(fraction) > digits || strings.ContainsAny(whole+fraction, "+-") {
+		return Money{}, fmt.Errorf("invalid %s amount %q", currency, decimal)
+	}
+	fraction += strings.Repeat("0", digits-len(fraction))
+	amount, err := strconv.ParseInt(whole+fraction, 10, 64)
+	if err != nil {
+		return Money{}, fmt.Errorf("invalid %s amount %q", currency, decimal)
+	}
+	if negative {
+		amount = -amount
+	}
+	return Money{Amount: amount, Currency: currency}, nil
+}
+
+// UnmarshalJSON requires both fields and normalizes the currency code to upper case, checking it is
+// left to the validation of the request so an unsupported one is reported with the field.
+// Amounts are integers in minor units, so 123.45 RUB is sent as {"amount": 12345, "currency": "RUB"}.
+func (m *Money) UnmarshalJSON(data []byte) error {
+	var raw struct {
+		Amount   *int64  `json:"amount"`
+		Currency *string `json:"currency"`
+	}
+	err := json.Unmarshal(data, &raw)
+	if err != nil {
+		return fmt.Errorf("money must be an object with an integer amount in minor units and a currency: %w", err)
+	}
+	if raw.Amount == nil || raw.Currency == nil {
+		return errors.New("money must have both amount and currency")
+	}
+	*m = Money{Amount: *raw.Amount, Currency: strings.ToUpper(strings.TrimSpace(*raw.Currency))}
+	return nil
+}
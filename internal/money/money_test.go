@@ -0,0 +1,84 @@
+package money
+
+import (
+	"math"
+	"testing"
+)
+
+func TestDecimal(t *testing.T) {
+	tests := []struct {
+		name  string
+		money Money
+		want  string
+	}{
+		{"two digits", New(12345, "RUB"), "123.45"},
+		{"leading zeros of the fraction", New(5, "RUB"), "0.05"},
+		{"zero", New(0, "RUB"), "0.00"},
+		{"negative", New(-5, "RUB"), "-0.05"},
+		{"no minor units", New(500, "JPY"), "500"},
+		{"three digits", New(1234, "KWD"), "1.234"},
+		{"largest amount", New(math.MaxInt64, "RUB"), "92233720368547758.07"},
+		{"smallest amount", New(math.MinInt64, "RUB"), "-92233720368547758.08"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.money.Decimal(); got != tt.want {
+				t.Errorf("Decimal() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name     string
+		decimal  string
+		currency string
+		want     Money
+		wantErr  bool
+	}{
+		{"point", "123.45", "RUB", New(12345, "RUB"), false},
+		{"comma", "123,45", "RUB", New(12345, "RUB"), false},
+		{"short fraction", "123.4", "RUB", New(12340, "RUB"), false},
+		{"whole", "123", "RUB", New(12300, "RUB"), false},
+		{"spaces and lowercase currency", " 1.5 ", " usd ", New(150, "USD"), false},
+		{"negative", "-1.50", "RUB", New(-150, "RUB"), false},
+		{"no minor units", "500", "JPY", New(500, "JPY"), false},
+		{"three digits", "1.234", "KWD", New(1234, "KWD"), false},
+		{"largest amount", "92233720368547758.07", "RUB", New(math.MaxInt64, "RUB"), false},
+		{"overflow", "92233720368547758.08", "RUB", Money{}, true},
+		{"overflow by scaling to minor units", "9223372036854775807", "RUB", Money{}, true},
+		{"fraction of a currency without minor units", "1.5", "JPY", Money{}, true},
+		{"more digits than the currency has", "1.2345", "KWD", Money{}, true},
+		{"empty", "", "RUB", Money{}, true},
+		{"no whole part", ".5", "RUB", Money{}, true},
+		{"two points", "1.2.3", "RUB", Money{}, true},
+		{"double sign", "--1", "RUB", Money{}, true},
+		{"sign in the fraction", "1.+5", "RUB", Money{}, true},
+		{"exponent", "1e3", "RUB", Money{}, true},
+		{"unsupported currency", "1", "XXX", Money{}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Parse(tt.decimal, tt.currency)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Parse(%q, %q) error = %v, wantErr %v", tt.decimal, tt.currency, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("Parse(%q, %q) = %v, want %v", tt.decimal, tt.currency, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseDecimalRoundTrip(t *testing.T) {
+	for _, money := range []Money{New(12345, "RUB"), New(-1, "USD"), New(500, "JPY"), New(1, "BHD"), New(math.MaxInt64, "KWD")} {
+		got, err := Parse(money.Decimal(), money.Currency)
+		if err != nil {
+			t.Fatalf("Parse(%q) error = %v", money.Decimal(), err)
+		}
+		if got != money {
+			t.Errorf("Parse(%q) = %v, want %v", money.Decimal(), got, money)
+		}
+	}
+}
@@ -0,0 +1,47 @@
+package pricehistory
+
+import (
+	"github.com/Aoladiy/go-with-tools/internal/DTO"
+	"github.com/Aoladiy/go-with-tools/internal/database/queries"
+	"github.com/Aoladiy/go-with-tools/internal/helpers"
+	"github.com/Aoladiy/go-with-tools/internal/money"
+)
+
+func mapListRequestToGetParams(request DTO.PriceHistoryListRequest, variantId *int64, page helpers.Page) queries.GetPriceHistoryParams {
+	return queries.GetPriceHistoryParams{
+		ProductID:   request.ProductId,
+		VariantID:   variantId,
+		UpdatedBy:   request.UpdatedBy,
+		CreatedFrom: helpers.ToPgTimestamptz(request.CreatedFrom),
+		CreatedTo:   helpers.ToPgTimestamptz(request.CreatedTo),
+		CursorID:    page.CursorId,
+		CursorValue: page.CursorValue,
+		SortBy:      page.SortBy,
+		SortDesc:    page.SortDesc,
+		PageLimit:   page.Limit + 1,
+	}
+}
+
+func mapListRequestToCountParams(request DTO.PriceHistoryListRequest, variantId *int64) queries.CountPriceHistoryParams {
+	return queries.CountPriceHistoryParams{
+		ProductID:   request.ProductId,
+		VariantID:   variantId,
+		UpdatedBy:   request.UpdatedBy,
+		CreatedFrom: helpers.ToPgTimestamptz(request.CreatedFrom),
+		CreatedTo:   helpers.ToPgTimestamptz(request.CreatedTo),
+	}
+}
+
+func mapGetRowToResponse(entry queries.GetPriceHistoryRow) DTO.PriceHistoryResponse {
+	return DTO.PriceHistoryResponse{
+		Id:             entry.ID,
+		ProductId:      entry.ProductID,
+		ProductName:    entry.ProductName,
+		VariantId:      entry.VariantID,
+		OldPrice:       money.New(entry.OldPriceKopeck, entry.Currency),
+		NewPrice:       money.New(entry.NewPriceKopeck, entry.Currency),
+		UpdatedBy:      entry.UpdatedBy,
+		UpdatedByEmail: entry.UpdatedByEmail,
+		CreatedAt:      entry.CreatedAt,
+	}
+}
@@ -0,0 +1,132 @@
+package pricehistory
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Aoladiy/go-with-tools/internal/DTO"
+	"github.com/Aoladiy/go-with-tools/internal/database/queries"
+	"github.com/Aoladiy/go-with-tools/internal/errs"
+	"github.com/Aoladiy/go-with-tools/internal/export"
+	"github.com/Aoladiy/go-with-tools/internal/helpers"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+var sortFields = []string{"created_at"}
+
+type Service struct {
+	q *queries.Queries
+	p *pgxpool.Pool
+}
+
+func New(q *queries.Queries, p *pgxpool.Pool) *Service {
+	return &Service{q: q, p: p}
+}
+
+// GetRecent returns price changes of all products that aren't deleted, the latest first unless order is given.
+func (s *Service) GetRecent(ctx context.Context, request DTO.PriceHistoryListRequest) (DTO.PriceHistoryPageResponse, *errs.AppError) {
+	if request.Order == "" {
+		request.Order = "desc"
+	}
+	return s.getAll(ctx, request, nil)
+}
+
+// GetByProduct returns price changes of the product, including the ones of its variants.
+func (s *Service) GetByProduct(ctx context.Context, productId int64, request DTO.PriceHistoryListRequest) (DTO.PriceHistoryPageResponse, *errs.AppError) {
+	_, err := s.q.GetProduct(ctx, productId)
+	if err != nil {
+		return DTO.PriceHistoryPageResponse{}, errs.NotFound(fmt.Errorf("product with id=%d not found | %w", productId, err))
+	}
+
+	request.ProductId = &productId
+	return s.getAll(ctx, request, nil)
+}
+
+// GetByVariant returns price changes of a variant of the product.
+func (s *Service) GetByVariant(ctx context.Context, productId, variantId int64, request DTO.PriceHistoryListRequest) (DTO.PriceHistoryPageResponse, *errs.AppError) {
+	_, err := s.q.GetProductVariant(ctx, queries.GetProductVariantParams{ID: variantId, ProductID: productId})
+	if err != nil {
+		return DTO.PriceHistoryPageResponse{}, errs.NotFound(fmt.Errorf("variant with id=%d not found | %w", variantId, err))
+	}
+
+	request.ProductId = &productId
+	return s.getAll(ctx, request, &variantId)
+}
+
+// Export writes the price changes GetRecent would return, with the same filters and order.
+func (s *Service) Export(ctx context.Context, request DTO.PriceHistoryListRequest, w export.Writer) *errs.AppError {
+	if request.Order == "" {
+		request.Order = "desc"
+	}
+	page, appErr := parsePage(request)
+	if appErr != nil {
+		return appErr
+	}
+
+	err := w.WriteHeader("id", "product_id", "product_name", "variant_id", "old_price", "new_price", "currency", "updated_by", "updated_by_email", "created_at")
+	if err != nil {
+		return errs.Internal(err)
+	}
+	return helpers.WithSnapshot(ctx, s.p, s.q, func(timeout context.Context, q *queries.Queries) *errs.AppError {
+		return helpers.Walk(page, func(page helpers.Page) ([]DTO.PriceHistoryResponse, *errs.AppError) {
+			history, err := q.GetPriceHistory(timeout, mapListRequestToGetParams(request, nil, page))
+			if err != nil {
+				return nil, errs.Internal(err)
+			}
+			historyResponse := make([]DTO.PriceHistoryResponse, len(history))
+			for i, entry := range history {
+				historyResponse[i] = mapGetRowToResponse(entry)
+			}
+			return historyResponse, nil
+		}, cursorOf(page.SortBy), func(entry DTO.PriceHistoryResponse) error {
+			return w.WriteRow(entry.Id, entry.ProductId, entry.ProductName, entry.VariantId, export.Decimal(entry.OldPrice.Decimal()),
+				export.Decimal(entry.NewPrice.Decimal()), entry.NewPrice.Currency, entry.UpdatedBy, entry.UpdatedByEmail, entry.CreatedAt)
+		})
+	})
+}
+
+func (s *Service) getAll(ctx context.Context, request DTO.PriceHistoryListRequest, variantId *int64) (DTO.PriceHistoryPageResponse, *errs.AppError) {
+	page, appErr := parsePage(request)
+	if appErr != nil {
+		return DTO.PriceHistoryPageResponse{}, appErr
+	}
+
+	history, err := s.q.GetPriceHistory(ctx, mapListRequestToGetParams(request, variantId, page))
+	if err != nil {
+		return DTO.PriceHistoryPageResponse{}, errs.Internal(err)
+	}
+	total, err := s.q.CountPriceHistory(ctx, mapListRequestToCountParams(request, variantId))
+	if err != nil {
+		return DTO.PriceHistoryPageResponse{}, errs.Internal(err)
+	}
+
+	historyResponse := make([]DTO.PriceHistoryResponse, len(history))
+	for i, entry := range history {
+		historyResponse[i] = mapGetRowToResponse(entry)
+	}
+	items, pageInfo := helpers.Paginate(historyResponse, total, page, cursorOf(page.SortBy))
+	return DTO.PriceHistoryPageResponse{Items: items, PageInfo: pageInfo}, nil
+}
+
+func parsePage(request DTO.PriceHistoryListRequest) (helpers.Page, *errs.AppError) {
+	page, appErr := helpers.ParsePage(request.PageRequest, sortFields...)
+	if appErr != nil {
+		return helpers.Page{}, appErr
+	}
+	if request.CreatedFrom != nil && request.CreatedTo != nil && request.CreatedTo.Before(*request.CreatedFrom) {
+		return helpers.Page{}, errs.BadRequest(errors.New("created_to must not be before created_from"))
+	}
+	return page, nil
+}
+
+func cursorOf(sortBy string) func(entry DTO.PriceHistoryResponse) (string, int64) {
+	return func(entry DTO.PriceHistoryResponse) (string, int64) {
+		if sortBy == "created_at" {
+			return entry.CreatedAt.Format(time.RFC3339Nano), entry.Id
+		}
+		return "", entry.Id
+	}
+}
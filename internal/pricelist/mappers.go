@@ -0,0 +1,100 @@
+package pricelist
+
+import (
+	"github.com/Aoladiy/go-with-tools/internal/DTO"
+	"github.com/Aoladiy/go-with-tools/internal/database/queries"
+	"github.com/Aoladiy/go-with-tools/internal/helpers"
+	"github.com/Aoladiy/go-with-tools/internal/money"
+)
+
+func mapRequestToCreateParams(request DTO.PriceListRequest) queries.CreatePriceListParams {
+	return queries.CreatePriceListParams{
+		Code:          request.Code,
+		Name:          request.Name,
+		Currency:      request.Currency,
+		CustomerGroup: request.CustomerGroup,
+		RoundingStep:  helpers.DerefInt64(request.RoundingStep, 1),
+		RoundingMode:  helpers.DerefString(request.RoundingMode, RoundingHalfUp),
+		IsActive:      helpers.DerefBool(request.IsActive, true),
+	}
+}
+
+func mapRequestToUpdateParams(id int64, request DTO.PriceListRequest) queries.UpdatePriceListParams {
+	return queries.UpdatePriceListParams{
+		ID:            id,
+		Code:          request.Code,
+		Name:          request.Name,
+		Currency:      request.Currency,
+		CustomerGroup: request.CustomerGroup,
+		RoundingStep:  helpers.DerefInt64(request.RoundingStep, 1),
+		RoundingMode:  helpers.DerefString(request.RoundingMode, RoundingHalfUp),
+		IsActive:      helpers.DerefBool(request.IsActive, true),
+	}
+}
+
+func mapListRequestToGetAllParams(request DTO.PriceListListRequest, page helpers.Page) queries.GetAllPriceListsParams {
+	return queries.GetAllPriceListsParams{
+		Currency:      request.Currency,
+		CustomerGroup: request.CustomerGroup,
+		IsActive:      request.IsActive,
+		CursorID:      page.CursorId,
+		CursorValue:   page.CursorValue,
+		SortBy:        page.SortBy,
+		SortDesc:      page.SortDesc,
+		PageLimit:     page.Limit + 1,
+	}
+}
+
+func mapListRequestToCountParams(request DTO.PriceListListRequest) queries.CountPriceListsParams {
+	return queries.CountPriceListsParams{
+		Currency:      request.Currency,
+		CustomerGroup: request.CustomerGroup,
+		IsActive:      request.IsActive,
+	}
+}
+
+func mapPriceListToResponse(priceList queries.PriceList) DTO.PriceListResponse {
+	return DTO.PriceListResponse{
+		Id:            priceList.ID,
+		Code:          priceList.Code,
+		Name:          priceList.Name,
+		Currency:      priceList.Currency,
+		CustomerGroup: priceList.CustomerGroup,
+		RoundingStep:  priceList.RoundingStep,
+		RoundingMode:  priceList.RoundingMode,
+		IsActive:      priceList.IsActive,
+		CreatedAt:     priceList.CreatedAt,
+		UpdatedAt:     priceList.UpdatedAt,
+	}
+}
+
+func mapItemRowToResponse(item queries.GetPriceListItemsRow, currency string) DTO.PriceListItemResponse {
+	return DTO.PriceListItemResponse{
+		ProductId:   item.ProductID,
+		ProductName: item.ProductName,
+		Price:       money.New(item.Amount, currency),
+		CreatedAt:   item.CreatedAt,
+		UpdatedAt:   item.UpdatedAt,
+	}
+}
+
+func mapItemToResponse(item queries.PriceListItem, productName, currency string) DTO.PriceListItemResponse {
+	return DTO.PriceListItemResponse{
+		ProductId:   item.ProductID,
+		ProductName: productName,
+		Price:       money.New(item.Amount, currency),
+		CreatedAt:   item.CreatedAt,
+		UpdatedAt:   item.UpdatedAt,
+	}
+}
+
+func mapRateToResponse(rate queries.GetExchangeRatesRow) DTO.ExchangeRateResponse {
+	return DTO.ExchangeRateResponse{
+		Id:            rate.ID,
+		BaseCurrency:  rate.BaseCurrency,
+		QuoteCurrency: rate.QuoteCurrency,
+		Rate:          rate.Rate,
+		CreatedAt:     rate.CreatedAt,
+		UpdatedAt:     rate.UpdatedAt,
+	}
+}
@@ -0,0 +1,228 @@
+package pricelist
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/Aoladiy/go-with-tools/internal/DTO"
+	"github.com/Aoladiy/go-with-tools/internal/database/queries"
+	"github.com/Aoladiy/go-with-tools/internal/errs"
+	"github.com/Aoladiy/go-with-tools/internal/helpers"
+	"github.com/Aoladiy/go-with-tools/internal/money"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const (
+	RoundingHalfUp = "half_up"
+	RoundingUp     = "up"
+	RoundingDown   = "down"
+)
+
+var sortFields = []string{"name"}
+
+type Service struct {
+	q *queries.Queries
+	p *pgxpool.Pool
+}
+
+func New(q *queries.Queries, p *pgxpool.Pool) *Service {
+	return &Service{q: q, p: p}
+}
+
+func (s *Service) Create(ctx context.Context, request DTO.PriceListRequest) (DTO.PriceListResponse, *errs.AppError) {
+	request, appErr := normalizeRequest(request)
+	if appErr != nil {
+		return DTO.PriceListResponse{}, appErr
+	}
+
+	priceList, err := s.q.CreatePriceList(ctx, mapRequestToCreateParams(request))
+	if err != nil {
+		return DTO.PriceListResponse{}, errs.FromPgErr(err)
+	}
+
+	return mapPriceListToResponse(priceList), nil
+}
+
+func (s *Service) GetAll(ctx context.Context, request DTO.PriceListListRequest) (DTO.PriceListPageResponse, *errs.AppError) {
+	page, appErr := helpers.ParsePage(request.PageRequest, sortFields...)
+	if appErr != nil {
+		return DTO.PriceListPageResponse{}, appErr
+	}
+
+	priceLists, err := s.q.GetAllPriceLists(ctx, mapListRequestToGetAllParams(request, page))
+	if err != nil {
+		return DTO.PriceListPageResponse{}, errs.Internal(err)
+	}
+	total, err := s.q.CountPriceLists(ctx, mapListRequestToCountParams(request))
+	if err != nil {
+		return DTO.PriceListPageResponse{}, errs.Internal(err)
+	}
+
+	priceListsResponse := make([]DTO.PriceListResponse, len(priceLists))
+	for i, priceList := range priceLists {
+		priceListsResponse[i] = mapPriceListToResponse(priceList)
+	}
+	items, pageInfo := helpers.Paginate(priceListsResponse, total, page, func(priceList DTO.PriceListResponse) (string, int64) {
+		if page.SortBy == "name" {
+			return priceList.Name, priceList.Id
+		}
+		return "", priceList.Id
+	})
+	return DTO.PriceListPageResponse{Items: items, PageInfo: pageInfo}, nil
+}
+
+func (s *Service) Get(ctx context.Context, id int64) (DTO.PriceListResponse, *errs.AppError) {
+	priceList, err := s.q.GetPriceList(ctx, id)
+	if err != nil {
+		return DTO.PriceListResponse{}, errs.FromPgErr(err)
+	}
+
+	return mapPriceListToResponse(priceList), nil
+}
+
+// Update can't change the currency of the list, its explicit prices are in it.
+func (s *Service) Update(ctx context.Context, id int64, request DTO.PriceListRequest) (DTO.PriceListResponse, *errs.AppError) {
+	request, appErr := normalizeRequest(request)
+	if appErr != nil {
+		return DTO.PriceListResponse{}, appErr
+	}
+	old, err := s.q.GetPriceList(ctx, id)
+	if err != nil {
+		return DTO.PriceListResponse{}, errs.FromPgErr(err)
+	}
+	if old.Currency != request.Currency {
+		return DTO.PriceListResponse{}, errs.UnprocessableEntity(fmt.Errorf("price list with id=%d is in %s, its currency can't be changed to %s", id, old.Currency, request.Currency))
+	}
+
+	priceList, err := s.q.UpdatePriceList(ctx, mapRequestToUpdateParams(id, request))
+	if err != nil {
+		return DTO.PriceListResponse{}, errs.FromPgErr(err)
+	}
+
+	return mapPriceListToResponse(priceList), nil
+}
+
+func (s *Service) Delete(ctx context.Context, id int64) (int, *errs.AppError) {
+	rows, err := s.q.DeletePriceList(ctx, id)
+	if err != nil {
+		return 0, errs.Internal(err)
+	}
+	if rows == 0 {
+		return int(rows), errs.NotFound(errors.New("price list not found"))
+	}
+	return int(rows), nil
+}
+
+// GetItems returns a page of the explicit product prices of the list ordered by product id.
+func (s *Service) GetItems(ctx context.Context, id int64, request DTO.PageRequest) (DTO.PriceListItemPageResponse, *errs.AppError) {
+	page, appErr := helpers.ParsePage(request)
+	if appErr != nil {
+		return DTO.PriceListItemPageResponse{}, appErr
+	}
+	priceList, err := s.q.GetPriceList(ctx, id)
+	if err != nil {
+		return DTO.PriceListItemPageResponse{}, errs.FromPgErr(err)
+	}
+
+	rows, err := s.q.GetPriceListItems(ctx, queries.GetPriceListItemsParams{
+		PriceListID: id,
+		CursorID:    page.CursorId,
+		SortDesc:    page.SortDesc,
+		PageLimit:   page.Limit + 1,
+	})
+	if err != nil {
+		return DTO.PriceListItemPageResponse{}, errs.Internal(err)
+	}
+	total, err := s.q.CountPriceListItems(ctx, id)
+	if err != nil {
+		return DTO.PriceListItemPageResponse{}, errs.Internal(err)
+	}
+
+	itemsResponse := make([]DTO.PriceListItemResponse, len(rows))
+	for i, row := range rows {
+		itemsResponse[i] = mapItemRowToResponse(row, priceList.Currency)
+	}
+	items, pageInfo := helpers.Paginate(itemsResponse, total, page, func(item DTO.PriceListItemResponse) (string, int64) {
+		return "", item.ProductId
+	})
+	return DTO.PriceListItemPageResponse{Items: items, PageInfo: pageInfo}, nil
+}
+
+// SetItem sets the explicit price of the product in the list, it must be in the currency of the list.
+func (s *Service) SetItem(ctx context.Context, id, productId int64, request DTO.PriceListItemRequest) (DTO.PriceListItemResponse, *errs.AppError) {
+	err := request.Price.Validate()
+	if err != nil {
+		return DTO.PriceListItemResponse{}, errs.BadRequest(fmt.Errorf("price: %w", err))
+	}
+	priceList, err := s.q.GetPriceList(ctx, id)
+	if err != nil {
+		return DTO.PriceListItemResponse{}, errs.FromPgErr(err)
+	}
+	if priceList.Currency != request.Price.Currency {
+		return DTO.PriceListItemResponse{}, errs.UnprocessableEntity(fmt.Errorf("price list with id=%d is in %s, not %s", id, priceList.Currency, request.Price.Currency))
+	}
+	product, err := s.q.GetProduct(ctx, productId)
+	if err != nil {
+		return DTO.PriceListItemResponse{}, errs.NotFound(fmt.Errorf("product with id=%d not found | %w", productId, err))
+	}
+
+	item, err := s.q.UpsertPriceListItem(ctx, queries.UpsertPriceListItemParams{
+		PriceListID: id,
+		ProductID:   productId,
+		Amount:      request.Price.Amount,
+	})
+	if err != nil {
+		return DTO.PriceListItemResponse{}, errs.FromPgErr(err)
+	}
+
+	return mapItemToResponse(item, product.Name, priceList.Currency), nil
+}
+
+// DeleteItem removes the explicit price, the product gets its converted base price in the list again.
+func (s *Service) DeleteItem(ctx context.Context, id, productId int64) (int, *errs.AppError) {
+	rows, err := s.q.DeletePriceListItem(ctx, queries.DeletePriceListItemParams{PriceListID: id, ProductID: productId})
+	if err != nil {
+		return 0, errs.Internal(err)
+	}
+	if rows == 0 {
+		return int(rows), errs.NotFound(errors.New("price list item not found"))
+	}
+	return int(rows), nil
+}
+
+func normalizeRequest(request DTO.PriceListRequest) (DTO.PriceListRequest, *errs.AppError) {
+	request.Code = strings.TrimSpace(request.Code)
+	if request.Code == "" {
+		return request, errs.BadRequest(errors.New("code is required"))
+	}
+	request.Name = strings.TrimSpace(request.Name)
+	if request.Name == "" {
+		return request, errs.BadRequest(errors.New("name is required"))
+	}
+	request.Currency = strings.ToUpper(strings.TrimSpace(request.Currency))
+	err := money.ValidateCurrency(request.Currency)
+	if err != nil {
+		return request, errs.BadRequest(err)
+	}
+	if request.CustomerGroup != nil {
+		group := strings.TrimSpace(*request.CustomerGroup)
+		if group == "" {
+			return request, errs.BadRequest(errors.New("customer_group must not be empty"))
+		}
+		request.CustomerGroup = &group
+	}
+	if request.RoundingStep != nil && *request.RoundingStep < 1 {
+		return request, errs.BadRequest(errors.New("rounding_step must be at least 1 minor unit"))
+	}
+	if request.RoundingMode != nil {
+		switch *request.RoundingMode {
+		case RoundingHalfUp, RoundingUp, RoundingDown:
+		default:
+			return request, errs.BadRequest(fmt.Errorf("unknown rounding_mode %q, expected half_up, up or down", *request.RoundingMode))
+		}
+	}
+	return request, nil
+}
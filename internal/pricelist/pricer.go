@@ -0,0 +1,198 @@
+package pricelist
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/Aoladiy/go-with-tools/internal/DTO"
+	"github.com/Aoladiy/go-with-tools/internal/database/queries"
+	"github.com/Aoladiy/go-with-tools/internal/errs"
+	"github.com/Aoladiy/go-with-tools/internal/money"
+
+	"github.com/jackc/pgx/v5"
+)
+
+type currencyPair struct {
+	base  string
+	quote string
+}
+
+// Pricer prices products in the currency of the selected price list. A product gets its explicit
+// price in the list if there is one, otherwise its base price converted by the stored exchange rates
+// and rounded to the rounding step of the list.
+type Pricer struct {
+	currency     string
+	roundingStep int64
+	roundingMode string
+	items        map[int64]int64
+	rates        map[currencyPair]*big.Rat
+}
+
+// Pricer selects the price list by its code or, if only a currency is given, the default list of the
+// currency, that is the active one without a customer group. A currency without a default list is
+// plain conversion. Pricer returns nil if nothing is selected, prices stay as they are then.
+func (s *Service) Pricer(ctx context.Context, query DTO.PriceListQuery, productIds []int64) (*Pricer, *errs.AppError) {
+	if query.PriceList == nil && query.Currency == nil {
+		return nil, nil
+	}
+
+	var currency string
+	if query.Currency != nil {
+		currency = strings.ToUpper(strings.TrimSpace(*query.Currency))
+		err := money.ValidateCurrency(currency)
+		if err != nil {
+			return nil, errs.BadRequest(fmt.Errorf("currency: %w", err))
+		}
+	}
+
+	var priceList *queries.PriceList
+	if query.PriceList != nil {
+		found, err := s.q.GetActivePriceListByCode(ctx, *query.PriceList)
+		if err != nil {
+			return nil, errs.FromPgErr(err)
+		}
+		if currency != "" && found.Currency != currency {
+			return nil, errs.BadRequest(fmt.Errorf("price list %s is in %s, not %s", found.Code, found.Currency, currency))
+		}
+		priceList = &found
+	} else {
+		found, err := s.q.GetDefaultPriceList(ctx, currency)
+		if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+			return nil, errs.Internal(err)
+		}
+		if err == nil {
+			priceList = &found
+		}
+	}
+
+	pricer := &Pricer{
+		currency:     currency,
+		roundingStep: 1,
+		roundingMode: RoundingHalfUp,
+		items:        map[int64]int64{},
+		rates:        map[currencyPair]*big.Rat{},
+	}
+	if priceList != nil {
+		pricer.currency = priceList.Currency
+		pricer.roundingStep = priceList.RoundingStep
+		pricer.roundingMode = priceList.RoundingMode
+
+		items, err := s.q.GetPriceListItemsByProductIds(ctx, queries.GetPriceListItemsByProductIdsParams{
+			PriceListID: priceList.ID,
+			ProductIds:  productIds,
+		})
+		if err != nil {
+			return nil, errs.Internal(err)
+		}
+		for _, item := range items {
+			pricer.items[item.ProductID] = item.Amount
+		}
+	}
+
+	rates, err := s.q.GetExchangeRates(ctx)
+	if err != nil {
+		return nil, errs.Internal(err)
+	}
+	for _, rate := range rates {
+		value, ok := new(big.Rat).SetString(rate.Rate)
+		if !ok {
+			return nil, errs.Internal(fmt.Errorf("invalid exchange rate %q from %s to %s", rate.Rate, rate.BaseCurrency, rate.QuoteCurrency))
+		}
+		pricer.rates[currencyPair{rate.BaseCurrency, rate.QuoteCurrency}] = value
+	}
+	return pricer, nil
+}
+
+func (p *Pricer) Currency() string {
+	return p.currency
+}
+
+// Price returns the price of the product in the list, base is its own price.
+func (p *Pricer) Price(productId int64, base money.Money) (money.Money, *errs.AppError) {
+	if amount, ok := p.items[productId]; ok {
+		return money.New(amount, p.currency), nil
+	}
+	return p.Convert(base)
+}
+
+// Convert converts the price to the currency of the list and rounds it by the rules of the list.
+// Prices already in that currency are returned as they are.
+func (p *Pricer) Convert(price money.Money) (money.Money, *errs.AppError) {
+	if price.Currency == p.currency {
+		return price, nil
+	}
+	rate, ok := p.rate(price.Currency, p.currency)
+	if !ok {
+		return money.Money{}, errs.UnprocessableEntity(fmt.Errorf("no exchange rate from %s to %s", price.Currency, p.currency))
+	}
+
+	fromUnits, _ := money.MinorUnits(price.Currency)
+	toUnits, _ := money.MinorUnits(p.currency)
+	converted := new(big.Rat).Mul(big.NewRat(price.Amount, 1), rate)
+	scale := new(big.Rat).SetInt(new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(abs(toUnits-fromUnits))), nil))
+	if toUnits > fromUnits {
+		converted.Mul(converted, scale)
+	} else {
+		converted.Quo(converted, scale)
+	}
+
+	amount, ok := round(converted, p.roundingStep, p.roundingMode)
+	if !ok {
+		return money.Money{}, errs.UnprocessableEntity(fmt.Errorf("%s converted to %s doesn't fit into a price", price, p.currency))
+	}
+	return money.New(amount, p.currency), nil
+}
+
+// rate looks for the direct rate, the inverse one and then the cross rate through the default currency.
+func (p *Pricer) rate(from, to string) (*big.Rat, bool) {
+	if rate, ok := p.rates[currencyPair{from, to}]; ok {
+		return rate, true
+	}
+	if rate, ok := p.rates[currencyPair{to, from}]; ok {
+		return new(big.Rat).Inv(rate), true
+	}
+	if from == money.DefaultCurrency || to == money.DefaultCurrency {
+		return nil, false
+	}
+	toDefault, ok := p.rate(from, money.DefaultCurrency)
+	if !ok {
+		return nil, false
+	}
+	fromDefault, ok := p.rate(money.DefaultCurrency, to)
+	if !ok {
+		return nil, false
+	}
+	return new(big.Rat).Mul(toDefault, fromDefault), true
+}
+
+// round rounds the non-negative amount to a multiple of the step, false means it overflows int64.
+func round(amount *big.Rat, step int64, mode string) (int64, bool) {
+	steps := new(big.Rat).Quo(amount, big.NewRat(step, 1))
+	quotient, remainder := new(big.Int).QuoRem(steps.Num(), steps.Denom(), new(big.Int))
+	if remainder.Sign() != 0 {
+		switch mode {
+		case RoundingUp:
+			quotient.Add(quotient, big.NewInt(1))
+		case RoundingHalfUp:
+			if new(big.Int).Lsh(remainder, 1).Cmp(steps.Denom()) >= 0 {
+				quotient.Add(quotient, big.NewInt(1))
+			}
+		}
+	}
+
+	rounded := quotient.Mul(quotient, big.NewInt(step))
+	if !rounded.IsInt64() {
+		return 0, false
+	}
+	return rounded.Int64(), true
+}
+
+func abs(value int) int {
+	if value < 0 {
+		return -value
+	}
+	return value
+}
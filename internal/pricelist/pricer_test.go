@@ -0,0 +1,184 @@
+package pricelist
+
+import (
+	"math"
+	"math/big"
+	"testing"
+
+	"github.com/Aoladiy/go-with-tools/internal/errs"
+	"github.com/Aoladiy/go-with-tools/internal/money"
+)
+
+func rat(t *testing.T, value string) *big.Rat {
+	t.Helper()
+	parsed, ok := new(big.Rat).SetString(value)
+	if !ok {
+		t.Fatalf("invalid rational %q", value)
+	}
+	return parsed
+}
+
+func TestRound(t *testing.T) {
+	tests := []struct {
+		name   string
+		amount string
+		step   int64
+		mode   string
+		want   int64
+		wantOk bool
+	}{
+		{"half up rounds a half up", "1234.5", 1, RoundingHalfUp, 1235, true},
+		{"half up rounds less than a half down", "1234.4", 1, RoundingHalfUp, 1234, true},
+		{"up", "1234.1", 1, RoundingUp, 1235, true},
+		{"down", "1234.9", 1, RoundingDown, 1234, true},
+		{"exact amount is kept", "1234", 1, RoundingUp, 1234, true},
+		{"zero", "0", 100, RoundingUp, 0, true},
+		{"half up to a step", "1249.99", 100, RoundingHalfUp, 1200, true},
+		{"half up to a step at a half", "1250", 100, RoundingHalfUp, 1300, true},
+		{"up to a step", "1201", 100, RoundingUp, 1300, true},
+		{"down to a step", "1299", 100, RoundingDown, 1200, true},
+		{"exact multiple of a step is kept", "1200", 100, RoundingUp, 1200, true},
+		{"up to a step of 99", "100", 99, RoundingUp, 198, true},
+		{"largest amount", "9223372036854775807", 1, RoundingHalfUp, math.MaxInt64, true},
+		{"rounding down the largest amount and a half", "9223372036854775807.5", 1, RoundingDown, math.MaxInt64, true},
+		{"overflow by rounding up", "9223372036854775807.5", 1, RoundingHalfUp, 0, false},
+		{"overflow by rounding up to a step", "9223372036854775807", 100, RoundingUp, 0, false},
+		{"overflow", "18446744073709551616", 1, RoundingDown, 0, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := round(rat(t, tt.amount), tt.step, tt.mode)
+			if got != tt.want || ok != tt.wantOk {
+				t.Errorf("round(%s, %d, %s) = %d, %v, want %d, %v", tt.amount, tt.step, tt.mode, got, ok, tt.want, tt.wantOk)
+			}
+		})
+	}
+}
+
+// newPricer is a pricer of the currency with the rates USD/RUB 90, EUR/RUB 100, USD/JPY 150
+// and KWD/RUB 300.
+func newPricer(t *testing.T, currency string, step int64, mode string) *Pricer {
+	return &Pricer{
+		currency:     currency,
+		roundingStep: step,
+		roundingMode: mode,
+		items:        map[int64]int64{},
+		rates: map[currencyPair]*big.Rat{
+			{"USD", "RUB"}: rat(t, "90"),
+			{"EUR", "RUB"}: rat(t, "100"),
+			{"USD", "JPY"}: rat(t, "150"),
+			{"KWD", "RUB"}: rat(t, "300"),
+		},
+	}
+}
+
+func TestConvert(t *testing.T) {
+	tests := []struct {
+		name     string
+		currency string
+		step     int64
+		mode     string
+		price    money.Money
+		want     money.Money
+	}{
+		{"same currency is kept as it is", "RUB", 100, RoundingUp, money.New(12345, "RUB"), money.New(12345, "RUB")},
+		{"direct rate", "RUB", 1, RoundingHalfUp, money.New(150, "USD"), money.New(13500, "RUB")},
+		{"inverse rate", "USD", 1, RoundingHalfUp, money.New(9000, "RUB"), money.New(100, "USD")},
+		{"inverse rate rounded half up", "USD", 1, RoundingHalfUp, money.New(50, "RUB"), money.New(1, "USD")},
+		{"inverse rate rounded down", "USD", 1, RoundingDown, money.New(100, "RUB"), money.New(1, "USD")},
+		{"cross rate through the default currency", "EUR", 1, RoundingHalfUp, money.New(9000, "USD"), money.New(8100, "EUR")},
+		{"cross rate back", "USD", 1, RoundingHalfUp, money.New(8100, "EUR"), money.New(9000, "USD")},
+		{"to fewer minor units", "JPY", 1, RoundingHalfUp, money.New(199, "USD"), money.New(299, "JPY")},
+		{"to more minor units", "USD", 1, RoundingHalfUp, money.New(300, "JPY"), money.New(200, "USD")},
+		{"from three minor units", "RUB", 1, RoundingHalfUp, money.New(1000, "KWD"), money.New(30000, "RUB")},
+		{"rounded up to whole roubles", "RUB", 100, RoundingUp, money.New(101, "USD"), money.New(9100, "RUB")},
+		{"rounded half up to whole roubles", "RUB", 100, RoundingHalfUp, money.New(101, "USD"), money.New(9100, "RUB")},
+		{"rounded down to whole roubles", "RUB", 100, RoundingDown, money.New(101, "USD"), money.New(9000, "RUB")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, appErr := newPricer(t, tt.currency, tt.step, tt.mode).Convert(tt.price)
+			if appErr != nil {
+				t.Fatalf("Convert(%v) error = %v", tt.price, appErr.Err)
+			}
+			if got != tt.want {
+				t.Errorf("Convert(%v) = %v, want %v", tt.price, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestConvertFails(t *testing.T) {
+	tests := []struct {
+		name     string
+		currency string
+		price    money.Money
+	}{
+		{"no rate", "GBP", money.New(100, "USD")},
+		{"no rate from the default currency", "GBP", money.New(100, "RUB")},
+		{"no cross rate", "USD", money.New(100, "CNY")},
+		{"overflow", "RUB", money.New(math.MaxInt64, "USD")},
+		{"overflow by scaling to more minor units", "RUB", money.New(math.MaxInt64/10, "JPY")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, appErr := newPricer(t, tt.currency, 1, RoundingHalfUp).Convert(tt.price)
+			if appErr == nil {
+				t.Fatalf("Convert(%v) = %v, want an error", tt.price, got)
+			}
+			if appErr.Code != errs.UnprocessableEntityErrCode {
+				t.Errorf("Convert(%v) error code = %v, want %v", tt.price, appErr.Code, errs.UnprocessableEntityErrCode)
+			}
+		})
+	}
+}
+
+func TestRate(t *testing.T) {
+	pricer := newPricer(t, "RUB", 1, RoundingHalfUp)
+	tests := []struct {
+		from, to string
+		want     string
+		wantOk   bool
+	}{
+		{"USD", "RUB", "90", true},
+		{"RUB", "USD", "1/90", true},
+		{"USD", "EUR", "9/10", true},
+		{"EUR", "USD", "10/9", true},
+		{"KWD", "USD", "10/3", true},
+		{"JPY", "USD", "1/150", true},
+		{"GBP", "RUB", "", false},
+		{"GBP", "USD", "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.from+"/"+tt.to, func(t *testing.T) {
+			got, ok := pricer.rate(tt.from, tt.to)
+			if ok != tt.wantOk {
+				t.Fatalf("rate(%s, %s) ok = %v, want %v", tt.from, tt.to, ok, tt.wantOk)
+			}
+			if ok && got.Cmp(rat(t, tt.want)) != 0 {
+				t.Errorf("rate(%s, %s) = %s, want %s", tt.from, tt.to, got.RatString(), tt.want)
+			}
+		})
+	}
+}
+
+func TestPrice(t *testing.T) {
+	pricer := newPricer(t, "RUB", 100, RoundingUp)
+	pricer.items[1] = 4999
+
+	got, appErr := pricer.Price(1, money.New(100, "USD"))
+	if appErr != nil {
+		t.Fatalf("Price() error = %v", appErr.Err)
+	}
+	if want := money.New(4999, "RUB"); got != want {
+		t.Errorf("Price() of a product in the list = %v, want %v", got, want)
+	}
+
+	got, appErr = pricer.Price(2, money.New(100, "USD"))
+	if appErr != nil {
+		t.Fatalf("Price() error = %v", appErr.Err)
+	}
+	if want := money.New(9000, "RUB"); got != want {
+		t.Errorf("Price() of a product not in the list = %v, want %v", got, want)
+	}
+}
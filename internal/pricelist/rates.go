@@ -0,0 +1,144 @@
+package pricelist
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+
+	"github.com/Aoladiy/go-with-tools/internal/DTO"
+	"github.com/Aoladiy/go-with-tools/internal/database/queries"
+	"github.com/Aoladiy/go-with-tools/internal/errs"
+	"github.com/Aoladiy/go-with-tools/internal/helpers"
+	"github.com/Aoladiy/go-with-tools/internal/money"
+)
+
+// rateRegexp matches what fits into numeric(20,10).
+var rateRegexp = regexp.MustCompile(`^\d{1,10}(\.\d{1,10})?$`)
+
+var rateCsvHeader = []string{"base_currency", "quote_currency", "rate"}
+
+func (s *Service) GetRates(ctx context.Context) ([]DTO.ExchangeRateResponse, *errs.AppError) {
+	rates, err := s.q.GetExchangeRates(ctx)
+	if err != nil {
+		return nil, errs.Internal(err)
+	}
+
+	ratesResponse := make([]DTO.ExchangeRateResponse, len(rates))
+	for i, rate := range rates {
+		ratesResponse[i] = mapRateToResponse(rate)
+	}
+	return ratesResponse, nil
+}
+
+// UpsertRate stores how many units of the quote currency one unit of the base currency costs.
+func (s *Service) UpsertRate(ctx context.Context, request DTO.ExchangeRateRequest) (DTO.ExchangeRateResponse, *errs.AppError) {
+	params, err := rateParams(request)
+	if err != nil {
+		return DTO.ExchangeRateResponse{}, errs.BadRequest(err)
+	}
+
+	rate, err := s.q.UpsertExchangeRate(ctx, params)
+	if err != nil {
+		return DTO.ExchangeRateResponse{}, errs.FromPgErr(err)
+	}
+
+	return mapRateToResponse(queries.GetExchangeRatesRow(rate)), nil
+}
+
+func (s *Service) DeleteRate(ctx context.Context, id int64) (int, *errs.AppError) {
+	rows, err := s.q.DeleteExchangeRate(ctx, id)
+	if err != nil {
+		return 0, errs.Internal(err)
+	}
+	if rows == 0 {
+		return int(rows), errs.NotFound(errors.New("exchange rate not found"))
+	}
+	return int(rows), nil
+}
+
+// ImportRates upserts the rates of a base_currency,quote_currency,rate CSV file, the header line is optional.
+// Nothing is stored if any line is invalid, all line errors are returned at once.
+func (s *Service) ImportRates(ctx context.Context, file io.Reader) (DTO.ExchangeRateImportResponse, *errs.AppError) {
+	reader := csv.NewReader(file)
+	reader.FieldsPerRecord = len(rateCsvHeader)
+	reader.TrimLeadingSpace = true
+
+	var params []queries.UpsertExchangeRateParams
+	var lineErrs []error
+	for line := 1; ; line++ {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			var parseErr *csv.ParseError
+			if errors.As(err, &parseErr) && errors.Is(parseErr.Err, csv.ErrFieldCount) {
+				lineErrs = append(lineErrs, fmt.Errorf("line %d: expected %d fields", parseErr.Line, len(rateCsvHeader)))
+				continue
+			}
+			return DTO.ExchangeRateImportResponse{}, errs.BadRequest(fmt.Errorf("invalid csv: %w", err))
+		}
+		if line == 1 && strings.EqualFold(strings.TrimSpace(record[0]), rateCsvHeader[0]) {
+			continue
+		}
+
+		param, err := rateParams(DTO.ExchangeRateRequest{
+			BaseCurrency:  record[0],
+			QuoteCurrency: record[1],
+			Rate:          record[2],
+		})
+		if err != nil {
+			lineErrs = append(lineErrs, fmt.Errorf("line %d: %w", line, err))
+			continue
+		}
+		params = append(params, param)
+	}
+	if len(lineErrs) > 0 {
+		return DTO.ExchangeRateImportResponse{}, errs.BadRequest(errors.Join(lineErrs...))
+	}
+	if len(params) == 0 {
+		return DTO.ExchangeRateImportResponse{}, errs.BadRequest(errors.New("file has no exchange rates"))
+	}
+
+	appErr := helpers.WithTx(ctx, s.p, s.q, func(timeout context.Context, q *queries.Queries) *errs.AppError {
+		for _, param := range params {
+			_, err := q.UpsertExchangeRate(timeout, param)
+			if err != nil {
+				return errs.FromPgErr(err)
+			}
+		}
+		return nil
+	})
+	if appErr != nil {
+		return DTO.ExchangeRateImportResponse{}, appErr
+	}
+	return DTO.ExchangeRateImportResponse{Imported: len(params)}, nil
+}
+
+func rateParams(request DTO.ExchangeRateRequest) (queries.UpsertExchangeRateParams, error) {
+	base := strings.ToUpper(strings.TrimSpace(request.BaseCurrency))
+	err := money.ValidateCurrency(base)
+	if err != nil {
+		return queries.UpsertExchangeRateParams{}, fmt.Errorf("base_currency: %w", err)
+	}
+	quote := strings.ToUpper(strings.TrimSpace(request.QuoteCurrency))
+	err = money.ValidateCurrency(quote)
+	if err != nil {
+		return queries.UpsertExchangeRateParams{}, fmt.Errorf("quote_currency: %w", err)
+	}
+	if base == quote {
+		return queries.UpsertExchangeRateParams{}, errors.New("base_currency and quote_currency must differ")
+	}
+	rate := strings.ReplaceAll(strings.TrimSpace(request.Rate), ",", ".")
+	if !rateRegexp.MatchString(rate) {
+		return queries.UpsertExchangeRateParams{}, fmt.Errorf("rate %q must be a positive decimal with up to 10 digits before and after the point", request.Rate)
+	}
+	if strings.Trim(rate, "0.") == "" {
+		return queries.UpsertExchangeRateParams{}, errors.New("rate must be greater than zero")
+	}
+	return queries.UpsertExchangeRateParams{BaseCurrency: base, QuoteCurrency: quote, Rate: rate}, nil
+}
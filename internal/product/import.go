@@ -0,0 +1,324 @@
+package product
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Aoladiy/go-with-tools/internal/DTO"
+	"github.com/Aoladiy/go-with-tools/internal/database/queries"
+	"github.com/Aoladiy/go-with-tools/internal/errs"
+	"github.com/Aoladiy/go-with-tools/internal/helpers"
+	"github.com/Aoladiy/go-with-tools/internal/money"
+)
+
+const (
+	maxImportRows = 5000
+	importTimeout = time.Minute
+)
+
+var (
+	importRequiredColumns = []string{"slug", "name", "brand_slug", "category_slug", "price"}
+	importOptionalColumns = []string{"description", "is_active", "currency"}
+)
+
+// importRow is a parsed line of the file, id is set for products that already exist.
+type importRow struct {
+	line      int
+	id        *int64
+	unchanged bool
+	request   DTO.ProductRequest
+}
+
+// Import creates or updates products by slug from a CSV file with a header line. slug, name,
+// brand_slug, category_slug and price columns are required, description, is_active and currency
+// are optional. price is a decimal in currency, which defaults to the currency of the existing
+// product or RUB, a missing description or is_active keeps the one of the existing product.
+// Every row is validated first and nothing is imported if any of them is invalid, in a dry run
+// nothing is imported at all. Unchanged products aren't touched.
+func (s *Service) Import(ctx context.Context, file io.Reader, request DTO.ProductImportRequest) (DTO.ProductImportResponse, *errs.AppError) {
+	header, records, appErr := readImportFile(file)
+	if appErr != nil {
+		return DTO.ProductImportResponse{}, appErr
+	}
+
+	rows, importErrs, appErr := s.parseImportRows(ctx, header, records)
+	if appErr != nil {
+		return DTO.ProductImportResponse{}, appErr
+	}
+
+	response := DTO.ProductImportResponse{
+		DryRun: request.DryRun,
+		Total:  len(records),
+		Errors: importErrs,
+	}
+	for _, row := range rows {
+		switch {
+		case row.id == nil:
+			response.Created++
+		case row.unchanged:
+			response.Unchanged++
+		default:
+			response.Updated++
+		}
+	}
+	if len(response.Errors) > 0 || request.DryRun {
+		return response, nil
+	}
+
+	appErr = helpers.WithTxTimeout(ctx, s.p, s.q, importTimeout, func(timeout context.Context, q *queries.Queries) *errs.AppError {
+		for _, row := range rows {
+			var appErr *errs.AppError
+			switch {
+			case row.id == nil:
+				_, appErr = s.create(timeout, q, row.request)
+			case !row.unchanged:
+				_, appErr = s.update(timeout, q, *row.id, row.request)
+			}
+			if appErr != nil {
+				appErr.Err = fmt.Errorf("line %d: %w", row.line, appErr.Err)
+				return appErr
+			}
+		}
+		return nil
+	})
+	if appErr != nil {
+		return DTO.ProductImportResponse{}, appErr
+	}
+	return response, nil
+}
+
+// importRecord is a line of the file split into fields.
+type importRecord struct {
+	line   int
+	fields []string
+}
+
+// readImportFile reads the whole file and checks the header, it returns the header and the other records.
+func readImportFile(file io.Reader) ([]string, []importRecord, *errs.AppError) {
+	reader := csv.NewReader(file)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	var records []importRecord
+	for {
+		fields, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, nil, errs.BadRequest(fmt.Errorf("invalid csv: %w", err))
+		}
+		line, _ := reader.FieldPos(0)
+		records = append(records, importRecord{line: line, fields: fields})
+		if len(records)-1 > maxImportRows {
+			return nil, nil, errs.BadRequest(fmt.Errorf("at most %d products can be imported at once", maxImportRows))
+		}
+	}
+	if len(records) < 2 {
+		return nil, nil, errs.BadRequest(errors.New("file has no products"))
+	}
+
+	header := records[0].fields
+	for i, column := range header {
+		header[i] = strings.ToLower(strings.TrimSpace(column))
+		if !slices.Contains(importRequiredColumns, header[i]) && !slices.Contains(importOptionalColumns, header[i]) {
+			return nil, nil, errs.BadRequest(fmt.Errorf("unknown column %q", column))
+		}
+		if slices.Contains(header[:i], header[i]) {
+			return nil, nil, errs.BadRequest(fmt.Errorf("duplicate column %q", column))
+		}
+	}
+	for _, column := range importRequiredColumns {
+		if !slices.Contains(header, column) {
+			return nil, nil, errs.BadRequest(fmt.Errorf("column %q is required", column))
+		}
+	}
+	return header, records[1:], nil
+}
+
+// parseImportRows turns the records into product requests checked the same way Create and Update
+// check theirs: each row against the binding rules of the request and its price, while the brands and
+// categories are resolved for the whole file at once. Problems of the rows are returned as import
+// errors, not as an AppError.
+func (s *Service) parseImportRows(ctx context.Context, header []string, records []importRecord) ([]importRow, []DTO.ProductImportErrorResponse, *errs.AppError) {
+	values := make([]map[string]string, len(records))
+	var slugs, brandSlugs, categorySlugs []string
+	for i, record := range records {
+		if len(record.fields) != len(header) {
+			continue
+		}
+		values[i] = make(map[string]string, len(header))
+		for j, column := range header {
+			values[i][column] = strings.TrimSpace(record.fields[j])
+		}
+		slugs = append(slugs, values[i]["slug"])
+		brandSlugs = append(brandSlugs, values[i]["brand_slug"])
+		categorySlugs = append(categorySlugs, values[i]["category_slug"])
+	}
+
+	existing, brandIds, categoryIds, appErr := s.getImportReferences(ctx, slugs, brandSlugs, categorySlugs)
+	if appErr != nil {
+		return nil, nil, appErr
+	}
+
+	rows := make([]importRow, 0, len(values))
+	importErrs := []DTO.ProductImportErrorResponse{}
+	lines := make(map[string]int, len(values))
+	for i, value := range values {
+		line := records[i].line
+		slug := value["slug"]
+		rowErr := func(err error) {
+			importErrs = append(importErrs, DTO.ProductImportErrorResponse{Line: line, Slug: slug, Error: err.Error()})
+		}
+
+		if value == nil {
+			rowErr(fmt.Errorf("expected %d fields, got %d", len(header), len(records[i].fields)))
+			continue
+		}
+		if slug == "" {
+			rowErr(errors.New("slug is required"))
+			continue
+		}
+		if first, ok := lines[slug]; ok {
+			rowErr(fmt.Errorf("slug is already used on line %d", first))
+			continue
+		}
+		lines[slug] = line
+
+		row := importRow{line: line}
+		product, ok := existing[slug]
+		if ok {
+			if product.DeletedAt.Valid {
+				rowErr(errors.New("product with the slug is in the trash, restore it first"))
+				continue
+			}
+			row.id = &product.ID
+		}
+
+		request, err := importRequest(value, brandIds, categoryIds, product)
+		if err != nil {
+			rowErr(err)
+			continue
+		}
+		err = helpers.ValidateStruct(request)
+		if err != nil {
+			rowErr(err)
+			continue
+		}
+		err = request.Price.Validate()
+		if err != nil {
+			rowErr(fmt.Errorf("price: %w", err))
+			continue
+		}
+		if row.id != nil && product.Currency != request.Price.Currency {
+			rowErr(fmt.Errorf("product is priced in %s, its currency can't be changed to %s", product.Currency, request.Price.Currency))
+			continue
+		}
+
+		row.request = request
+		row.unchanged = row.id != nil && isUnchanged(product, request)
+		rows = append(rows, row)
+	}
+	return rows, importErrs, nil
+}
+
+// getImportReferences returns the products of the file including trashed ones keyed by slug and ids of
+// brands and categories keyed by slug, with a query for each.
+func (s *Service) getImportReferences(ctx context.Context, slugs, brandSlugs, categorySlugs []string) (map[string]queries.GetProductsBySlugsRow, map[string]int64, map[string]int64, *errs.AppError) {
+	products, err := s.q.GetProductsBySlugs(ctx, slugs)
+	if err != nil {
+		return nil, nil, nil, errs.Internal(err)
+	}
+	brands, err := s.q.GetBrandIdsBySlugs(ctx, brandSlugs)
+	if err != nil {
+		return nil, nil, nil, errs.Internal(err)
+	}
+	categories, err := s.q.GetCategoryIdsBySlugs(ctx, categorySlugs)
+	if err != nil {
+		return nil, nil, nil, errs.Internal(err)
+	}
+
+	existing := make(map[string]queries.GetProductsBySlugsRow, len(products))
+	for _, product := range products {
+		existing[product.Slug] = product
+	}
+	brandIds := make(map[string]int64, len(brands))
+	for _, brand := range brands {
+		brandIds[brand.Slug] = brand.ID
+	}
+	categoryIds := make(map[string]int64, len(categories))
+	for _, category := range categories {
+		categoryIds[category.Slug] = category.ID
+	}
+	return existing, brandIds, categoryIds, nil
+}
+
+// importRequest builds the request of a row, product is the existing one with the slug if any.
+// The optional columns that are missing or empty keep the values of the existing product.
+func importRequest(value map[string]string, brandIds, categoryIds map[string]int64, product queries.GetProductsBySlugsRow) (DTO.ProductRequest, error) {
+	request := DTO.ProductRequest{
+		Name: value["name"],
+		Slug: value["slug"],
+	}
+	if request.Name == "" {
+		return DTO.ProductRequest{}, errors.New("name is required")
+	}
+
+	brandId, ok := brandIds[value["brand_slug"]]
+	if !ok {
+		return DTO.ProductRequest{}, fmt.Errorf("brand with slug %q not found", value["brand_slug"])
+	}
+	request.BrandId = brandId
+	categoryId, ok := categoryIds[value["category_slug"]]
+	if !ok {
+		return DTO.ProductRequest{}, fmt.Errorf("category with slug %q not found", value["category_slug"])
+	}
+	request.CategoryId = categoryId
+
+	currency := strings.ToUpper(value["currency"])
+	if currency == "" {
+		currency = product.Currency
+	}
+	if currency == "" {
+		currency = money.DefaultCurrency
+	}
+	price, err := money.Parse(value["price"], currency)
+	if err != nil {
+		return DTO.ProductRequest{}, fmt.Errorf("price: %w", err)
+	}
+	request.Price = price
+
+	exists := product.ID != 0
+	if description := value["description"]; description != "" {
+		request.Description = &description
+	} else if exists {
+		request.Description = &product.Description
+	}
+	if isActive := value["is_active"]; isActive != "" {
+		parsed, err := strconv.ParseBool(isActive)
+		if err != nil {
+			return DTO.ProductRequest{}, fmt.Errorf("is_active %q must be true or false", isActive)
+		}
+		request.IsActive = &parsed
+	} else if exists {
+		request.IsActive = &product.IsActive
+	}
+	return request, nil
+}
+
+func isUnchanged(product queries.GetProductsBySlugsRow, request DTO.ProductRequest) bool {
+	params := mapRequestToUpdateParams(product.ID, request)
+	return product.BrandID == params.BrandID &&
+		product.CategoryID == params.CategoryID &&
+		product.Name == params.Name &&
+		product.Description == params.Description &&
+		product.PriceKopeck == params.PriceKopeck &&
+		product.IsActive == params.IsActive
+}
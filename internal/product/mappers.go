@@ -4,6 +4,7 @@ import (
 	"github.com/Aoladiy/go-with-tools/internal/DTO"
 	"github.com/Aoladiy/go-with-tools/internal/database/queries"
 	"github.com/Aoladiy/go-with-tools/internal/helpers"
+	"github.com/Aoladiy/go-with-tools/internal/money"
 )
 
 func mapRequestToCreateParams(request DTO.ProductRequest) queries.CreateProductParams {
@@ -13,7 +14,8 @@ func mapRequestToCreateParams(request DTO.ProductRequest) queries.CreateProductP
 		Name:        request.Name,
 		Slug:        request.Slug,
 		Description: helpers.DerefString(request.Description, ""),
-		PriceKopeck: request.PriceKopeck,
+		PriceKopeck: request.Price.Amount,
+		Currency:    request.Price.Currency,
 		IsActive:    helpers.DerefBool(request.IsActive, true),
 	}
 }
@@ -26,10 +28,88 @@ func mapCreateRowToResponse(product queries.CreateProductRow) DTO.ProductRespons
 		Name:        product.Name,
 		Slug:        product.Slug,
 		Description: product.Description,
-		PriceKopeck: product.PriceKopeck,
+		Price:       money.New(product.PriceKopeck, product.Currency),
 		IsActive:    product.IsActive,
 		CreatedAt:   product.CreatedAt,
 		UpdatedAt:   product.UpdatedAt,
+		Version:     product.Version,
+	}
+}
+
+// mapPatchToParams sets only the columns of the members present in the patch, request is the
+// product with the patch applied.
+func mapPatchToParams(id int64, patch DTO.ProductPatchRequest, request DTO.ProductRequest) queries.PatchProductParams {
+	params := queries.PatchProductParams{ID: id}
+	if patch.BrandId.Set {
+		params.BrandID = &request.BrandId
+	}
+	if patch.CategoryId.Set {
+		params.CategoryID = &request.CategoryId
+	}
+	if patch.Name.Set {
+		params.Name = &request.Name
+	}
+	if patch.Slug.Set {
+		params.Slug = &request.Slug
+	}
+	if patch.Description.Set {
+		params.Description = request.Description
+	}
+	if patch.Price.Set {
+		params.PriceKopeck = &request.Price.Amount
+	}
+	if patch.IsActive.Set {
+		params.IsActive = request.IsActive
+	}
+	return params
+}
+
+func mapPatchRowToResponse(product queries.PatchProductRow) DTO.ProductResponse {
+	return DTO.ProductResponse{
+		Id:          product.ID,
+		BrandId:     product.BrandID,
+		CategoryId:  product.CategoryID,
+		Name:        product.Name,
+		Slug:        product.Slug,
+		Description: product.Description,
+		Price:       money.New(product.PriceKopeck, product.Currency),
+		IsActive:    product.IsActive,
+		CreatedAt:   product.CreatedAt,
+		UpdatedAt:   product.UpdatedAt,
+		Version:     product.Version,
+	}
+}
+
+func mapListRequestToGetAllParams(request DTO.ProductListRequest, page helpers.Page) queries.GetAllProductsParams {
+	return queries.GetAllProductsParams{
+		BrandID:     request.BrandId,
+		CategoryID:  request.CategoryId,
+		IsActive:    request.IsActive,
+		PriceMin:    request.PriceMin,
+		PriceMax:    request.PriceMax,
+		CreatedFrom: helpers.ToPgTimestamptz(request.CreatedFrom),
+		CreatedTo:   helpers.ToPgTimestamptz(request.CreatedTo),
+		UpdatedFrom: helpers.ToPgTimestamptz(request.UpdatedFrom),
+		UpdatedTo:   helpers.ToPgTimestamptz(request.UpdatedTo),
+		CursorID:    page.CursorId,
+		CursorValue: page.CursorValue,
+		SortBy:      page.SortBy,
+		SortDesc:    page.SortDesc,
+		PageLimit:   page.Limit + 1,
+	}
+}
+
+func mapListRequestToCountParams(request DTO.ProductListRequest) queries.CountProductsParams {
+	return queries.CountProductsParams{
+		BrandID:     request.BrandId,
+		CategoryID:  request.CategoryId,
+		IsActive:    request.IsActive,
+		PriceMin:    request.PriceMin,
+		PriceMax:    request.PriceMax,
+		CreatedFrom: helpers.ToPgTimestamptz(request.CreatedFrom),
+		CreatedTo:   helpers.ToPgTimestamptz(request.CreatedTo),
+		UpdatedFrom: helpers.ToPgTimestamptz(request.UpdatedFrom),
+		UpdatedTo:   helpers.ToPgTimestamptz(request.UpdatedTo),
 	}
 }
 
@@ -41,10 +121,11 @@ func mapGetAllRowToResponse(product queries.GetAllProductsRow) DTO.ProductRespon
 		Name:        product.Name,
 		Slug:        product.Slug,
 		Description: product.Description,
-		PriceKopeck: product.PriceKopeck,
+		Price:       money.New(product.PriceKopeck, product.Currency),
 		IsActive:    product.IsActive,
 		CreatedAt:   product.CreatedAt,
 		UpdatedAt:   product.UpdatedAt,
+		Version:     product.Version,
 	}
 }
 
@@ -56,10 +137,11 @@ func mapGetRowToResponse(product queries.GetProductRow) DTO.ProductResponse {
 		Name:        product.Name,
 		Slug:        product.Slug,
 		Description: product.Description,
-		PriceKopeck: product.PriceKopeck,
+		Price:       money.New(product.PriceKopeck, product.Currency),
 		IsActive:    product.IsActive,
 		CreatedAt:   product.CreatedAt,
 		UpdatedAt:   product.UpdatedAt,
+		Version:     product.Version,
 	}
 }
 
@@ -71,7 +153,7 @@ func mapRequestToUpdateParams(id int64, request DTO.ProductRequest) queries.Upda
 		Name:        request.Name,
 		Slug:        request.Slug,
 		Description: helpers.DerefString(request.Description, ""),
-		PriceKopeck: request.PriceKopeck,
+		PriceKopeck: request.Price.Amount,
 		IsActive:    helpers.DerefBool(request.IsActive, true),
 	}
 }
@@ -84,9 +166,221 @@ func mapUpdateRowToResponse(product queries.UpdateProductRow) DTO.ProductRespons
 		Name:        product.Name,
 		Slug:        product.Slug,
 		Description: product.Description,
-		PriceKopeck: product.PriceKopeck,
+		Price:       money.New(product.PriceKopeck, product.Currency),
+		IsActive:    product.IsActive,
+		CreatedAt:   product.CreatedAt,
+		UpdatedAt:   product.UpdatedAt,
+		Version:     product.Version,
+	}
+}
+
+func mapUpdatePriceRowToResponse(product queries.UpdateProductPriceRow) DTO.ProductResponse {
+	return DTO.ProductResponse{
+		Id:          product.ID,
+		BrandId:     product.BrandID,
+		CategoryId:  product.CategoryID,
+		Name:        product.Name,
+		Slug:        product.Slug,
+		Description: product.Description,
+		Price:       money.New(product.PriceKopeck, product.Currency),
+		IsActive:    product.IsActive,
+		CreatedAt:   product.CreatedAt,
+		UpdatedAt:   product.UpdatedAt,
+		Version:     product.Version,
+	}
+}
+
+func mapGetFrontFilteredRowToFrontResponse(product queries.GetFrontFilteredProductsRow) DTO.FrontProductResponse {
+	return DTO.FrontProductResponse{
+		Id:           product.ID,
+		BrandId:      product.BrandID,
+		BrandName:    product.BrandName,
+		CategoryId:   product.CategoryID,
+		CategoryName: product.CategoryName,
+		Name:         product.Name,
+		Slug:         product.Slug,
+		Description:  product.Description,
+		Price:        money.New(product.PriceKopeck, product.Currency),
+	}
+}
+
+func mapGetFrontBySlugRowToDetailResponse(product queries.GetFrontProductBySlugRow) DTO.FrontProductDetailResponse {
+	return DTO.FrontProductDetailResponse{
+		FrontProductResponse: DTO.FrontProductResponse{
+			Id:           product.ID,
+			BrandId:      product.BrandID,
+			BrandName:    product.BrandName,
+			CategoryId:   product.CategoryID,
+			CategoryName: product.CategoryName,
+			Name:         product.Name,
+			Slug:         product.Slug,
+			Description:  product.Description,
+			Price:        money.New(product.PriceKopeck, product.Currency),
+		},
+		Brand: DTO.FrontBrandResponse{
+			Id:   product.BrandID,
+			Name: product.BrandName,
+			Slug: product.BrandSlug,
+		},
+		Category: DTO.FrontCategoryResponse{
+			Id:       product.CategoryID,
+			Name:     product.CategoryName,
+			Slug:     product.CategorySlug,
+			ParentId: product.CategoryParentID,
+		},
+	}
+}
+
+func mapGetAncestorsRowToFrontResponse(category queries.GetCategoryAncestorsRow) DTO.FrontCategoryResponse {
+	return DTO.FrontCategoryResponse{
+		Id:       category.ID,
+		Name:     category.Name,
+		Slug:     category.Slug,
+		ParentId: category.ParentID,
+	}
+}
+
+func mapGetByCategorySubtreeRowToFrontResponse(product queries.GetFrontProductsByCategorySubtreeRow) DTO.FrontProductResponse {
+	return DTO.FrontProductResponse{
+		Id:           product.ID,
+		BrandId:      product.BrandID,
+		BrandName:    product.BrandName,
+		CategoryId:   product.CategoryID,
+		CategoryName: product.CategoryName,
+		Name:         product.Name,
+		Slug:         product.Slug,
+		Description:  product.Description,
+		Price:        money.New(product.PriceKopeck, product.Currency),
+	}
+}
+
+func mapSearchRowToResponse(product queries.SearchProductsRow) DTO.ProductResponse {
+	return DTO.ProductResponse{
+		Id:          product.ID,
+		BrandId:     product.BrandID,
+		CategoryId:  product.CategoryID,
+		Name:        product.Name,
+		Slug:        product.Slug,
+		Description: product.Description,
+		Price:       money.New(product.PriceKopeck, product.Currency),
+		IsActive:    product.IsActive,
+		CreatedAt:   product.CreatedAt,
+		UpdatedAt:   product.UpdatedAt,
+		Version:     product.Version,
+	}
+}
+
+func mapSearchRowToFrontResponse(product queries.SearchProductsRow) DTO.FrontProductResponse {
+	return DTO.FrontProductResponse{
+		Id:           product.ID,
+		BrandId:      product.BrandID,
+		BrandName:    product.BrandName,
+		CategoryId:   product.CategoryID,
+		CategoryName: product.CategoryName,
+		Name:         product.Name,
+		Slug:         product.Slug,
+		Description:  product.Description,
+		Price:        money.New(product.PriceKopeck, product.Currency),
+	}
+}
+
+func mapSuggestRowToResponse(product queries.SuggestProductsRow) DTO.ProductSuggestionResponse {
+	return DTO.ProductSuggestionResponse{
+		Id:   product.ID,
+		Name: product.Name,
+		Slug: product.Slug,
+	}
+}
+
+func mapTrashedRowToResponse(product queries.GetTrashedProductsRow) DTO.TrashedProductResponse {
+	return DTO.TrashedProductResponse{
+		ProductResponse: DTO.ProductResponse{
+			Id:          product.ID,
+			BrandId:     product.BrandID,
+			CategoryId:  product.CategoryID,
+			Name:        product.Name,
+			Slug:        product.Slug,
+			Description: product.Description,
+			Price:       money.New(product.PriceKopeck, product.Currency),
+			IsActive:    product.IsActive,
+			CreatedAt:   product.CreatedAt,
+			UpdatedAt:   product.UpdatedAt,
+		},
+		DeletedAt: product.DeletedAt,
+	}
+}
+
+func mapRestoreRowToResponse(product queries.RestoreProductRow) DTO.ProductResponse {
+	return DTO.ProductResponse{
+		Id:          product.ID,
+		BrandId:     product.BrandID,
+		CategoryId:  product.CategoryID,
+		Name:        product.Name,
+		Slug:        product.Slug,
+		Description: product.Description,
+		Price:       money.New(product.PriceKopeck, product.Currency),
 		IsActive:    product.IsActive,
 		CreatedAt:   product.CreatedAt,
 		UpdatedAt:   product.UpdatedAt,
+		Version:     product.Version,
+	}
+}
+
+func mapFrontListRequestToParams(request DTO.FrontProductListRequest, filters attributeFilters, page helpers.Page) queries.GetFrontFilteredProductsParams {
+	return queries.GetFrontFilteredProductsParams{
+		CategoryID:  request.CategoryId,
+		BrandIds:    nonNilIds(request.BrandIds),
+		FilterCodes: filters.codes,
+		FilterPairs: filters.pairs,
+		RangeCodes:  filters.rangeCodes,
+		RangeMins:   filters.rangeMins,
+		RangeMaxs:   filters.rangeMaxs,
+		CursorID:    page.CursorId,
+		SortDesc:    page.SortDesc,
+		PageLimit:   page.Limit + 1,
+	}
+}
+
+func mapFrontListRequestToCountParams(request DTO.FrontProductListRequest, filters attributeFilters) queries.CountFrontFilteredProductsParams {
+	return queries.CountFrontFilteredProductsParams{
+		CategoryID:  request.CategoryId,
+		BrandIds:    nonNilIds(request.BrandIds),
+		FilterCodes: filters.codes,
+		FilterPairs: filters.pairs,
+		RangeCodes:  filters.rangeCodes,
+		RangeMins:   filters.rangeMins,
+		RangeMaxs:   filters.rangeMaxs,
+	}
+}
+
+func mapFrontListRequestToBrandFacetsParams(request DTO.FrontProductListRequest, filters attributeFilters) queries.GetFrontBrandFacetsParams {
+	return queries.GetFrontBrandFacetsParams(mapFrontListRequestToCountParams(request, filters))
+}
+
+func mapFrontListRequestToAttributeFacetsParams(request DTO.FrontProductListRequest, filters attributeFilters) queries.GetFrontAttributeFacetsParams {
+	return queries.GetFrontAttributeFacetsParams{
+		CategoryID:  request.CategoryId,
+		BrandIds:    nonNilIds(request.BrandIds),
+		FilterCodes: filters.codes,
+		FilterPairs: filters.pairs,
+		RangeCodes:  filters.rangeCodes,
+		RangeMins:   filters.rangeMins,
+		RangeMaxs:   filters.rangeMaxs,
+	}
+}
+
+func mapBrandFacetToResponse(facet queries.GetFrontBrandFacetsRow) DTO.FrontBrandFacetResponse {
+	return DTO.FrontBrandFacetResponse{
+		Id:    facet.ID,
+		Name:  facet.Name,
+		Count: facet.Count,
+	}
+}
+
+// nonNilIds keeps an empty filter from being sent as NULL, which the queries read as "match nothing".
+func nonNilIds(ids []int64) []int64 {
+	if ids == nil {
+		return []int64{}
 	}
+	return ids
 }
@@ -4,66 +4,463 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/Aoladiy/go-with-tools/internal/DTO"
+	"github.com/Aoladiy/go-with-tools/internal/attribute"
 	"github.com/Aoladiy/go-with-tools/internal/database/queries"
 	"github.com/Aoladiy/go-with-tools/internal/errs"
+	"github.com/Aoladiy/go-with-tools/internal/export"
 	"github.com/Aoladiy/go-with-tools/internal/helpers"
+	"github.com/Aoladiy/go-with-tools/internal/media"
+	"github.com/Aoladiy/go-with-tools/internal/money"
+	"github.com/Aoladiy/go-with-tools/internal/pricelist"
+	"github.com/Aoladiy/go-with-tools/internal/promotion"
+	"github.com/Aoladiy/go-with-tools/internal/slug"
 
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
 type Service struct {
-	q *queries.Queries
-	p *pgxpool.Pool
+	q         *queries.Queries
+	p         *pgxpool.Pool
+	media     *media.Service
+	promotion *promotion.Service
+	pricelist *pricelist.Service
 }
 
-func New(q *queries.Queries, p *pgxpool.Pool) *Service {
-	return &Service{q: q, p: p}
+const defaultSuggestLimit = 10
+
+var (
+	sortFields = []string{"name", "price", "created_at", "updated_at"}
+	wordRegexp = regexp.MustCompile(`[\p{L}\p{N}]+`)
+)
+
+func New(q *queries.Queries, p *pgxpool.Pool, media *media.Service, promotion *promotion.Service, pricelist *pricelist.Service) *Service {
+	return &Service{q: q, p: p, media: media, promotion: promotion, pricelist: pricelist}
 }
 
+// Create generates the slug from the name when the request has none.
 func (s *Service) Create(ctx context.Context, request DTO.ProductRequest) (DTO.ProductResponse, *errs.AppError) {
-	_, err := s.q.GetBrand(ctx, request.BrandId)
+	appErr := s.validate(ctx, s.q, request)
+	if appErr != nil {
+		return DTO.ProductResponse{}, appErr
+	}
+
+	var product queries.CreateProductRow
+	appErr = helpers.WithTx(ctx, s.p, s.q, func(timeout context.Context, q *queries.Queries) *errs.AppError {
+		var appErr *errs.AppError
+		product, appErr = s.create(timeout, q, request)
+		return appErr
+	})
+	if appErr != nil {
+		return DTO.ProductResponse{}, appErr
+	}
+
+	response := mapCreateRowToResponse(product)
+	response.Media = []DTO.MediaResponse{}
+	return response, nil
+}
+
+// validate checks the request of Create, Update and Patch against the brands and categories.
+func (s *Service) validate(ctx context.Context, q *queries.Queries, request DTO.ProductRequest) *errs.AppError {
+	err := request.Price.Validate()
+	if err != nil {
+		return errs.BadRequest(fmt.Errorf("price: %w", err))
+	}
+	_, err = q.GetBrand(ctx, request.BrandId)
 	if err != nil {
-		return DTO.ProductResponse{}, errs.NotFound(fmt.Errorf("brand with id=%d not found | %w", request.BrandId, err))
+		return errs.NotFound(fmt.Errorf("brand with id=%d not found | %w", request.BrandId, err))
 	}
-	_, err = s.q.GetCategory(ctx, request.CategoryId)
+	_, err = q.GetCategory(ctx, request.CategoryId)
 	if err != nil {
-		return DTO.ProductResponse{}, errs.NotFound(fmt.Errorf("category with id=%d not found | %w", request.CategoryId, err))
+		return errs.NotFound(fmt.Errorf("category with id=%d not found | %w", request.CategoryId, err))
+	}
+	return nil
+}
+
+// create inserts the validated product and records its initial price.
+func (s *Service) create(timeout context.Context, q *queries.Queries, request DTO.ProductRequest) (queries.CreateProductRow, *errs.AppError) {
+	var appErr *errs.AppError
+	request.Slug, appErr = slug.Assign(timeout, q, slug.Products, 0, request.Slug, request.Name)
+	if appErr != nil {
+		return queries.CreateProductRow{}, appErr
 	}
 
-	var product queries.CreateProductRow
-	appErr := helpers.WithTx(ctx, s.p, s.q, func(timeout context.Context, q *queries.Queries) *errs.AppError {
-		var err error
-		product, err = q.CreateProduct(timeout, mapRequestToCreateParams(request))
+	product, err := q.CreateProduct(timeout, mapRequestToCreateParams(request))
+	if err != nil {
+		return queries.CreateProductRow{}, errs.FromPgErr(err)
+	}
+
+	appErr = s.createPriceHistory(timeout, q, product.ID, money.New(0, product.Currency), money.New(product.PriceKopeck, product.Currency))
+	if appErr != nil {
+		return queries.CreateProductRow{}, appErr
+	}
+	return product, nil
+}
+
+func (s *Service) GetAll(ctx context.Context, request DTO.ProductListRequest) (DTO.ProductPageResponse, *errs.AppError) {
+	page, appErr := helpers.ParsePage(request.PageRequest, sortFields...)
+	if appErr != nil {
+		return DTO.ProductPageResponse{}, appErr
+	}
+
+	products, err := s.q.GetAllProducts(ctx, mapListRequestToGetAllParams(request, page))
+	if err != nil {
+		return DTO.ProductPageResponse{}, errs.Internal(err)
+	}
+	total, err := s.q.CountProducts(ctx, mapListRequestToCountParams(request))
+	if err != nil {
+		return DTO.ProductPageResponse{}, errs.Internal(err)
+	}
+
+	productsResponse := make([]DTO.ProductResponse, len(products))
+	for i, product := range products {
+		productsResponse[i] = mapGetAllRowToResponse(product)
+	}
+	items, pageInfo := helpers.Paginate(productsResponse, total, page, cursorOf(page.SortBy))
+	appErr = s.withMedia(ctx, helpers.Pointers(items)...)
+	if appErr != nil {
+		return DTO.ProductPageResponse{}, appErr
+	}
+	return DTO.ProductPageResponse{Items: items, PageInfo: pageInfo}, nil
+}
+
+// Export writes the products GetAll would return, with the same filters and sort.
+func (s *Service) Export(ctx context.Context, request DTO.ProductListRequest, w export.Writer) *errs.AppError {
+	page, appErr := helpers.ParsePage(request.PageRequest, sortFields...)
+	if appErr != nil {
+		return appErr
+	}
+
+	err := w.WriteHeader("id", "slug", "name", "brand_id", "category_id", "description", "price", "currency", "is_active", "created_at", "updated_at")
+	if err != nil {
+		return errs.Internal(err)
+	}
+	return helpers.WithSnapshot(ctx, s.p, s.q, func(timeout context.Context, q *queries.Queries) *errs.AppError {
+		return helpers.Walk(page, exportPage(timeout, q, request), cursorOf(page.SortBy), func(product DTO.ProductResponse) error {
+			return w.WriteRow(product.Id, product.Slug, product.Name, product.BrandId, product.CategoryId, product.Description,
+				export.Decimal(product.Price.Decimal()), product.Price.Currency, product.IsActive, product.CreatedAt, product.UpdatedAt)
+		})
+	})
+}
+
+// ExportStock writes the stock of the products GetAll would return, with the same filters and sort.
+func (s *Service) ExportStock(ctx context.Context, request DTO.ProductListRequest, w export.Writer) *errs.AppError {
+	page, appErr := helpers.ParsePage(request.PageRequest, sortFields...)
+	if appErr != nil {
+		return appErr
+	}
+
+	err := w.WriteHeader("product_id", "slug", "name", "quantity")
+	if err != nil {
+		return errs.Internal(err)
+	}
+	var stocks map[int64]int64
+	return helpers.WithSnapshot(ctx, s.p, s.q, func(timeout context.Context, q *queries.Queries) *errs.AppError {
+		fetch := exportPage(timeout, q, request)
+		return helpers.Walk(page, func(page helpers.Page) ([]DTO.ProductResponse, *errs.AppError) {
+			products, appErr := fetch(page)
+			if appErr != nil {
+				return nil, appErr
+			}
+			ids := make([]int64, len(products))
+			for i, product := range products {
+				ids[i] = product.Id
+			}
+			rows, err := q.GetProductStocks(timeout, ids)
+			if err != nil {
+				return nil, errs.Internal(err)
+			}
+			stocks = make(map[int64]int64, len(rows))
+			for _, row := range rows {
+				stocks[row.ProductID] = row.Quantity
+			}
+			return products, nil
+		}, cursorOf(page.SortBy), func(product DTO.ProductResponse) error {
+			return w.WriteRow(product.Id, product.Slug, product.Name, stocks[product.Id])
+		})
+	})
+}
+
+func exportPage(ctx context.Context, q *queries.Queries, request DTO.ProductListRequest) func(page helpers.Page) ([]DTO.ProductResponse, *errs.AppError) {
+	return func(page helpers.Page) ([]DTO.ProductResponse, *errs.AppError) {
+		products, err := q.GetAllProducts(ctx, mapListRequestToGetAllParams(request, page))
 		if err != nil {
-			return errs.FromPgErr(err)
+			return nil, errs.Internal(err)
 		}
+		productsResponse := make([]DTO.ProductResponse, len(products))
+		for i, product := range products {
+			productsResponse[i] = mapGetAllRowToResponse(product)
+		}
+		return productsResponse, nil
+	}
+}
 
-		appErr := s.createPriceHistory(timeout, q, product.ID, 0, product.PriceKopeck)
-		if appErr != nil {
-			return appErr
+// GetAllFront returns a page of active products matching the filters together with facet
+// counts over the whole filtered set, so the storefront can render brand and attribute filters.
+func (s *Service) GetAllFront(ctx context.Context, request DTO.FrontProductListRequest) (DTO.FrontProductFacetedPageResponse, *errs.AppError) {
+	page, appErr := helpers.ParsePage(request.PageRequest)
+	if appErr != nil {
+		return DTO.FrontProductFacetedPageResponse{}, appErr
+	}
+	filters, appErr := parseAttributeFilters(request.Attributes)
+	if appErr != nil {
+		return DTO.FrontProductFacetedPageResponse{}, appErr
+	}
+
+	products, err := s.q.GetFrontFilteredProducts(ctx, mapFrontListRequestToParams(request, filters, page))
+	if err != nil {
+		return DTO.FrontProductFacetedPageResponse{}, errs.Internal(err)
+	}
+	total, err := s.q.CountFrontFilteredProducts(ctx, mapFrontListRequestToCountParams(request, filters))
+	if err != nil {
+		return DTO.FrontProductFacetedPageResponse{}, errs.Internal(err)
+	}
+	facets, appErr := s.getFacets(ctx, request, filters)
+	if appErr != nil {
+		return DTO.FrontProductFacetedPageResponse{}, appErr
+	}
+
+	productsResponse := make([]DTO.FrontProductResponse, len(products))
+	for i, product := range products {
+		productsResponse[i] = mapGetFrontFilteredRowToFrontResponse(product)
+	}
+	items, pageInfo := helpers.Paginate(productsResponse, total, page, func(product DTO.FrontProductResponse) (string, int64) {
+		return "", product.Id
+	})
+	appErr = s.withEffectivePrices(ctx, request.PriceListQuery, items)
+	if appErr != nil {
+		return DTO.FrontProductFacetedPageResponse{}, appErr
+	}
+	return DTO.FrontProductFacetedPageResponse{Items: items, Facets: facets, PageInfo: pageInfo}, nil
+}
+
+// GetFrontBySlug returns an active product by its current slug with its media, brand, category and the
+// breadcrumbs of the category, priced like the storefront lists.
+func (s *Service) GetFrontBySlug(ctx context.Context, slug string, query DTO.PriceListQuery) (DTO.FrontProductDetailResponse, *errs.AppError) {
+	product, err := s.q.GetFrontProductBySlug(ctx, slug)
+	if err != nil {
+		appErr := errs.FromPgErr(err)
+		if appErr.Code == errs.NotFoundErrCode {
+			return DTO.FrontProductDetailResponse{}, errs.NotFound(fmt.Errorf("product with slug %q not found | %w", slug, err))
 		}
-		return nil
+		return DTO.FrontProductDetailResponse{}, appErr
+	}
+	response := mapGetFrontBySlugRowToDetailResponse(product)
+
+	ancestors, err := s.q.GetCategoryAncestors(ctx, product.CategoryID)
+	if err != nil {
+		return DTO.FrontProductDetailResponse{}, errs.Internal(err)
+	}
+	response.Breadcrumbs = make([]DTO.FrontCategoryResponse, len(ancestors))
+	for i, category := range ancestors {
+		response.Breadcrumbs[i] = mapGetAncestorsRowToFrontResponse(category)
+	}
+
+	media, appErr := s.media.ForProducts(ctx, []int64{product.ID})
+	if appErr != nil {
+		return DTO.FrontProductDetailResponse{}, appErr
+	}
+	response.Media = media[0]
+
+	priced := []DTO.FrontProductResponse{response.FrontProductResponse}
+	appErr = s.withEffectivePrices(ctx, query, priced)
+	if appErr != nil {
+		return DTO.FrontProductDetailResponse{}, appErr
+	}
+	response.FrontProductResponse = priced[0]
+	return response, nil
+}
+
+// getFacets counts brands ignoring the brand filter itself, so the storefront can still offer
+// the other brands of a multi-select, and the values of each attribute ignoring the filter of that
+// attribute the same way.
+func (s *Service) getFacets(ctx context.Context, request DTO.FrontProductListRequest, filters attributeFilters) (DTO.FrontFacetsResponse, *errs.AppError) {
+	brandsRequest := request
+	brandsRequest.BrandIds = nil
+	brands, err := s.q.GetFrontBrandFacets(ctx, mapFrontListRequestToBrandFacetsParams(brandsRequest, filters))
+	if err != nil {
+		return DTO.FrontFacetsResponse{}, errs.Internal(err)
+	}
+	values, err := s.q.GetFrontAttributeFacets(ctx, mapFrontListRequestToAttributeFacetsParams(request, filters))
+	if err != nil {
+		return DTO.FrontFacetsResponse{}, errs.Internal(err)
+	}
+
+	facets := DTO.FrontFacetsResponse{
+		Brands:     make([]DTO.FrontBrandFacetResponse, len(brands)),
+		Attributes: []DTO.FrontAttributeFacetResponse{},
+	}
+	for i, brand := range brands {
+		facets.Brands[i] = mapBrandFacetToResponse(brand)
+	}
+	// values come ordered by attribute, so each attribute is a contiguous run of rows
+	for _, value := range values {
+		last := len(facets.Attributes) - 1
+		if last < 0 || facets.Attributes[last].Id != value.ID {
+			facets.Attributes = append(facets.Attributes, DTO.FrontAttributeFacetResponse{
+				Id:     value.ID,
+				Code:   value.Code,
+				Name:   value.Name,
+				Type:   value.Type,
+				Unit:   value.Unit,
+				Values: []DTO.FrontFacetValueResponse{},
+			})
+			last++
+		}
+		facet := &facets.Attributes[last]
+		facet.Values = append(facet.Values, DTO.FrontFacetValueResponse{Value: value.Value, Count: value.Count})
+		if value.Type == attribute.TypeNumber {
+			number, err := strconv.ParseFloat(value.Value, 64)
+			if err != nil {
+				continue
+			}
+			if facet.Min == nil || number < *facet.Min {
+				facet.Min = &number
+			}
+			if facet.Max == nil || number > *facet.Max {
+				facet.Max = &number
+			}
+		}
+	}
+	return facets, nil
+}
+
+func (s *Service) GetAllFrontByCategory(ctx context.Context, categoryId int64, request DTO.FrontCategoryProductListRequest) (DTO.FrontProductPageResponse, *errs.AppError) {
+	page, appErr := helpers.ParsePage(request.PageRequest)
+	if appErr != nil {
+		return DTO.FrontProductPageResponse{}, appErr
+	}
+
+	_, err := s.q.GetCategory(ctx, categoryId)
+	if err != nil {
+		return DTO.FrontProductPageResponse{}, errs.FromPgErr(err)
+	}
+
+	products, err := s.q.GetFrontProductsByCategorySubtree(ctx, queries.GetFrontProductsByCategorySubtreeParams{
+		CategoryID: categoryId,
+		CursorID:   page.CursorId,
+		SortDesc:   page.SortDesc,
+		PageLimit:  page.Limit + 1,
+	})
+	if err != nil {
+		return DTO.FrontProductPageResponse{}, errs.Internal(err)
+	}
+	total, err := s.q.CountFrontProductsByCategorySubtree(ctx, categoryId)
+	if err != nil {
+		return DTO.FrontProductPageResponse{}, errs.Internal(err)
+	}
+
+	productsResponse := make([]DTO.FrontProductResponse, len(products))
+	for i, product := range products {
+		productsResponse[i] = mapGetByCategorySubtreeRowToFrontResponse(product)
+	}
+	items, pageInfo := helpers.Paginate(productsResponse, total, page, func(product DTO.FrontProductResponse) (string, int64) {
+		return "", product.Id
 	})
+	appErr = s.withEffectivePrices(ctx, request.PriceListQuery, items)
 	if appErr != nil {
-		return DTO.ProductResponse{}, appErr
+		return DTO.FrontProductPageResponse{}, appErr
+	}
+	return DTO.FrontProductPageResponse{Items: items, PageInfo: pageInfo}, nil
+}
+
+func (s *Service) Search(ctx context.Context, request DTO.ProductSearchRequest) (DTO.ProductPageResponse, *errs.AppError) {
+	products, pageInfo, appErr := s.search(ctx, request)
+	if appErr != nil {
+		return DTO.ProductPageResponse{}, appErr
+	}
+
+	productsResponse := make([]DTO.ProductResponse, len(products))
+	for i, product := range products {
+		productsResponse[i] = mapSearchRowToResponse(product)
+	}
+	appErr = s.withMedia(ctx, helpers.Pointers(productsResponse)...)
+	if appErr != nil {
+		return DTO.ProductPageResponse{}, appErr
+	}
+	return DTO.ProductPageResponse{Items: productsResponse, PageInfo: pageInfo}, nil
+}
+
+func (s *Service) SearchFront(ctx context.Context, request DTO.FrontProductSearchRequest) (DTO.FrontProductPageResponse, *errs.AppError) {
+	isActive := true
+	request.IsActive = &isActive
+	products, pageInfo, appErr := s.search(ctx, request.ProductSearchRequest)
+	if appErr != nil {
+		return DTO.FrontProductPageResponse{}, appErr
 	}
 
-	return mapCreateRowToResponse(product), nil
+	productsResponse := make([]DTO.FrontProductResponse, len(products))
+	for i, product := range products {
+		productsResponse[i] = mapSearchRowToFrontResponse(product)
+	}
+	appErr = s.withEffectivePrices(ctx, request.PriceListQuery, productsResponse)
+	if appErr != nil {
+		return DTO.FrontProductPageResponse{}, appErr
+	}
+	return DTO.FrontProductPageResponse{Items: productsResponse, PageInfo: pageInfo}, nil
 }
 
-func (s *Service) GetAll(ctx context.Context) ([]DTO.ProductResponse, *errs.AppError) {
-	products, err := s.q.GetAllProducts(ctx)
+func (s *Service) Suggest(ctx context.Context, request DTO.ProductSuggestRequest) ([]DTO.ProductSuggestionResponse, *errs.AppError) {
+	if request.Limit == 0 {
+		request.Limit = defaultSuggestLimit
+	}
+	if request.Limit < 0 || request.Limit > helpers.MaxPageLimit {
+		return nil, errs.BadRequest(fmt.Errorf("limit must be between 1 and %d", helpers.MaxPageLimit))
+	}
+	prefixQuery := prefixTsQuery(request.Query)
+	if prefixQuery == "" {
+		return nil, errs.BadRequest(errors.New("q must contain at least one letter or digit"))
+	}
+
+	products, err := s.q.SuggestProducts(ctx, queries.SuggestProductsParams{
+		PrefixQuery: prefixQuery,
+		PageLimit:   request.Limit,
+	})
 	if err != nil {
 		return nil, errs.Internal(err)
 	}
 
-	productsResponse := make([]DTO.ProductResponse, len(products))
+	suggestionsResponse := make([]DTO.ProductSuggestionResponse, len(products))
 	for i, product := range products {
-		productsResponse[i] = mapGetAllRowToResponse(product)
+		suggestionsResponse[i] = mapSuggestRowToResponse(product)
 	}
-	return productsResponse, nil
+	return suggestionsResponse, nil
+}
+
+func (s *Service) search(ctx context.Context, request DTO.ProductSearchRequest) ([]queries.SearchProductsRow, DTO.PageInfo, *errs.AppError) {
+	if strings.TrimSpace(request.Query) == "" {
+		return nil, DTO.PageInfo{}, errs.BadRequest(errors.New("q must not be empty"))
+	}
+	page, appErr := helpers.ParseOffsetPage(request.PageRequest)
+	if appErr != nil {
+		return nil, DTO.PageInfo{}, appErr
+	}
+
+	products, err := s.q.SearchProducts(ctx, queries.SearchProductsParams{
+		IsActive:   request.IsActive,
+		Query:      request.Query,
+		PageOffset: page.Offset,
+		PageLimit:  page.Limit + 1,
+	})
+	if err != nil {
+		return nil, DTO.PageInfo{}, errs.Internal(err)
+	}
+	total, err := s.q.CountSearchProducts(ctx, queries.CountSearchProductsParams{
+		IsActive: request.IsActive,
+		Query:    request.Query,
+	})
+	if err != nil {
+		return nil, DTO.PageInfo{}, errs.Internal(err)
+	}
+
+	products, pageInfo := helpers.PaginateOffset(products, total, page)
+	return products, pageInfo, nil
 }
 
 func (s *Service) Get(ctx context.Context, id int64) (DTO.ProductResponse, *errs.AppError) {
@@ -72,80 +469,525 @@ func (s *Service) Get(ctx context.Context, id int64) (DTO.ProductResponse, *errs
 		return DTO.ProductResponse{}, errs.FromPgErr(err)
 	}
 
-	return mapGetRowToResponse(product), nil
+	response := mapGetRowToResponse(product)
+	appErr := s.withMedia(ctx, &response)
+	if appErr != nil {
+		return DTO.ProductResponse{}, appErr
+	}
+	return response, nil
+}
+
+// Update can't change the currency of the product, the prices of its variants and the history are in it.
+// The current slug is kept when the request has none, a replaced slug goes to the history.
+func (s *Service) Update(ctx context.Context, id int64, version *int64, request DTO.ProductRequest) (DTO.ProductResponse, *errs.AppError) {
+	appErr := s.validate(ctx, s.q, request)
+	if appErr != nil {
+		return DTO.ProductResponse{}, appErr
+	}
+
+	var product queries.UpdateProductRow
+	appErr = helpers.WithTx(ctx, s.p, s.q, func(timeout context.Context, q *queries.Queries) *errs.AppError {
+		appErr := lockVersion(timeout, q, id, version)
+		if appErr != nil {
+			return appErr
+		}
+		product, appErr = s.update(timeout, q, id, request)
+		return appErr
+	})
+	if appErr != nil {
+		return DTO.ProductResponse{}, appErr
+	}
+
+	response := mapUpdateRowToResponse(product)
+	appErr = s.withMedia(ctx, &response)
+	if appErr != nil {
+		return DTO.ProductResponse{}, appErr
+	}
+	return response, nil
 }
 
-func (s *Service) Update(ctx context.Context, id int64, request DTO.ProductRequest) (DTO.ProductResponse, *errs.AppError) {
-	_, err := s.q.GetBrand(ctx, request.BrandId)
+// update replaces the product with the validated request and records the price change, if any.
+func (s *Service) update(timeout context.Context, q *queries.Queries, id int64, request DTO.ProductRequest) (queries.UpdateProductRow, *errs.AppError) {
+	oldProduct, err := q.GetProduct(timeout, id)
 	if err != nil {
-		return DTO.ProductResponse{}, errs.NotFound(fmt.Errorf("brand with id=%d not found | %w", request.BrandId, err))
+		return queries.UpdateProductRow{}, errs.FromPgErr(err)
+	}
+	if request.Slug == "" {
+		request.Slug = oldProduct.Slug
 	}
-	_, err = s.q.GetCategory(ctx, request.CategoryId)
+	request, appErr := prepareUpdate(timeout, q, oldProduct, request)
+	if appErr != nil {
+		return queries.UpdateProductRow{}, appErr
+	}
+
+	product, err := q.UpdateProduct(timeout, mapRequestToUpdateParams(id, request))
 	if err != nil {
-		return DTO.ProductResponse{}, errs.NotFound(fmt.Errorf("category with id=%d not found | %w", request.CategoryId, err))
+		return queries.UpdateProductRow{}, errs.FromPgErr(err)
 	}
+	appErr = s.recordUpdate(timeout, q, oldProduct, product.Slug, product.CategoryID, money.New(product.PriceKopeck, product.Currency))
+	if appErr != nil {
+		return queries.UpdateProductRow{}, appErr
+	}
+	return product, nil
+}
 
-	var product queries.UpdateProductRow
+// Patch applies a merge patch to the product, only the members present in the patch are written.
+// The patched product is checked like on Update and a price change goes to the history the same way.
+func (s *Service) Patch(ctx context.Context, id int64, version *int64, patch DTO.ProductPatchRequest) (DTO.ProductResponse, *errs.AppError) {
+	var product queries.PatchProductRow
 	appErr := helpers.WithTx(ctx, s.p, s.q, func(timeout context.Context, q *queries.Queries) *errs.AppError {
-		var err error
+		appErr := lockVersion(timeout, q, id, version)
+		if appErr != nil {
+			return appErr
+		}
 		oldProduct, err := q.GetProduct(timeout, id)
 		if err != nil {
 			return errs.FromPgErr(err)
 		}
 
-		product, err = q.UpdateProduct(timeout, mapRequestToUpdateParams(id, request))
-		if err != nil {
-			return errs.FromPgErr(err)
+		request, appErr := applyPatch(oldProduct, patch)
+		if appErr != nil {
+			return appErr
 		}
-
-		if oldProduct.PriceKopeck != product.PriceKopeck {
-			appErr := s.createPriceHistory(timeout, q, product.ID, oldProduct.PriceKopeck, product.PriceKopeck)
-			if appErr != nil {
-				return appErr
-			}
+		appErr = s.validate(timeout, q, request)
+		if appErr != nil {
+			return appErr
+		}
+		request, appErr = prepareUpdate(timeout, q, oldProduct, request)
+		if appErr != nil {
+			return appErr
 		}
 
-		return nil
+		product, err = q.PatchProduct(timeout, mapPatchToParams(id, patch, request))
+		if err != nil {
+			return errs.FromPgErr(err)
+		}
+		return s.recordUpdate(timeout, q, oldProduct, product.Slug, product.CategoryID, money.New(product.PriceKopeck, product.Currency))
 	})
 	if appErr != nil {
 		return DTO.ProductResponse{}, appErr
 	}
 
-	return mapUpdateRowToResponse(product), nil
+	response := mapPatchRowToResponse(product)
+	appErr = s.withMedia(ctx, &response)
+	if appErr != nil {
+		return DTO.ProductResponse{}, appErr
+	}
+	return response, nil
 }
 
-func (s *Service) Delete(ctx context.Context, id int64) (int, *errs.AppError) {
-	rows, err := s.q.DeleteProduct(ctx, id)
-	if err != nil {
-		return 0, errs.Internal(err)
+// prepareUpdate checks the validated request of Update and Patch against the product and assigns the slug
+// to write. The currency can't change, the prices of the variants and the history are in it.
+func prepareUpdate(timeout context.Context, q *queries.Queries, oldProduct queries.GetProductRow, request DTO.ProductRequest) (DTO.ProductRequest, *errs.AppError) {
+	if oldProduct.Currency != request.Price.Currency {
+		return DTO.ProductRequest{}, errs.UnprocessableEntity(fmt.Errorf("product with id=%d is priced in %s, its currency can't be changed to %s", oldProduct.ID, oldProduct.Currency, request.Price.Currency))
 	}
-	if rows == 0 {
-		return int(rows), errs.NotFound(errors.New("product not found"))
+	var appErr *errs.AppError
+	request.Slug, appErr = slug.Assign(timeout, q, slug.Products, oldProduct.ID, request.Slug, request.Name)
+	if appErr != nil {
+		return DTO.ProductRequest{}, appErr
+	}
+	return request, nil
+}
+
+// recordUpdate follows up the write of Update and Patch: the replaced slug goes to the history, the
+// attribute values the new category doesn't define are dropped and a price change is recorded.
+func (s *Service) recordUpdate(timeout context.Context, q *queries.Queries, oldProduct queries.GetProductRow, newSlug string, categoryId int64, price money.Money) *errs.AppError {
+	appErr := slug.Record(timeout, q, slug.Products, oldProduct.ID, oldProduct.Slug, newSlug)
+	if appErr != nil {
+		return appErr
+	}
+
+	if oldProduct.CategoryID != categoryId {
+		// attributes of the old category don't describe the product anymore
+		_, err := q.DeleteInapplicableProductAttributeValues(timeout, oldProduct.ID)
+		if err != nil {
+			return errs.Internal(err)
+		}
+	}
+
+	if oldProduct.PriceKopeck != price.Amount {
+		return s.createPriceHistory(timeout, q, oldProduct.ID, money.New(oldProduct.PriceKopeck, oldProduct.Currency), price)
+	}
+	return nil
+}
+
+// applyPatch returns the product with the merge patch applied as a full request. The members that
+// can't be removed are rejected when null, a null slug is left empty to be generated again.
+func applyPatch(product queries.GetProductRow, patch DTO.ProductPatchRequest) (DTO.ProductRequest, *errs.AppError) {
+	request := DTO.ProductRequest{
+		BrandId:     product.BrandID,
+		CategoryId:  product.CategoryID,
+		Name:        product.Name,
+		Slug:        product.Slug,
+		Description: &product.Description,
+		Price:       money.New(product.PriceKopeck, product.Currency),
+		IsActive:    &product.IsActive,
+	}
+
+	required := []struct {
+		member string
+		null   bool
+	}{
+		{"brand_id", patch.BrandId.Null},
+		{"category_id", patch.CategoryId.Null},
+		{"name", patch.Name.Null},
+		{"price", patch.Price.Null},
+		{"price.amount", patch.Price.Value.Amount.Null},
+		{"price.currency", patch.Price.Value.Currency.Null},
+	}
+	for _, r := range required {
+		if r.null {
+			return DTO.ProductRequest{}, errs.BadRequest(fmt.Errorf("%s can't be removed", r.member))
+		}
+	}
+
+	if patch.BrandId.Set {
+		request.BrandId = patch.BrandId.Value
+	}
+	if patch.CategoryId.Set {
+		request.CategoryId = patch.CategoryId.Value
+	}
+	if patch.Name.Set {
+		request.Name = patch.Name.Value
+	}
+	if patch.Slug.Set {
+		request.Slug = patch.Slug.Value
+	}
+	if patch.Description.Set {
+		description := patch.Description.Value
+		request.Description = &description
+	}
+	if patch.Price.Value.Amount.Set {
+		request.Price.Amount = patch.Price.Value.Amount.Value
+	}
+	if patch.Price.Value.Currency.Set {
+		request.Price.Currency = strings.ToUpper(strings.TrimSpace(patch.Price.Value.Currency.Value))
+	}
+	if patch.IsActive.Set {
+		isActive := patch.IsActive.Null || patch.IsActive.Value
+		request.IsActive = &isActive
+	}
+	return request, nil
+}
+
+func (s *Service) Delete(ctx context.Context, id int64, version *int64) (int, *errs.AppError) {
+	var rows int64
+	appErr := helpers.WithTx(ctx, s.p, s.q, func(timeout context.Context, q *queries.Queries) *errs.AppError {
+		appErr := lockVersion(timeout, q, id, version)
+		if appErr != nil {
+			return appErr
+		}
+		var err error
+		rows, err = q.DeleteProduct(timeout, queries.DeleteProductParams{ID: id, DeletionID: helpers.NewDeletionId()})
+		if err != nil {
+			return errs.Internal(err)
+		}
+		if rows == 0 {
+			return errs.NotFound(errors.New("product not found"))
+		}
+		return nil
+	})
+	if appErr != nil {
+		return 0, appErr
 	}
 	return int(rows), nil
 }
 
-func (s *Service) GetPriceHistory(ctx context.Context, id int64) ([]queries.ProductPriceHistory, *errs.AppError) {
-	byProductId, err := s.q.GetProductPriceHistoryByProductId(ctx, id)
+// lockVersion locks the product till the end of the transaction and checks it still has the expected version.
+func lockVersion(ctx context.Context, q *queries.Queries, id int64, version *int64) *errs.AppError {
+	current, err := q.LockProduct(ctx, id)
 	if err != nil {
-		return nil, errs.Internal(err)
+		appErr := errs.FromPgErr(err)
+		if appErr.Code == errs.NotFoundErrCode {
+			return errs.NotFound(fmt.Errorf("product with id=%d not found | %w", id, err))
+		}
+		return appErr
 	}
-	return byProductId, nil
+	return helpers.CheckVersion(version, current)
 }
 
-func (s *Service) createPriceHistory(timeout context.Context, q *queries.Queries, productId int64, oldPrice, newPrice int32) *errs.AppError {
+// RollbackPrice sets the price of the product back to the one it got with the history entry.
+// The rollback is recorded as a new entry, so the history itself is never rewritten.
+func (s *Service) RollbackPrice(ctx context.Context, id, historyId int64) (DTO.ProductResponse, *errs.AppError) {
+	var product queries.UpdateProductPriceRow
+	appErr := helpers.WithTx(ctx, s.p, s.q, func(timeout context.Context, q *queries.Queries) *errs.AppError {
+		_, err := q.LockProduct(timeout, id)
+		if err != nil {
+			return errs.FromPgErr(err)
+		}
+		oldProduct, err := q.GetProduct(timeout, id)
+		if err != nil {
+			return errs.FromPgErr(err)
+		}
+		entry, err := q.GetPriceHistoryEntry(timeout, queries.GetPriceHistoryEntryParams{ID: historyId, ProductID: id})
+		if err != nil {
+			appErr := errs.FromPgErr(err)
+			if appErr.Code == errs.NotFoundErrCode {
+				return errs.NotFound(fmt.Errorf("price history entry with id=%d not found | %w", historyId, err))
+			}
+			return appErr
+		}
+		if entry.VariantID != nil {
+			return errs.UnprocessableEntity(fmt.Errorf("price history entry with id=%d belongs to variant with id=%d, only product prices can be rolled back", historyId, *entry.VariantID))
+		}
+		if entry.NewPriceKopeck == oldProduct.PriceKopeck {
+			return errs.UnprocessableEntity(fmt.Errorf("product price is already %s", money.New(oldProduct.PriceKopeck, oldProduct.Currency)))
+		}
+
+		product, err = q.UpdateProductPrice(timeout, queries.UpdateProductPriceParams{ID: id, PriceKopeck: entry.NewPriceKopeck})
+		if err != nil {
+			return errs.FromPgErr(err)
+		}
+		return s.createPriceHistory(timeout, q, id, money.New(oldProduct.PriceKopeck, oldProduct.Currency), money.New(product.PriceKopeck, product.Currency))
+	})
+	if appErr != nil {
+		return DTO.ProductResponse{}, appErr
+	}
+
+	response := mapUpdatePriceRowToResponse(product)
+	appErr = s.withMedia(ctx, &response)
+	if appErr != nil {
+		return DTO.ProductResponse{}, appErr
+	}
+	return response, nil
+}
+
+// createPriceHistory records the change of the product price, and of the price of every variant that
+// inherits it, as the variants without a price of their own are sold at the new price too.
+func (s *Service) createPriceHistory(timeout context.Context, q *queries.Queries, productId int64, oldPrice, newPrice money.Money) *errs.AppError {
 	id, err := helpers.SafeGetUserID(timeout)
 	if err != nil {
 		return errs.Internal(err)
 	}
 	_, err = q.CreateProductPriceHistory(timeout, queries.CreateProductPriceHistoryParams{
 		ProductID:      productId,
-		OldPriceKopeck: oldPrice,
-		NewPriceKopeck: newPrice,
+		OldPriceKopeck: oldPrice.Amount,
+		NewPriceKopeck: newPrice.Amount,
+		Currency:       newPrice.Currency,
 		UpdatedBy:      id,
 	})
 	if err != nil {
 		return errs.Internal(err)
 	}
+	_, err = q.CreateInheritedVariantPriceHistory(timeout, queries.CreateInheritedVariantPriceHistoryParams{
+		OldPriceKopeck: oldPrice.Amount,
+		NewPriceKopeck: newPrice.Amount,
+		Currency:       newPrice.Currency,
+		UpdatedBy:      id,
+		ProductID:      productId,
+	})
+	if err != nil {
+		return errs.Internal(err)
+	}
+	return nil
+}
+
+func (s *Service) GetTrash(ctx context.Context, request DTO.PageRequest) (DTO.TrashedProductPageResponse, *errs.AppError) {
+	page, appErr := helpers.ParsePage(request, helpers.SortByDeletedAt)
+	if appErr != nil {
+		return DTO.TrashedProductPageResponse{}, appErr
+	}
+
+	products, err := s.q.GetTrashedProducts(ctx, queries.GetTrashedProductsParams{
+		CursorID:    page.CursorId,
+		CursorValue: page.CursorValue,
+		SortBy:      page.SortBy,
+		SortDesc:    page.SortDesc,
+		PageLimit:   page.Limit + 1,
+	})
+	if err != nil {
+		return DTO.TrashedProductPageResponse{}, errs.Internal(err)
+	}
+	total, err := s.q.CountTrashedProducts(ctx)
+	if err != nil {
+		return DTO.TrashedProductPageResponse{}, errs.Internal(err)
+	}
+
+	productsResponse := make([]DTO.TrashedProductResponse, len(products))
+	for i, product := range products {
+		productsResponse[i] = mapTrashedRowToResponse(product)
+	}
+	items, pageInfo := helpers.Paginate(productsResponse, total, page, func(product DTO.TrashedProductResponse) (string, int64) {
+		if page.SortBy == helpers.SortByDeletedAt {
+			return product.DeletedAt.Format(time.RFC3339Nano), product.Id
+		}
+		return "", product.Id
+	})
+	trashed := make([]*DTO.ProductResponse, len(items))
+	for i := range items {
+		trashed[i] = &items[i].ProductResponse
+	}
+	appErr = s.withMedia(ctx, trashed...)
+	if appErr != nil {
+		return DTO.TrashedProductPageResponse{}, appErr
+	}
+	return DTO.TrashedProductPageResponse{Items: items, PageInfo: pageInfo}, nil
+}
+
+// Restore brings a single soft-deleted product back. Its brand and category must not be deleted.
+func (s *Service) Restore(ctx context.Context, id int64) (DTO.ProductResponse, *errs.AppError) {
+	var product queries.RestoreProductRow
+	appErr := helpers.WithTx(ctx, s.p, s.q, func(timeout context.Context, q *queries.Queries) *errs.AppError {
+		trashed, err := q.LockTrashedProduct(timeout, id)
+		if err != nil {
+			return errs.FromPgErr(err)
+		}
+		if trashed.BrandDeleted {
+			return errs.UnprocessableEntity(fmt.Errorf("brand of product with id=%d is deleted, restore it first", id))
+		}
+		if trashed.CategoryDeleted {
+			return errs.UnprocessableEntity(fmt.Errorf("category of product with id=%d is deleted, restore it first", id))
+		}
+
+		product, err = q.RestoreProduct(timeout, id)
+		if err != nil {
+			return errs.FromPgErr(err)
+		}
+		return nil
+	})
+	if appErr != nil {
+		return DTO.ProductResponse{}, appErr
+	}
+
+	response := mapRestoreRowToResponse(product)
+	appErr = s.withMedia(ctx, &response)
+	if appErr != nil {
+		return DTO.ProductResponse{}, appErr
+	}
+	return response, nil
+}
+
+// withMedia fills in the media of the products with a single query for all of them.
+func (s *Service) withMedia(ctx context.Context, products ...*DTO.ProductResponse) *errs.AppError {
+	ids := make([]int64, len(products))
+	for i, product := range products {
+		ids[i] = product.Id
+	}
+	media, appErr := s.media.ForProducts(ctx, ids)
+	if appErr != nil {
+		return appErr
+	}
+	for i, product := range products {
+		product.Media = media[i]
+	}
 	return nil
 }
+
+// withEffectivePrices prices the storefront products in the selected price list, if any, and applies
+// the promotions in effect, with a single query for all of them.
+func (s *Service) withEffectivePrices(ctx context.Context, query DTO.PriceListQuery, products []DTO.FrontProductResponse) *errs.AppError {
+	ids := make([]int64, len(products))
+	for i, product := range products {
+		ids[i] = product.Id
+	}
+	pricer, appErr := s.pricelist.Pricer(ctx, query, ids)
+	if appErr != nil {
+		return appErr
+	}
+	rules, appErr := s.promotion.Rules(ctx, ids)
+	if appErr != nil {
+		return appErr
+	}
+	for i := range products {
+		if pricer != nil {
+			products[i].Price, appErr = pricer.Price(products[i].Id, products[i].Price)
+			if appErr != nil {
+				return appErr
+			}
+		}
+		resolved := promotion.Resolve(products[i].Price, rules[products[i].Id])
+		products[i].EffectivePrice = resolved.EffectivePrice
+		products[i].AppliedPromotions = resolved.AppliedPromotions
+	}
+	return nil
+}
+
+// attributeFilters is the attr[code] query params split into the array arguments of the
+// filtered product queries. pairs holds "code:value" of every accepted value, ranges are
+// parallel arrays with infinite bounds for the omitted ones.
+type attributeFilters struct {
+	codes      []string
+	pairs      []string
+	rangeCodes []string
+	rangeMins  []float64
+	rangeMaxs  []float64
+}
+
+func parseAttributeFilters(attributes map[string]string) (attributeFilters, *errs.AppError) {
+	filters := attributeFilters{
+		codes:      []string{},
+		pairs:      []string{},
+		rangeCodes: []string{},
+		rangeMins:  []float64{},
+		rangeMaxs:  []float64{},
+	}
+	for code, raw := range attributes {
+		if minRaw, maxRaw, isRange := strings.Cut(raw, ".."); isRange {
+			minValue, maxValue := math.Inf(-1), math.Inf(1)
+			var err error
+			if strings.TrimSpace(minRaw) != "" {
+				minValue, err = strconv.ParseFloat(strings.TrimSpace(minRaw), 64)
+				if err != nil {
+					return attributeFilters{}, errs.BadRequest(fmt.Errorf("attr[%s]: invalid range start %q", code, minRaw))
+				}
+			}
+			if strings.TrimSpace(maxRaw) != "" {
+				maxValue, err = strconv.ParseFloat(strings.TrimSpace(maxRaw), 64)
+				if err != nil {
+					return attributeFilters{}, errs.BadRequest(fmt.Errorf("attr[%s]: invalid range end %q", code, maxRaw))
+				}
+			}
+			filters.rangeCodes = append(filters.rangeCodes, code)
+			filters.rangeMins = append(filters.rangeMins, minValue)
+			filters.rangeMaxs = append(filters.rangeMaxs, maxValue)
+			continue
+		}
+
+		accepted := false
+		for _, value := range strings.Split(raw, ",") {
+			value = strings.TrimSpace(value)
+			if value == "" {
+				continue
+			}
+			accepted = true
+			filters.pairs = append(filters.pairs, code+":"+value)
+			// number values are stored in canonical form, so 16.0 has to match 16
+			if number, err := strconv.ParseFloat(value, 64); err == nil && attribute.FormatNumber(number) != value {
+				filters.pairs = append(filters.pairs, code+":"+attribute.FormatNumber(number))
+			}
+		}
+		if !accepted {
+			return attributeFilters{}, errs.BadRequest(fmt.Errorf("attr[%s] must not be empty", code))
+		}
+		filters.codes = append(filters.codes, code)
+	}
+	return filters, nil
+}
+
+func cursorOf(sortBy string) func(product DTO.ProductResponse) (string, int64) {
+	return func(product DTO.ProductResponse) (string, int64) {
+		switch sortBy {
+		case "name":
+			return product.Name, product.Id
+		case "price":
+			return strconv.FormatInt(product.Price.Amount, 10), product.Id
+		case "created_at":
+			return product.CreatedAt.Format(time.RFC3339Nano), product.Id
+		case "updated_at":
+			return product.UpdatedAt.Format(time.RFC3339Nano), product.Id
+		default:
+			return "", product.Id
+		}
+	}
+}
+
+// prefixTsQuery turns user input into a to_tsquery expression that matches every word by prefix,
+// dropping everything that could be interpreted as tsquery syntax.
+func prefixTsQuery(query string) string {
+	words := wordRegexp.FindAllString(query, -1)
+	for i, word := range words {
+		words[i] = word + ":*"
+	}
+	return strings.Join(words, " & ")
+}
@@ -0,0 +1,97 @@
+package promotion
+
+import (
+	"github.com/Aoladiy/go-with-tools/internal/DTO"
+	"github.com/Aoladiy/go-with-tools/internal/database/queries"
+	"github.com/Aoladiy/go-with-tools/internal/helpers"
+)
+
+func mapRequestToCreateParams(request DTO.PromotionRequest) queries.CreatePromotionParams {
+	return queries.CreatePromotionParams{
+		Name:        request.Name,
+		Type:        request.Type,
+		Value:       request.Value,
+		Currency:    request.Currency,
+		ProductID:   request.ProductId,
+		BrandID:     request.BrandId,
+		CategoryID:  request.CategoryId,
+		StartsAt:    request.StartsAt,
+		EndsAt:      helpers.ToPgTimestamptz(request.EndsAt),
+		Priority:    request.Priority,
+		IsStackable: helpers.DerefBool(request.IsStackable, false),
+		IsActive:    helpers.DerefBool(request.IsActive, true),
+	}
+}
+
+func mapRequestToUpdateParams(id int64, request DTO.PromotionRequest) queries.UpdatePromotionParams {
+	return queries.UpdatePromotionParams{
+		ID:          id,
+		Name:        request.Name,
+		Type:        request.Type,
+		Value:       request.Value,
+		Currency:    request.Currency,
+		ProductID:   request.ProductId,
+		BrandID:     request.BrandId,
+		CategoryID:  request.CategoryId,
+		StartsAt:    request.StartsAt,
+		EndsAt:      helpers.ToPgTimestamptz(request.EndsAt),
+		Priority:    request.Priority,
+		IsStackable: helpers.DerefBool(request.IsStackable, false),
+		IsActive:    helpers.DerefBool(request.IsActive, true),
+	}
+}
+
+func mapListRequestToGetAllParams(request DTO.PromotionListRequest, page helpers.Page) queries.GetAllPromotionsParams {
+	return queries.GetAllPromotionsParams{
+		IsActive:    request.IsActive,
+		ProductID:   request.ProductId,
+		BrandID:     request.BrandId,
+		CategoryID:  request.CategoryId,
+		CursorID:    page.CursorId,
+		CursorValue: page.CursorValue,
+		SortBy:      page.SortBy,
+		SortDesc:    page.SortDesc,
+		PageLimit:   page.Limit + 1,
+	}
+}
+
+func mapListRequestToCountParams(request DTO.PromotionListRequest) queries.CountPromotionsParams {
+	return queries.CountPromotionsParams{
+		IsActive:   request.IsActive,
+		ProductID:  request.ProductId,
+		BrandID:    request.BrandId,
+		CategoryID: request.CategoryId,
+	}
+}
+
+func mapPromotionToResponse(promotion queries.Promotion) DTO.PromotionResponse {
+	return DTO.PromotionResponse{
+		Id:          promotion.ID,
+		Name:        promotion.Name,
+		Type:        promotion.Type,
+		Value:       promotion.Value,
+		Currency:    promotion.Currency,
+		ProductId:   promotion.ProductID,
+		BrandId:     promotion.BrandID,
+		CategoryId:  promotion.CategoryID,
+		StartsAt:    promotion.StartsAt,
+		EndsAt:      helpers.ParsePgTimestamptz(promotion.EndsAt),
+		Priority:    promotion.Priority,
+		IsStackable: promotion.IsStackable,
+		IsActive:    promotion.IsActive,
+		CreatedAt:   promotion.CreatedAt,
+		UpdatedAt:   promotion.UpdatedAt,
+	}
+}
+
+func mapApplicableRowToRule(row queries.GetApplicablePromotionsRow) Rule {
+	return Rule{
+		Id:          row.ID,
+		Name:        row.Name,
+		Type:        row.Type,
+		Value:       row.Value,
+		Priority:    row.Priority,
+		IsStackable: row.IsStackable,
+		Currency:    row.Currency,
+	}
+}
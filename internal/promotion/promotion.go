@@ -0,0 +1,171 @@
+package promotion
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Aoladiy/go-with-tools/internal/DTO"
+	"github.com/Aoladiy/go-with-tools/internal/database/queries"
+	"github.com/Aoladiy/go-with-tools/internal/errs"
+	"github.com/Aoladiy/go-with-tools/internal/helpers"
+	"github.com/Aoladiy/go-with-tools/internal/money"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+var sortFields = []string{"priority", "starts_at"}
+
+type Service struct {
+	q *queries.Queries
+	p *pgxpool.Pool
+}
+
+func New(q *queries.Queries, p *pgxpool.Pool) *Service {
+	return &Service{q: q, p: p}
+}
+
+func (s *Service) Create(ctx context.Context, request DTO.PromotionRequest) (DTO.PromotionResponse, *errs.AppError) {
+	request, appErr := s.validateRequest(ctx, request)
+	if appErr != nil {
+		return DTO.PromotionResponse{}, appErr
+	}
+
+	promotion, err := s.q.CreatePromotion(ctx, mapRequestToCreateParams(request))
+	if err != nil {
+		return DTO.PromotionResponse{}, errs.FromPgErr(err)
+	}
+
+	return mapPromotionToResponse(promotion), nil
+}
+
+func (s *Service) GetAll(ctx context.Context, request DTO.PromotionListRequest) (DTO.PromotionPageResponse, *errs.AppError) {
+	page, appErr := helpers.ParsePage(request.PageRequest, sortFields...)
+	if appErr != nil {
+		return DTO.PromotionPageResponse{}, appErr
+	}
+
+	promotions, err := s.q.GetAllPromotions(ctx, mapListRequestToGetAllParams(request, page))
+	if err != nil {
+		return DTO.PromotionPageResponse{}, errs.Internal(err)
+	}
+	total, err := s.q.CountPromotions(ctx, mapListRequestToCountParams(request))
+	if err != nil {
+		return DTO.PromotionPageResponse{}, errs.Internal(err)
+	}
+
+	promotionsResponse := make([]DTO.PromotionResponse, len(promotions))
+	for i, promotion := range promotions {
+		promotionsResponse[i] = mapPromotionToResponse(promotion)
+	}
+	items, pageInfo := helpers.Paginate(promotionsResponse, total, page, cursorOf(page.SortBy))
+	return DTO.PromotionPageResponse{Items: items, PageInfo: pageInfo}, nil
+}
+
+func (s *Service) Get(ctx context.Context, id int64) (DTO.PromotionResponse, *errs.AppError) {
+	promotion, err := s.q.GetPromotion(ctx, id)
+	if err != nil {
+		return DTO.PromotionResponse{}, errs.FromPgErr(err)
+	}
+
+	return mapPromotionToResponse(promotion), nil
+}
+
+func (s *Service) Update(ctx context.Context, id int64, request DTO.PromotionRequest) (DTO.PromotionResponse, *errs.AppError) {
+	request, appErr := s.validateRequest(ctx, request)
+	if appErr != nil {
+		return DTO.PromotionResponse{}, appErr
+	}
+
+	promotion, err := s.q.UpdatePromotion(ctx, mapRequestToUpdateParams(id, request))
+	if err != nil {
+		return DTO.PromotionResponse{}, errs.FromPgErr(err)
+	}
+
+	return mapPromotionToResponse(promotion), nil
+}
+
+func (s *Service) Delete(ctx context.Context, id int64) (int, *errs.AppError) {
+	rows, err := s.q.DeletePromotion(ctx, id)
+	if err != nil {
+		return 0, errs.Internal(err)
+	}
+	if rows == 0 {
+		return int(rows), errs.NotFound(errors.New("promotion not found"))
+	}
+	return int(rows), nil
+}
+
+// ResolveProduct returns the price of the product with the promotions in effect right now.
+func (s *Service) ResolveProduct(ctx context.Context, productId int64) (DTO.ResolvedPriceResponse, *errs.AppError) {
+	product, err := s.q.GetProduct(ctx, productId)
+	if err != nil {
+		return DTO.ResolvedPriceResponse{}, errs.FromPgErr(err)
+	}
+	rules, appErr := s.Rules(ctx, []int64{productId})
+	if appErr != nil {
+		return DTO.ResolvedPriceResponse{}, appErr
+	}
+
+	return Resolve(money.New(product.PriceKopeck, product.Currency), rules[productId]), nil
+}
+
+func (s *Service) validateRequest(ctx context.Context, request DTO.PromotionRequest) (DTO.PromotionRequest, *errs.AppError) {
+	request.Name = strings.TrimSpace(request.Name)
+	if request.Name == "" {
+		return request, errs.BadRequest(errors.New("name is required"))
+	}
+	switch request.Type {
+	case TypePercent:
+		if request.Value < 1 || request.Value > 100 {
+			return request, errs.BadRequest(errors.New("percent promotion value must be between 1 and 100"))
+		}
+		if request.Currency != nil {
+			return request, errs.BadRequest(errors.New("currency is only allowed for fixed promotions"))
+		}
+	case TypeFixed:
+		if request.Value < 1 {
+			return request, errs.BadRequest(errors.New("fixed promotion value must be at least 1 minor unit"))
+		}
+		if request.Currency == nil {
+			return request, errs.BadRequest(errors.New("currency is required for fixed promotions"))
+		}
+		currency := strings.ToUpper(strings.TrimSpace(*request.Currency))
+		err := money.ValidateCurrency(currency)
+		if err != nil {
+			return request, errs.BadRequest(err)
+		}
+		request.Currency = &currency
+	default:
+		return request, errs.BadRequest(fmt.Errorf("unknown type %q, expected percent or fixed", request.Type))
+	}
+
+	if request.StartsAt.IsZero() {
+		return request, errs.BadRequest(errors.New("starts_at is required"))
+	}
+	if request.EndsAt != nil && !request.EndsAt.After(request.StartsAt) {
+		return request, errs.BadRequest(errors.New("ends_at must be after starts_at"))
+	}
+
+	_, appErr := helpers.ValidateScope(ctx, s.q, request.ProductId, request.BrandId, request.CategoryId)
+	if appErr != nil {
+		return request, appErr
+	}
+	return request, nil
+}
+
+func cursorOf(sortBy string) func(promotion DTO.PromotionResponse) (string, int64) {
+	return func(promotion DTO.PromotionResponse) (string, int64) {
+		switch sortBy {
+		case "priority":
+			return strconv.Itoa(int(promotion.Priority)), promotion.Id
+		case "starts_at":
+			return promotion.StartsAt.Format(time.RFC3339Nano), promotion.Id
+		default:
+			return "", promotion.Id
+		}
+	}
+}
@@ -0,0 +1,92 @@
+package promotion
+
+import (
+	"context"
+
+	"github.com/Aoladiy/go-with-tools/internal/DTO"
+	"github.com/Aoladiy/go-with-tools/internal/errs"
+	"github.com/Aoladiy/go-with-tools/internal/money"
+)
+
+const (
+	TypePercent = "percent"
+	TypeFixed   = "fixed"
+)
+
+// Rule is a promotion in effect for a product.
+type Rule struct {
+	Id          int64
+	Name        string
+	Type        string
+	Value       int64
+	Priority    int32
+	IsStackable bool
+	Currency    *string
+}
+
+// Rules returns the promotions in effect for every product keyed by product id, each list ordered by
+// priority from the highest. A promotion scoped to a category covers the whole subtree.
+func (s *Service) Rules(ctx context.Context, productIds []int64) (map[int64][]Rule, *errs.AppError) {
+	rows, err := s.q.GetApplicablePromotions(ctx, productIds)
+	if err != nil {
+		return nil, errs.Internal(err)
+	}
+
+	rules := make(map[int64][]Rule, len(productIds))
+	for _, row := range rows {
+		rules[row.ProductID] = append(rules[row.ProductID], mapApplicableRowToRule(row))
+	}
+	return rules, nil
+}
+
+// Resolve applies the rules to the base price. The rule with the highest priority always applies.
+// If it isn't stackable it is the only one, otherwise every other stackable rule is applied after it
+// in priority order, each to the price left by the previous ones. The price never goes below zero.
+// Fixed rules in another currency than the base price don't apply at all.
+func Resolve(basePrice money.Money, rules []Rule) DTO.ResolvedPriceResponse {
+	rules = inCurrency(rules, basePrice.Currency)
+	resolved := DTO.ResolvedPriceResponse{
+		BasePrice:         basePrice,
+		EffectivePrice:    basePrice,
+		AppliedPromotions: []DTO.AppliedPromotionResponse{},
+	}
+	for i, rule := range rules {
+		if i > 0 && (!rules[0].IsStackable || !rule.IsStackable) {
+			continue
+		}
+		discount := discountOf(resolved.EffectivePrice.Amount, rule)
+		resolved.EffectivePrice.Amount -= discount
+		resolved.AppliedPromotions = append(resolved.AppliedPromotions, DTO.AppliedPromotionResponse{
+			Id:       rule.Id,
+			Name:     rule.Name,
+			Type:     rule.Type,
+			Value:    rule.Value,
+			Discount: money.New(discount, basePrice.Currency),
+		})
+	}
+	return resolved
+}
+
+func inCurrency(rules []Rule, currency string) []Rule {
+	applicable := make([]Rule, 0, len(rules))
+	for _, rule := range rules {
+		if rule.Type == TypeFixed && (rule.Currency == nil || *rule.Currency != currency) {
+			continue
+		}
+		applicable = append(applicable, rule)
+	}
+	return applicable
+}
+
+// discountOf rounds percent discounts half up to a whole minor unit. The percent is applied
+// to the whole and the remainder separately, so the product never overflows int64.
+func discountOf(price int64, rule Rule) int64 {
+	var discount int64
+	switch rule.Type {
+	case TypePercent:
+		discount = price/100*rule.Value + (price%100*rule.Value+50)/100
+	case TypeFixed:
+		discount = rule.Value
+	}
+	return min(discount, price)
+}
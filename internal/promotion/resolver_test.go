@@ -0,0 +1,103 @@
+package promotion
+
+import (
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/Aoladiy/go-with-tools/internal/money"
+)
+
+func percent(id int64, value int64, stackable bool) Rule {
+	return Rule{Id: id, Type: TypePercent, Value: value, IsStackable: stackable}
+}
+
+func fixed(id int64, value int64, currency string, stackable bool) Rule {
+	return Rule{Id: id, Type: TypeFixed, Value: value, Currency: &currency, IsStackable: stackable}
+}
+
+func TestResolve(t *testing.T) {
+	tests := []struct {
+		name          string
+		base          money.Money
+		rules         []Rule
+		wantEffective int64
+		wantApplied   []int64
+		wantDiscounts []int64
+	}{
+		{"no promotions", money.New(10000, "RUB"), nil, 10000, []int64{}, []int64{}},
+		{"percent", money.New(12345, "RUB"), []Rule{percent(1, 10, false)}, 11110, []int64{1}, []int64{1235}},
+		{"percent rounded half up", money.New(5, "RUB"), []Rule{percent(1, 10, false)}, 4, []int64{1}, []int64{1}},
+		{"percent rounded down below a half", money.New(4, "RUB"), []Rule{percent(1, 10, false)}, 4, []int64{1}, []int64{0}},
+		{"fixed", money.New(10000, "RUB"), []Rule{fixed(1, 500, "RUB", false)}, 9500, []int64{1}, []int64{500}},
+		{
+			"not stackable highest priority is the only one",
+			money.New(10000, "RUB"),
+			[]Rule{percent(1, 10, false), fixed(2, 100, "RUB", true)},
+			9000, []int64{1}, []int64{1000},
+		},
+		{
+			"stackable ones apply to the price left by the previous ones",
+			money.New(10000, "RUB"),
+			[]Rule{percent(1, 10, true), percent(2, 10, true)},
+			8100, []int64{1, 2}, []int64{1000, 900},
+		},
+		{
+			"not stackable lower priority is skipped",
+			money.New(10000, "RUB"),
+			[]Rule{percent(1, 10, true), fixed(2, 500, "RUB", false), fixed(3, 100, "RUB", true)},
+			8900, []int64{1, 3}, []int64{1000, 100},
+		},
+		{
+			"fixed in another currency doesn't apply",
+			money.New(10000, "RUB"),
+			[]Rule{fixed(1, 500, "USD", false), percent(2, 10, false)},
+			9000, []int64{2}, []int64{1000},
+		},
+		{
+			"fixed without a currency doesn't apply",
+			money.New(10000, "RUB"),
+			[]Rule{{Id: 1, Type: TypeFixed, Value: 500}},
+			10000, []int64{}, []int64{},
+		},
+		{
+			"never below zero",
+			money.New(300, "RUB"),
+			[]Rule{fixed(1, 500, "RUB", true), percent(2, 10, true)},
+			0, []int64{1, 2}, []int64{300, 0},
+		},
+		{"whole largest price", money.New(math.MaxInt64, "RUB"), []Rule{percent(1, 100, false)}, 0, []int64{1}, []int64{math.MaxInt64}},
+		{
+			"half of the largest price without overflow",
+			money.New(math.MaxInt64, "RUB"),
+			[]Rule{percent(1, 50, false)},
+			4611686018427387903, []int64{1}, []int64{4611686018427387904},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Resolve(tt.base, tt.rules)
+			if got.BasePrice != tt.base {
+				t.Errorf("BasePrice = %v, want %v", got.BasePrice, tt.base)
+			}
+			if want := money.New(tt.wantEffective, tt.base.Currency); got.EffectivePrice != want {
+				t.Errorf("EffectivePrice = %v, want %v", got.EffectivePrice, want)
+			}
+			applied := []int64{}
+			discounts := []int64{}
+			for _, promotion := range got.AppliedPromotions {
+				applied = append(applied, promotion.Id)
+				discounts = append(discounts, promotion.Discount.Amount)
+				if promotion.Discount.Currency != tt.base.Currency {
+					t.Errorf("discount of promotion %d is in %s, want %s", promotion.Id, promotion.Discount.Currency, tt.base.Currency)
+				}
+			}
+			if !reflect.DeepEqual(applied, tt.wantApplied) {
+				t.Errorf("applied promotions = %v, want %v", applied, tt.wantApplied)
+			}
+			if !reflect.DeepEqual(discounts, tt.wantDiscounts) {
+				t.Errorf("discounts = %v, want %v", discounts, tt.wantDiscounts)
+			}
+		})
+	}
+}
@@ -0,0 +1,79 @@
+package schedule
+
+import (
+	"github.com/Aoladiy/go-with-tools/internal/DTO"
+	"github.com/Aoladiy/go-with-tools/internal/database/queries"
+	"github.com/Aoladiy/go-with-tools/internal/helpers"
+	"github.com/Aoladiy/go-with-tools/internal/money"
+)
+
+func mapRequestToCreateParams(request DTO.PriceScheduleRequest, createdBy int64) queries.CreatePriceScheduleParams {
+	return queries.CreatePriceScheduleParams{
+		ProductID:      request.ProductId,
+		BrandID:        request.BrandId,
+		CategoryID:     request.CategoryId,
+		NewPriceKopeck: request.NewPrice.Amount,
+		Currency:       request.NewPrice.Currency,
+		StartsAt:       request.StartsAt,
+		EndsAt:         helpers.ToPgTimestamptz(request.EndsAt),
+		CreatedBy:      createdBy,
+	}
+}
+
+func mapRequestToUpdateParams(id int64, request DTO.PriceScheduleRequest) queries.UpdatePriceScheduleParams {
+	return queries.UpdatePriceScheduleParams{
+		ID:             id,
+		ProductID:      request.ProductId,
+		BrandID:        request.BrandId,
+		CategoryID:     request.CategoryId,
+		NewPriceKopeck: request.NewPrice.Amount,
+		Currency:       request.NewPrice.Currency,
+		StartsAt:       request.StartsAt,
+		EndsAt:         helpers.ToPgTimestamptz(request.EndsAt),
+	}
+}
+
+func mapListRequestToGetAllParams(request DTO.PriceScheduleListRequest, page helpers.Page) queries.GetAllPriceSchedulesParams {
+	return queries.GetAllPriceSchedulesParams{
+		Status:      request.Status,
+		ProductID:   request.ProductId,
+		BrandID:     request.BrandId,
+		CategoryID:  request.CategoryId,
+		CursorID:    page.CursorId,
+		CursorValue: page.CursorValue,
+		SortBy:      page.SortBy,
+		SortDesc:    page.SortDesc,
+		PageLimit:   page.Limit + 1,
+	}
+}
+
+func mapListRequestToCountParams(request DTO.PriceScheduleListRequest) queries.CountPriceSchedulesParams {
+	return queries.CountPriceSchedulesParams{
+		Status:     request.Status,
+		ProductID:  request.ProductId,
+		BrandID:    request.BrandId,
+		CategoryID: request.CategoryId,
+	}
+}
+
+func mapScheduleToResponse(schedule queries.PriceSchedule) DTO.PriceScheduleResponse {
+	return DTO.PriceScheduleResponse{
+		Id:           schedule.ID,
+		ProductId:    schedule.ProductID,
+		BrandId:      schedule.BrandID,
+		CategoryId:   schedule.CategoryID,
+		NewPrice:     money.New(schedule.NewPriceKopeck, schedule.Currency),
+		StartsAt:     schedule.StartsAt,
+		EndsAt:       helpers.ParsePgTimestamptz(schedule.EndsAt),
+		Status:       schedule.Status,
+		FailedStatus: schedule.FailedStatus,
+		AppliedAt:    helpers.ParsePgTimestamptz(schedule.AppliedAt),
+		RevertedAt:   helpers.ParsePgTimestamptz(schedule.RevertedAt),
+		Attempts:     schedule.Attempts,
+		LastError:    schedule.LastError,
+		RetryAt:      helpers.ParsePgTimestamptz(schedule.RetryAt),
+		CreatedBy:    schedule.CreatedBy,
+		CreatedAt:    schedule.CreatedAt,
+		UpdatedAt:    schedule.UpdatedAt,
+	}
+}
@@ -0,0 +1,360 @@
+package schedule
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/Aoladiy/go-with-tools/internal/DTO"
+	"github.com/Aoladiy/go-with-tools/internal/database/queries"
+	"github.com/Aoladiy/go-with-tools/internal/errs"
+	"github.com/Aoladiy/go-with-tools/internal/helpers"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const (
+	StatusPending   = "pending"
+	StatusApplied   = "applied"
+	StatusCompleted = "completed"
+	StatusFailed    = "failed"
+
+	// processTimeout is how long applying or reverting a schedule may take, a brand or category wide
+	// schedule changes many products at once.
+	processTimeout = time.Minute
+	// a schedule that fails is retried after retryDelay while the others go on, and marked failed for
+	// an admin to look at once it has failed maxAttempts times in a row.
+	retryDelay  = 5 * time.Minute
+	maxAttempts = 5
+)
+
+var sortFields = []string{"starts_at"}
+
+type Service struct {
+	q *queries.Queries
+	p *pgxpool.Pool
+}
+
+func New(q *queries.Queries, p *pgxpool.Pool) *Service {
+	return &Service{q: q, p: p}
+}
+
+func (s *Service) Create(ctx context.Context, request DTO.PriceScheduleRequest) (DTO.PriceScheduleResponse, *errs.AppError) {
+	appErr := s.validateRequest(ctx, request)
+	if appErr != nil {
+		return DTO.PriceScheduleResponse{}, appErr
+	}
+	userId, err := helpers.SafeGetUserID(ctx)
+	if err != nil {
+		return DTO.PriceScheduleResponse{}, errs.Internal(err)
+	}
+
+	schedule, err := s.q.CreatePriceSchedule(ctx, mapRequestToCreateParams(request, userId))
+	if err != nil {
+		return DTO.PriceScheduleResponse{}, errs.FromPgErr(err)
+	}
+
+	return mapScheduleToResponse(schedule), nil
+}
+
+func (s *Service) GetAll(ctx context.Context, request DTO.PriceScheduleListRequest) (DTO.PriceSchedulePageResponse, *errs.AppError) {
+	page, appErr := helpers.ParsePage(request.PageRequest, sortFields...)
+	if appErr != nil {
+		return DTO.PriceSchedulePageResponse{}, appErr
+	}
+	if request.Status != nil && *request.Status != StatusPending && *request.Status != StatusApplied && *request.Status != StatusCompleted && *request.Status != StatusFailed {
+		return DTO.PriceSchedulePageResponse{}, errs.BadRequest(fmt.Errorf("unknown status %q, expected pending, applied, completed or failed", *request.Status))
+	}
+
+	schedules, err := s.q.GetAllPriceSchedules(ctx, mapListRequestToGetAllParams(request, page))
+	if err != nil {
+		return DTO.PriceSchedulePageResponse{}, errs.Internal(err)
+	}
+	total, err := s.q.CountPriceSchedules(ctx, mapListRequestToCountParams(request))
+	if err != nil {
+		return DTO.PriceSchedulePageResponse{}, errs.Internal(err)
+	}
+
+	schedulesResponse := make([]DTO.PriceScheduleResponse, len(schedules))
+	for i, schedule := range schedules {
+		schedulesResponse[i] = mapScheduleToResponse(schedule)
+	}
+	items, pageInfo := helpers.Paginate(schedulesResponse, total, page, func(schedule DTO.PriceScheduleResponse) (string, int64) {
+		if page.SortBy == "starts_at" {
+			return schedule.StartsAt.Format(time.RFC3339Nano), schedule.Id
+		}
+		return "", schedule.Id
+	})
+	return DTO.PriceSchedulePageResponse{Items: items, PageInfo: pageInfo}, nil
+}
+
+func (s *Service) Get(ctx context.Context, id int64) (DTO.PriceScheduleResponse, *errs.AppError) {
+	schedule, err := s.q.GetPriceSchedule(ctx, id)
+	if err != nil {
+		return DTO.PriceScheduleResponse{}, errs.FromPgErr(err)
+	}
+
+	return mapScheduleToResponse(schedule), nil
+}
+
+// Update changes a schedule that hasn't been applied yet, a schedule that failed to apply included.
+// The change puts a failed schedule back in the queue.
+func (s *Service) Update(ctx context.Context, id int64, request DTO.PriceScheduleRequest) (DTO.PriceScheduleResponse, *errs.AppError) {
+	appErr := s.validateRequest(ctx, request)
+	if appErr != nil {
+		return DTO.PriceScheduleResponse{}, appErr
+	}
+
+	var schedule queries.PriceSchedule
+	appErr = helpers.WithTx(ctx, s.p, s.q, func(timeout context.Context, q *queries.Queries) *errs.AppError {
+		locked, appErr := lock(timeout, q, id)
+		if appErr != nil {
+			return appErr
+		}
+		if locked.Status != StatusPending && !isFailed(locked, StatusPending) {
+			return errs.UnprocessableEntity(fmt.Errorf("price schedule with id=%d is %s, only pending schedules can be changed", id, locked.Status))
+		}
+		var err error
+		schedule, err = q.UpdatePriceSchedule(timeout, mapRequestToUpdateParams(id, request))
+		if err != nil {
+			return errs.FromPgErr(err)
+		}
+		return nil
+	})
+	if appErr != nil {
+		return DTO.PriceScheduleResponse{}, appErr
+	}
+
+	return mapScheduleToResponse(schedule), nil
+}
+
+// Delete cancels a schedule that hasn't been applied yet or has failed. Cancelling a schedule that failed
+// to revert leaves its price on the products.
+func (s *Service) Delete(ctx context.Context, id int64) (int, *errs.AppError) {
+	var rows int64
+	appErr := helpers.WithTx(ctx, s.p, s.q, func(timeout context.Context, q *queries.Queries) *errs.AppError {
+		locked, appErr := lock(timeout, q, id)
+		if appErr != nil {
+			return appErr
+		}
+		if locked.Status != StatusPending && locked.Status != StatusFailed {
+			return errs.UnprocessableEntity(fmt.Errorf("price schedule with id=%d is %s, only pending and failed schedules can be cancelled", id, locked.Status))
+		}
+		var err error
+		rows, err = q.DeletePriceSchedule(timeout, id)
+		if err != nil {
+			return errs.Internal(err)
+		}
+		return nil
+	})
+	if appErr != nil {
+		return 0, appErr
+	}
+
+	return int(rows), nil
+}
+
+// Retry puts a failed schedule back in the queue in the status it failed in, so the scheduler applies
+// or reverts it again on its next run.
+func (s *Service) Retry(ctx context.Context, id int64) (DTO.PriceScheduleResponse, *errs.AppError) {
+	var schedule queries.PriceSchedule
+	appErr := helpers.WithTx(ctx, s.p, s.q, func(timeout context.Context, q *queries.Queries) *errs.AppError {
+		locked, appErr := lock(timeout, q, id)
+		if appErr != nil {
+			return appErr
+		}
+		if locked.Status != StatusFailed {
+			return errs.UnprocessableEntity(fmt.Errorf("price schedule with id=%d is %s, only failed schedules can be retried", id, locked.Status))
+		}
+		var err error
+		schedule, err = q.RetryPriceSchedule(timeout, id)
+		if err != nil {
+			return errs.FromPgErr(err)
+		}
+		return nil
+	})
+	if appErr != nil {
+		return DTO.PriceScheduleResponse{}, appErr
+	}
+
+	return mapScheduleToResponse(schedule), nil
+}
+
+// Run applies due schedules and reverts expired ones every interval until ctx is done.
+// The returned channel receives once the scheduler has stopped.
+func (s *Service) Run(ctx context.Context, interval time.Duration) chan bool {
+	done := make(chan bool)
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			s.processDue(ctx)
+			select {
+			case <-ctx.Done():
+				slog.Info("price scheduler stopped")
+				done <- true
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+	return done
+}
+
+// processDue handles one schedule per transaction, so a failing schedule doesn't hold back the others
+// and several instances of the service can run side by side, each skipping the rows locked by another.
+// A failed schedule is put off until its retry, so the next one is picked meanwhile.
+func (s *Service) processDue(ctx context.Context) {
+	for ctx.Err() == nil {
+		processed, appErr := s.applyNext(ctx)
+		if appErr != nil {
+			slog.Error("cannot apply price schedule", "error", appErr.Err)
+		}
+		if !processed {
+			break
+		}
+	}
+	for ctx.Err() == nil {
+		processed, appErr := s.revertNext(ctx)
+		if appErr != nil {
+			slog.Error("cannot revert price schedule", "error", appErr.Err)
+		}
+		if !processed {
+			break
+		}
+	}
+}
+
+// applyNext sets the new price of every product in the scope of the earliest due schedule and records
+// the changes in the price history on behalf of the admin who created the schedule. Products priced
+// in another currency than the schedule are left as they are.
+func (s *Service) applyNext(ctx context.Context) (bool, *errs.AppError) {
+	var id int64
+	appErr := helpers.WithTxTimeout(ctx, s.p, s.q, processTimeout, func(timeout context.Context, q *queries.Queries) *errs.AppError {
+		schedule, err := q.GetDuePriceSchedule(timeout)
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil
+		}
+		if err != nil {
+			return errs.Internal(err)
+		}
+		id = schedule.ID
+
+		products, err := q.ApplyPriceSchedule(timeout, queries.ApplyPriceScheduleParams{
+			NewPriceKopeck: schedule.NewPriceKopeck,
+			Currency:       schedule.Currency,
+			UpdatedBy:      schedule.CreatedBy,
+			CategoryID:     schedule.CategoryID,
+			ProductID:      schedule.ProductID,
+			BrandID:        schedule.BrandID,
+			ScheduleID:     schedule.ID,
+		})
+		if err != nil {
+			return errs.Internal(fmt.Errorf("schedule id=%d: %w", schedule.ID, err))
+		}
+		err = q.MarkPriceScheduleApplied(timeout, schedule.ID)
+		if err != nil {
+			return errs.Internal(fmt.Errorf("schedule id=%d: %w", schedule.ID, err))
+		}
+
+		slog.Info("price schedule applied", "id", schedule.ID, "products", products)
+		return nil
+	})
+	return s.processed(ctx, id, appErr)
+}
+
+// revertNext restores the old prices of the earliest expired schedule. Products whose price was changed
+// again after the schedule was applied keep their current price.
+func (s *Service) revertNext(ctx context.Context) (bool, *errs.AppError) {
+	var id int64
+	appErr := helpers.WithTxTimeout(ctx, s.p, s.q, processTimeout, func(timeout context.Context, q *queries.Queries) *errs.AppError {
+		schedule, err := q.GetExpiredPriceSchedule(timeout)
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil
+		}
+		if err != nil {
+			return errs.Internal(err)
+		}
+		id = schedule.ID
+
+		products, err := q.RevertPriceSchedule(timeout, queries.RevertPriceScheduleParams{
+			NewPriceKopeck: schedule.NewPriceKopeck,
+			Currency:       schedule.Currency,
+			UpdatedBy:      schedule.CreatedBy,
+			ScheduleID:     schedule.ID,
+		})
+		if err != nil {
+			return errs.Internal(fmt.Errorf("schedule id=%d: %w", schedule.ID, err))
+		}
+		err = q.MarkPriceScheduleReverted(timeout, schedule.ID)
+		if err != nil {
+			return errs.Internal(fmt.Errorf("schedule id=%d: %w", schedule.ID, err))
+		}
+
+		slog.Info("price schedule reverted", "id", schedule.ID, "products", products)
+		return nil
+	})
+	return s.processed(ctx, id, appErr)
+}
+
+// processed tells whether the schedule with the id was picked, the id is 0 when none was. The failure of
+// a picked schedule is recorded with the time of its retry, once it has failed too many times in a row
+// the schedule is marked failed with the status it failed in and isn't picked again until an admin retries it.
+func (s *Service) processed(ctx context.Context, id int64, appErr *errs.AppError) (bool, *errs.AppError) {
+	if id == 0 || appErr == nil {
+		return id != 0, appErr
+	}
+	err := s.q.RecordPriceScheduleFailure(ctx, queries.RecordPriceScheduleFailureParams{
+		LastError:   appErr.Err.Error(),
+		RetryAt:     time.Now().Add(retryDelay),
+		MaxAttempts: maxAttempts,
+		ID:          id,
+	})
+	if err != nil {
+		return false, errs.Internal(fmt.Errorf("schedule id=%d: %w | %w", id, err, appErr.Err))
+	}
+	return true, appErr
+}
+
+func (s *Service) validateRequest(ctx context.Context, request DTO.PriceScheduleRequest) *errs.AppError {
+	err := request.NewPrice.Validate()
+	if err != nil {
+		return errs.BadRequest(fmt.Errorf("new_price: %w", err))
+	}
+	if request.StartsAt.IsZero() {
+		return errs.BadRequest(errors.New("starts_at is required"))
+	}
+	if request.EndsAt != nil && !request.EndsAt.After(request.StartsAt) {
+		return errs.BadRequest(errors.New("ends_at must be after starts_at"))
+	}
+
+	product, appErr := helpers.ValidateScope(ctx, s.q, request.ProductId, request.BrandId, request.CategoryId)
+	if appErr != nil {
+		return appErr
+	}
+	if product != nil && product.Currency != request.NewPrice.Currency {
+		return errs.UnprocessableEntity(fmt.Errorf("product with id=%d is priced in %s, not %s", product.ID, product.Currency, request.NewPrice.Currency))
+	}
+	return nil
+}
+
+// lock locks the schedule row, so the scheduler can't pick it while it's being changed.
+func lock(timeout context.Context, q *queries.Queries, id int64) (queries.LockPriceScheduleRow, *errs.AppError) {
+	schedule, err := q.LockPriceSchedule(timeout, id)
+	if err != nil {
+		appErr := errs.FromPgErr(err)
+		if appErr.Code == errs.NotFoundErrCode {
+			return queries.LockPriceScheduleRow{}, errs.NotFound(fmt.Errorf("price schedule with id=%d not found | %w", id, err))
+		}
+		return queries.LockPriceScheduleRow{}, appErr
+	}
+	return schedule, nil
+}
+
+// isFailed tells whether the schedule has failed in the status.
+func isFailed(schedule queries.LockPriceScheduleRow, status string) bool {
+	return schedule.Status == StatusFailed && schedule.FailedStatus != nil && *schedule.FailedStatus == status
+}
@@ -5,7 +5,10 @@ import (
 
 	"github.com/Aoladiy/go-with-tools/gen"
 	"github.com/Aoladiy/go-with-tools/internal/DTO"
+	"github.com/Aoladiy/go-with-tools/internal/auth"
 	"github.com/Aoladiy/go-with-tools/internal/errs"
+	"github.com/Aoladiy/go-with-tools/internal/export"
+	"github.com/Aoladiy/go-with-tools/internal/media"
 
 	"github.com/gin-gonic/gin"
 )
@@ -22,6 +25,8 @@ import (
 //	@Failure		400		{object}	DTO.ErrorResponse	"invalid input or password too short"
 //	@Failure		409		{object}	DTO.ErrorResponse	"email already exists"
 //	@Failure		500		{object}	DTO.ErrorResponse
+//	@Failure		503		{object}	DTO.ErrorResponse	"auth microservice is unavailable"
+//	@Failure		504		{object}	DTO.ErrorResponse	"auth microservice didn't answer in time"
 //	@Router			/admin/sign-up [post]
 func (s *Server) SignUpHandler(c *gin.Context) {
 	request, appErr := bindJson[DTO.SignUpRequest](c)
@@ -31,7 +36,7 @@ func (s *Server) SignUpHandler(c *gin.Context) {
 	}
 	jwtResponse, err := s.auth.SignUp(c.Request.Context(), &gen.SignUpRequest{Email: request.Email, Password: request.Password})
 	if err != nil {
-		respondError(c, errs.Unauthorized(err))
+		respondError(c, auth.FromGrpcErr(err))
 		return
 	}
 	c.JSON(http.StatusOK, jwtResponse)
@@ -49,6 +54,8 @@ func (s *Server) SignUpHandler(c *gin.Context) {
 //	@Failure		400		{object}	DTO.ErrorResponse	"invalid input or password too short"
 //	@Failure		401		{object}	DTO.ErrorResponse	"wrong credentials"
 //	@Failure		500		{object}	DTO.ErrorResponse
+//	@Failure		503		{object}	DTO.ErrorResponse	"auth microservice is unavailable"
+//	@Failure		504		{object}	DTO.ErrorResponse	"auth microservice didn't answer in time"
 //	@Router			/admin/sign-in [post]
 func (s *Server) SignInHandler(c *gin.Context) {
 	request, appErr := bindJson[DTO.SignInRequest](c)
@@ -58,7 +65,7 @@ func (s *Server) SignInHandler(c *gin.Context) {
 	}
 	jwtResponse, err := s.auth.SignIn(c.Request.Context(), &gen.SignInRequest{Email: request.Email, Password: request.Password})
 	if err != nil {
-		respondError(c, errs.Unauthorized(err))
+		respondError(c, auth.FromGrpcErr(err))
 		return
 	}
 	c.JSON(http.StatusOK, jwtResponse)
@@ -74,7 +81,10 @@ func (s *Server) SignInHandler(c *gin.Context) {
 //	@Param			body	body		DTO.TokenRefreshRequest	true	"JWT access token"
 //	@Success		200		{object}	DTO.JWTResponse
 //	@Failure		400		{object}	DTO.ErrorResponse	"invalid input"
+//	@Failure		401		{object}	DTO.ErrorResponse	"invalid or signed out token"
 //	@Failure		500		{object}	DTO.ErrorResponse
+//	@Failure		503		{object}	DTO.ErrorResponse	"auth microservice is unavailable"
+//	@Failure		504		{object}	DTO.ErrorResponse	"auth microservice didn't answer in time"
 //	@Router			/admin/token-refresh [post]
 func (s *Server) TokenRefreshHandler(c *gin.Context) {
 	request, appErr := bindJson[DTO.TokenRefreshRequest](c)
@@ -84,7 +94,7 @@ func (s *Server) TokenRefreshHandler(c *gin.Context) {
 	}
 	jwtResponse, err := s.auth.TokenRefresh(c.Request.Context(), &gen.TokenRefreshRequest{RefreshToken: request.RefreshToken})
 	if err != nil {
-		respondError(c, errs.Unauthorized(err))
+		respondError(c, auth.FromGrpcErr(err))
 		return
 	}
 	c.JSON(http.StatusOK, jwtResponse)
@@ -100,7 +110,10 @@ func (s *Server) TokenRefreshHandler(c *gin.Context) {
 //	@Param			body	body	DTO.SignOutRequest	true	"JWT access&refresh tokens"
 //	@Success		200
 //	@Failure		400	{object}	DTO.ErrorResponse	"invalid input"
+//	@Failure		401	{object}	DTO.ErrorResponse	"invalid token"
 //	@Failure		500	{object}	DTO.ErrorResponse
+//	@Failure		503	{object}	DTO.ErrorResponse	"auth microservice is unavailable"
+//	@Failure		504	{object}	DTO.ErrorResponse	"auth microservice didn't answer in time"
 //	@Router			/admin/sign-out [post]
 func (s *Server) SignOutHandler(c *gin.Context) {
 	request, appErr := bindJson[DTO.SignOutRequest](c)
@@ -110,7 +123,7 @@ func (s *Server) SignOutHandler(c *gin.Context) {
 	}
 	_, err := s.auth.SignOut(c.Request.Context(), &gen.SignOutRequest{AccessToken: request.AccessToken, RefreshToken: request.RefreshToken})
 	if err != nil {
-		respondError(c, errs.Internal(err))
+		respondError(c, auth.FromGrpcErr(err))
 		return
 	}
 	c.Status(http.StatusOK)
@@ -119,15 +132,16 @@ func (s *Server) SignOutHandler(c *gin.Context) {
 // CreateProductHandler creates a new product
 //
 //	@Summary		Create product
-//	@Description	Create a new product entry
+//	@Description	Create a new product entry. The slug is generated from the name when empty, a taken slug gets the first free -2, -3... suffix
 //	@Tags			products
 //	@Accept			json
 //	@Produce		json
 //	@Param			body	body		DTO.ProductRequest	true	"Product data"
 //	@Success		201		{object}	DTO.ProductResponse
+//	@Header			201		{string}	ETag	"Version of the product"
 //	@Failure		400		{object}	DTO.ErrorResponse
 //	@Failure		401		{object}	DTO.ErrorResponse
-//	@Failure		409		{object}	DTO.ErrorResponse	"slug or name already exists"
+//	@Failure		409		{object}	DTO.ErrorResponse	"slug was taken by a concurrent request"
 //	@Failure		422		{object}	DTO.ErrorResponse	"brand or category not found"
 //	@Failure		500		{object}	DTO.ErrorResponse
 //	@Security		BearerAuth
@@ -143,114 +157,2216 @@ func (s *Server) CreateProductHandler(c *gin.Context) {
 		respondError(c, err)
 		return
 	}
-	c.JSON(http.StatusCreated, product)
+	respondVersioned(c, http.StatusCreated, product.Version, product)
 }
 
-// GetAllProductHandler returns all products
+// ImportProductsHandler creates or updates products from a CSV file
+//
+//	@Summary		Import products
+//	@Description	Create or update products by slug from a CSV file with a header line. Columns slug, name, brand_slug, category_slug and price are required, description, is_active and currency are optional. price is a decimal like 1299.90 in currency, which defaults to the currency of the existing product or RUB. An existing product keeps its description and is_active when the column is missing or empty. Every row is validated like in create and update, nothing is imported if any row is invalid and the errors of all rows are returned with 422. Changed prices are recorded in the price history
+//	@Tags			products
+//	@Accept			multipart/form-data
+//	@Produce		json
+//	@Param			file	formData	file	true	"CSV file of at most 16 MiB"
+//	@Param			dry_run	query		bool	false	"Only validate the file and count what would be done"
+//	@Success		200		{object}	DTO.ProductImportResponse
+//	@Failure		400		{object}	DTO.ErrorResponse	"missing file, invalid csv or header"
+//	@Failure		401		{object}	DTO.ErrorResponse
+//	@Failure		422		{object}	DTO.ProductImportResponse	"invalid rows"
+//	@Failure		500		{object}	DTO.ErrorResponse
+//	@Security		BearerAuth
+//	@Router			/admin/products/import [post]
+func (s *Server) ImportProductsHandler(c *gin.Context) {
+	request, err := bindQuery[DTO.ProductImportRequest](c)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	file, err := openFormFile(c, "file", importMaxSize)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	defer file.Close()
+	response, err := s.product.Import(c.Request.Context(), file, request)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	if len(response.Errors) > 0 {
+		c.JSON(http.StatusUnprocessableEntity, response)
+		return
+	}
+	c.JSON(http.StatusOK, response)
+}
+
+// ExportProductsHandler streams products as a file
+//
+//	@Summary		Export products
+//	@Description	Stream the products matching the filters of the product list in the requested format. The whole list is written in batches, limit and cursor don't apply
+//	@Tags			products
+//	@Produce		text/csv,application/x-ndjson,application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+//	@Param			format			query		string	false	"File format, csv by default"	Enums(csv, jsonl, xlsx)
+//	@Param			order			query		string	false	"Sort order"					Enums(asc, desc)
+//	@Param			sort_by			query		string	false	"Sort field"					Enums(id, name, price, created_at, updated_at)
+//	@Param			brand_id		query		int		false	"Brand ID"
+//	@Param			category_id		query		int		false	"Category ID"
+//	@Param			is_active		query		bool	false	"Is product active"
+//	@Param			price_min		query		int		false	"Minimal price in minor units of the product currency"
+//	@Param			price_max		query		int		false	"Maximal price in minor units of the product currency"
+//	@Param			created_from	query		string	false	"Created at or after (RFC 3339)"
+//	@Param			created_to		query		string	false	"Created at or before (RFC 3339)"
+//	@Param			updated_from	query		string	false	"Updated at or after (RFC 3339)"
+//	@Param			updated_to		query		string	false	"Updated at or before (RFC 3339)"
+//	@Success		200				{file}		file
+//	@Failure		400				{object}	DTO.ErrorResponse
+//	@Failure		401				{object}	DTO.ErrorResponse
+//	@Failure		500				{object}	DTO.ErrorResponse
+//	@Failure		503				{object}	DTO.ErrorResponse	"too many exports are running"
+//	@Failure		504				{object}	DTO.ErrorResponse	"export took too long"
+//	@Security		BearerAuth
+//	@Router			/admin/products/export [get]
+func (s *Server) ExportProductsHandler(c *gin.Context) {
+	request, err := bindQuery[DTO.ProductListRequest](c)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	respondExport(c, "products", func(w export.Writer) *errs.AppError {
+		return s.product.Export(c.Request.Context(), request, w)
+	})
+}
+
+// ExportProductStockHandler streams inventory levels as a file
+//
+//	@Summary		Export inventory levels
+//	@Description	Stream the stock of the products matching the filters of the product list in the requested format. The whole list is written in batches, limit and cursor don't apply
+//	@Tags			inventory
+//	@Produce		text/csv,application/x-ndjson,application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+//	@Param			format			query		string	false	"File format, csv by default"	Enums(csv, jsonl, xlsx)
+//	@Param			order			query		string	false	"Sort order"					Enums(asc, desc)
+//	@Param			sort_by			query		string	false	"Sort field"					Enums(id, name, price, created_at, updated_at)
+//	@Param			brand_id		query		int		false	"Brand ID"
+//	@Param			category_id		query		int		false	"Category ID"
+//	@Param			is_active		query		bool	false	"Is product active"
+//	@Param			price_min		query		int		false	"Minimal price in minor units of the product currency"
+//	@Param			price_max		query		int		false	"Maximal price in minor units of the product currency"
+//	@Param			created_from	query		string	false	"Created at or after (RFC 3339)"
+//	@Param			created_to		query		string	false	"Created at or before (RFC 3339)"
+//	@Param			updated_from	query		string	false	"Updated at or after (RFC 3339)"
+//	@Param			updated_to		query		string	false	"Updated at or before (RFC 3339)"
+//	@Success		200				{file}		file
+//	@Failure		400				{object}	DTO.ErrorResponse
+//	@Failure		401				{object}	DTO.ErrorResponse
+//	@Failure		500				{object}	DTO.ErrorResponse
+//	@Failure		503				{object}	DTO.ErrorResponse	"too many exports are running"
+//	@Failure		504				{object}	DTO.ErrorResponse	"export took too long"
+//	@Security		BearerAuth
+//	@Router			/admin/inventory/export [get]
+func (s *Server) ExportProductStockHandler(c *gin.Context) {
+	request, err := bindQuery[DTO.ProductListRequest](c)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	respondExport(c, "stock", func(w export.Writer) *errs.AppError {
+		return s.product.ExportStock(c.Request.Context(), request, w)
+	})
+}
+
+// GetAllProductHandler returns a page of products
 //
 //	@Summary		List products
-//	@Description	Get a list of all products
+//	@Description	Get a filtered and sorted page of products
 //	@Tags			products
 //	@Produce		json
-//	@Success		200	{array}		DTO.ProductResponse
-//	@Failure		401	{object}	DTO.ErrorResponse
-//	@Failure		500	{object}	DTO.ErrorResponse
+//	@Param			limit			query		int		false	"Page size (1-100, default 20)"
+//	@Param			cursor			query		string	false	"Cursor from next_cursor of the previous page"
+//	@Param			order			query		string	false	"Sort order"	Enums(asc, desc)
+//	@Param			sort_by			query		string	false	"Sort field"	Enums(id, name, price, created_at, updated_at)
+//	@Param			brand_id		query		int		false	"Brand ID"
+//	@Param			category_id		query		int		false	"Category ID"
+//	@Param			is_active		query		bool	false	"Is product active"
+//	@Param			price_min		query		int		false	"Minimal price in minor units of the product currency"
+//	@Param			price_max		query		int		false	"Maximal price in minor units of the product currency"
+//	@Param			created_from	query		string	false	"Created at or after (RFC 3339)"
+//	@Param			created_to		query		string	false	"Created at or before (RFC 3339)"
+//	@Param			updated_from	query		string	false	"Updated at or after (RFC 3339)"
+//	@Param			updated_to		query		string	false	"Updated at or before (RFC 3339)"
+//	@Success		200				{object}	DTO.ProductPageResponse
+//	@Failure		400				{object}	DTO.ErrorResponse
+//	@Failure		401				{object}	DTO.ErrorResponse
+//	@Failure		500				{object}	DTO.ErrorResponse
 //	@Security		BearerAuth
 //	@Router			/admin/products [get]
 func (s *Server) GetAllProductHandler(c *gin.Context) {
-	products, err := s.product.GetAll(c.Request.Context())
+	request, err := bindQuery[DTO.ProductListRequest](c)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	products, err := s.product.GetAll(c.Request.Context(), request)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	products.Items = nonNilSlice(products.Items)
+	c.JSON(http.StatusOK, products)
+}
+
+// SearchProductHandler searches products
+//
+//	@Summary		Search products
+//	@Description	Full-text search over products' name, description, brand and category names with typo tolerance. Results are ordered by relevance
+//	@Tags			products
+//	@Produce		json
+//	@Param			q			query		string	true	"Search query"
+//	@Param			is_active	query		bool	false	"Is product active"
+//	@Param			limit		query		int		false	"Page size (1-100, default 20)"
+//	@Param			cursor		query		string	false	"Cursor from next_cursor of the previous page"
+//	@Success		200			{object}	DTO.ProductPageResponse
+//	@Failure		400			{object}	DTO.ErrorResponse
+//	@Failure		401			{object}	DTO.ErrorResponse
+//	@Failure		500			{object}	DTO.ErrorResponse
+//	@Security		BearerAuth
+//	@Router			/admin/products/search [get]
+func (s *Server) SearchProductHandler(c *gin.Context) {
+	request, err := bindQuery[DTO.ProductSearchRequest](c)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	products, err := s.product.Search(c.Request.Context(), request)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	products.Items = nonNilSlice(products.Items)
+	c.JSON(http.StatusOK, products)
+}
+
+// GetProductHandler returns a product by ID
+//
+//	@Summary		Get product
+//	@Description	Fetch a single product by its ID
+//	@Tags			products
+//	@Produce		json
+//	@Param			id				path		int		true	"Product ID"
+//	@Param			If-None-Match	header		string	false	"ETag of the cached product"
+//	@Success		200				{object}	DTO.ProductResponse
+//	@Success		304				"Product wasn't changed since the ETag"
+//	@Header			200				{string}	ETag	"Version of the product"
+//	@Failure		400				{object}	DTO.ErrorResponse
+//	@Failure		401				{object}	DTO.ErrorResponse
+//	@Failure		404				{object}	DTO.ErrorResponse
+//	@Failure		500				{object}	DTO.ErrorResponse
+//	@Security		BearerAuth
+//	@Router			/admin/products/{id} [get]
+func (s *Server) GetProductHandler(c *gin.Context) {
+	id, err := getInt64PathParam(c, "id")
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	product, err := s.product.Get(c.Request.Context(), id)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	respondCached(c, product.Version, product)
+}
+
+// UpdateProductHandler updates an existing product
+//
+//	@Summary		Update product
+//	@Description	Update product data by ID. The current slug is kept when empty, a taken slug gets the first free -2, -3... suffix and the replaced one keeps resolving on the storefront
+//	@Tags			products
+//	@Accept			json
+//	@Produce		json
+//	@Param			id			path		int					true	"Product ID"
+//	@Param			body		body		DTO.ProductRequest	true	"Updated product data"
+//	@Param			If-Match	header		string				false	"ETag the product is expected to have"
+//	@Success		200			{object}	DTO.ProductResponse
+//	@Header			200			{string}	ETag	"Version of the product"
+//	@Failure		400			{object}	DTO.ErrorResponse
+//	@Failure		401			{object}	DTO.ErrorResponse
+//	@Failure		404			{object}	DTO.ErrorResponse
+//	@Failure		409			{object}	DTO.ErrorResponse	"slug was taken by a concurrent request"
+//	@Failure		412			{object}	DTO.ErrorResponse	"product was changed since the ETag"
+//	@Failure		422			{object}	DTO.ErrorResponse	"brand or category not found"
+//	@Failure		500			{object}	DTO.ErrorResponse
+//	@Security		BearerAuth
+//	@Router			/admin/products/{id} [put]
+func (s *Server) UpdateProductHandler(c *gin.Context) {
+	id, err := getInt64PathParam(c, "id")
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	request, err := bindJson[DTO.ProductRequest](c)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	product, err := s.product.Update(c.Request.Context(), id, getIfMatch(c), request)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	respondVersioned(c, http.StatusOK, product.Version, product)
+}
+
+// PatchProductHandler partially updates an existing product
+//
+//	@Summary		Patch product
+//	@Description	Update only the members present in an RFC 7396 merge patch, price is merged member by member. A null description empties it, a null is_active makes the product active, a null slug generates it from the name again. brand_id, category_id, name and price can't be null and the currency can't change. A price change is written to the price history
+//	@Tags			products
+//	@Accept			json
+//	@Accept			application/merge-patch+json
+//	@Produce		json
+//	@Param			id			path		int						true	"Product ID"
+//	@Param			body		body		DTO.ProductPatchRequest	true	"Merge patch of the product"
+//	@Param			If-Match	header		string					false	"ETag the product is expected to have"
+//	@Success		200			{object}	DTO.ProductResponse
+//	@Header			200			{string}	ETag	"Version of the product"
+//	@Failure		400			{object}	DTO.ErrorResponse
+//	@Failure		401			{object}	DTO.ErrorResponse
+//	@Failure		404			{object}	DTO.ErrorResponse
+//	@Failure		409			{object}	DTO.ErrorResponse	"slug was taken by a concurrent request"
+//	@Failure		412			{object}	DTO.ErrorResponse	"product was changed since the ETag"
+//	@Failure		422			{object}	DTO.ErrorResponse	"currency can't be changed"
+//	@Failure		500			{object}	DTO.ErrorResponse
+//	@Security		BearerAuth
+//	@Router			/admin/products/{id} [patch]
+func (s *Server) PatchProductHandler(c *gin.Context) {
+	id, err := getInt64PathParam(c, "id")
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	patch, err := bindMergePatch[DTO.ProductPatchRequest](c)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	product, err := s.product.Patch(c.Request.Context(), id, getIfMatch(c), patch)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	respondVersioned(c, http.StatusOK, product.Version, product)
+}
+
+// DeleteProductHandler deletes a product by ID
+//
+//	@Summary		Delete product
+//	@Description	Permanently delete a product by its ID
+//	@Tags			products
+//	@Produce		json
+//	@Param			id			path	int		true	"Product ID"
+//	@Param			If-Match	header	string	false	"ETag the product is expected to have"
+//	@Success		204
+//	@Failure		400	{object}	DTO.ErrorResponse
+//	@Failure		401	{object}	DTO.ErrorResponse
+//	@Failure		404	{object}	DTO.ErrorResponse
+//	@Failure		412	{object}	DTO.ErrorResponse	"product was changed since the ETag"
+//	@Security		BearerAuth
+//	@Router			/admin/products/{id} [delete]
+func (s *Server) DeleteProductHandler(c *gin.Context) {
+	id, err := getInt64PathParam(c, "id")
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	_, err = s.product.Delete(c.Request.Context(), id, getIfMatch(c))
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// GetTrashedProductHandler returns a page of soft-deleted products
+//
+//	@Summary		List trashed products
+//	@Description	Get a page of soft-deleted products
+//	@Tags			products
+//	@Produce		json
+//	@Param			limit	query		int		false	"Page size (1-100, default 20)"
+//	@Param			cursor	query		string	false	"Cursor from next_cursor of the previous page"
+//	@Param			sort_by	query		string	false	"Sort field"	Enums(id, deleted_at)
+//	@Param			order	query		string	false	"Sort order"	Enums(asc, desc)
+//	@Success		200		{object}	DTO.TrashedProductPageResponse
+//	@Failure		400		{object}	DTO.ErrorResponse
+//	@Failure		401		{object}	DTO.ErrorResponse
+//	@Failure		500		{object}	DTO.ErrorResponse
+//	@Security		BearerAuth
+//	@Router			/admin/products/trash [get]
+func (s *Server) GetTrashedProductHandler(c *gin.Context) {
+	request, err := bindQuery[DTO.PageRequest](c)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	products, err := s.product.GetTrash(c.Request.Context(), request)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	products.Items = nonNilSlice(products.Items)
+	c.JSON(http.StatusOK, products)
+}
+
+// RestoreProductHandler restores a soft-deleted product
+//
+//	@Summary		Restore product
+//	@Description	Restore a single soft-deleted product
+//	@Tags			products
+//	@Produce		json
+//	@Param			id	path		int	true	"Product ID"
+//	@Success		200	{object}	DTO.ProductResponse
+//	@Failure		400	{object}	DTO.ErrorResponse
+//	@Failure		401	{object}	DTO.ErrorResponse
+//	@Failure		404	{object}	DTO.ErrorResponse	"product not found in trash"
+//	@Failure		422	{object}	DTO.ErrorResponse	"brand or category is deleted"
+//	@Failure		500	{object}	DTO.ErrorResponse
+//	@Security		BearerAuth
+//	@Router			/admin/products/{id}/restore [post]
+func (s *Server) RestoreProductHandler(c *gin.Context) {
+	id, err := getInt64PathParam(c, "id")
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	response, err := s.product.Restore(c.Request.Context(), id)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, response)
+}
+
+// GetProductPriceHistory returns a page of the price history of a product
+//
+//	@Summary		Product price history
+//	@Description	Get a page of price changes of a product and its variants, with the email of the admin who made each change
+//	@Tags			products
+//	@Produce		json
+//	@Param			id				path		int		true	"Product ID"
+//	@Param			limit			query		int		false	"Page size (1-100, default 20)"
+//	@Param			cursor			query		string	false	"Cursor from next_cursor of the previous page"
+//	@Param			order			query		string	false	"Sort order"	Enums(asc, desc)
+//	@Param			sort_by			query		string	false	"Sort field"	Enums(id, created_at)
+//	@Param			updated_by		query		int		false	"Admin user ID"
+//	@Param			created_from	query		string	false	"Changed at or after (RFC 3339)"
+//	@Param			created_to		query		string	false	"Changed at or before (RFC 3339)"
+//	@Success		200				{object}	DTO.PriceHistoryPageResponse
+//	@Failure		400				{object}	DTO.ErrorResponse
+//	@Failure		401				{object}	DTO.ErrorResponse
+//	@Failure		404				{object}	DTO.ErrorResponse
+//	@Security		BearerAuth
+//	@Router			/admin/products/{id}/priceHistory [get]
+func (s *Server) GetProductPriceHistory(c *gin.Context) {
+	id, err := getInt64PathParam(c, "id")
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	request, err := bindQuery[DTO.PriceHistoryListRequest](c)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	priceHistory, err := s.priceHistory.GetByProduct(c.Request.Context(), id, request)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	priceHistory.Items = nonNilSlice(priceHistory.Items)
+	c.JSON(http.StatusOK, priceHistory)
+}
+
+// RollbackProductPriceHandler restores a product price from its history
+//
+//	@Summary		Roll back product price
+//	@Description	Set the product price back to the new price of a product level history entry. The rollback is recorded as a new history entry
+//	@Tags			products
+//	@Produce		json
+//	@Param			id			path		int	true	"Product ID"
+//	@Param			historyId	path		int	true	"Price history entry ID"
+//	@Success		200			{object}	DTO.ProductResponse
+//	@Failure		400			{object}	DTO.ErrorResponse
+//	@Failure		401			{object}	DTO.ErrorResponse
+//	@Failure		404			{object}	DTO.ErrorResponse
+//	@Failure		422			{object}	DTO.ErrorResponse
+//	@Security		BearerAuth
+//	@Router			/admin/products/{id}/priceHistory/{historyId}/rollback [post]
+func (s *Server) RollbackProductPriceHandler(c *gin.Context) {
+	id, err := getInt64PathParam(c, "id")
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	historyId, err := getInt64PathParam(c, "historyId")
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	product, err := s.product.RollbackPrice(c.Request.Context(), id, historyId)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, product)
+}
+
+// ExportPriceHistoryHandler streams price history as a file
+//
+//	@Summary		Export price history
+//	@Description	Stream the price changes matching the filters of the recent changes feed in the requested format, the latest first unless order is given. The whole list is written in batches, limit and cursor don't apply
+//	@Tags			priceHistory
+//	@Produce		text/csv,application/x-ndjson,application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+//	@Param			format			query		string	false	"File format, csv by default"	Enums(csv, jsonl, xlsx)
+//	@Param			order			query		string	false	"Sort order (default desc)"		Enums(asc, desc)
+//	@Param			sort_by			query		string	false	"Sort field"					Enums(id, created_at)
+//	@Param			product_id		query		int		false	"Product ID"
+//	@Param			updated_by		query		int		false	"Admin user ID"
+//	@Param			created_from	query		string	false	"Changed at or after (RFC 3339)"
+//	@Param			created_to		query		string	false	"Changed at or before (RFC 3339)"
+//	@Success		200				{file}		file
+//	@Failure		400				{object}	DTO.ErrorResponse
+//	@Failure		401				{object}	DTO.ErrorResponse
+//	@Failure		500				{object}	DTO.ErrorResponse
+//	@Failure		503				{object}	DTO.ErrorResponse	"too many exports are running"
+//	@Failure		504				{object}	DTO.ErrorResponse	"export took too long"
+//	@Security		BearerAuth
+//	@Router			/admin/priceHistory/export [get]
+func (s *Server) ExportPriceHistoryHandler(c *gin.Context) {
+	request, err := bindQuery[DTO.PriceHistoryListRequest](c)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	respondExport(c, "price_history", func(w export.Writer) *errs.AppError {
+		return s.priceHistory.Export(c.Request.Context(), request, w)
+	})
+}
+
+// GetRecentPriceChangesHandler returns a page of price changes across all products
+//
+//	@Summary		Recent price changes
+//	@Description	Get a page of price changes of all products, the latest first unless order is given
+//	@Tags			priceHistory
+//	@Produce		json
+//	@Param			limit			query		int		false	"Page size (1-100, default 20)"
+//	@Param			cursor			query		string	false	"Cursor from next_cursor of the previous page"
+//	@Param			order			query		string	false	"Sort order (default desc)"	Enums(asc, desc)
+//	@Param			sort_by			query		string	false	"Sort field"				Enums(id, created_at)
+//	@Param			product_id		query		int		false	"Product ID"
+//	@Param			updated_by		query		int		false	"Admin user ID"
+//	@Param			created_from	query		string	false	"Changed at or after (RFC 3339)"
+//	@Param			created_to		query		string	false	"Changed at or before (RFC 3339)"
+//	@Success		200				{object}	DTO.PriceHistoryPageResponse
+//	@Failure		400				{object}	DTO.ErrorResponse
+//	@Failure		401				{object}	DTO.ErrorResponse
+//	@Security		BearerAuth
+//	@Router			/admin/priceHistory [get]
+func (s *Server) GetRecentPriceChangesHandler(c *gin.Context) {
+	request, err := bindQuery[DTO.PriceHistoryListRequest](c)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	priceHistory, err := s.priceHistory.GetRecent(c.Request.Context(), request)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	priceHistory.Items = nonNilSlice(priceHistory.Items)
+	c.JSON(http.StatusOK, priceHistory)
+}
+
+// GetProductPriceHandler returns the price of a product with promotions applied
+//
+//	@Summary		Product price
+//	@Description	Resolve the current price of a product: base price, price after the promotions in effect and the applied promotions
+//	@Tags			promotions
+//	@Produce		json
+//	@Param			id	path		int	true	"Product ID"
+//	@Success		200	{object}	DTO.ResolvedPriceResponse
+//	@Failure		400	{object}	DTO.ErrorResponse
+//	@Failure		401	{object}	DTO.ErrorResponse
+//	@Failure		404	{object}	DTO.ErrorResponse
+//	@Failure		500	{object}	DTO.ErrorResponse
+//	@Security		BearerAuth
+//	@Router			/admin/products/{id}/price [get]
+func (s *Server) GetProductPriceHandler(c *gin.Context) {
+	id, err := getInt64PathParam(c, "id")
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	price, err := s.promotion.ResolveProduct(c.Request.Context(), id)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, price)
+}
+
+// GetProductStockHandler returns on-hand quantity of a product
+//
+//	@Summary		Product stock
+//	@Description	Get current on-hand quantity of a product by ID
+//	@Tags			inventory
+//	@Produce		json
+//	@Param			id	path		int	true	"Product ID"
+//	@Success		200	{object}	DTO.StockResponse
+//	@Failure		400	{object}	DTO.ErrorResponse
+//	@Failure		401	{object}	DTO.ErrorResponse
+//	@Failure		404	{object}	DTO.ErrorResponse
+//	@Failure		500	{object}	DTO.ErrorResponse
+//	@Security		BearerAuth
+//	@Router			/admin/products/{id}/stock [get]
+func (s *Server) GetProductStockHandler(c *gin.Context) {
+	id, err := getInt64PathParam(c, "id")
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	stock, err := s.inventory.GetStock(c.Request.Context(), id)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, stock)
+}
+
+// GetProductInventoryMovementsHandler returns a page of product's inventory movements
+//
+//	@Summary		Product inventory ledger
+//	@Description	Get a page of inventory movements of a product by ID
+//	@Tags			inventory
+//	@Produce		json
+//	@Param			id		path		int		true	"Product ID"
+//	@Param			limit	query		int		false	"Page size (1-100, default 20)"
+//	@Param			cursor	query		string	false	"Cursor from next_cursor of the previous page"
+//	@Param			order	query		string	false	"Sort order"	Enums(asc, desc)
+//	@Success		200		{object}	DTO.InventoryMovementPageResponse
+//	@Failure		400		{object}	DTO.ErrorResponse
+//	@Failure		401		{object}	DTO.ErrorResponse
+//	@Failure		404		{object}	DTO.ErrorResponse
+//	@Failure		500		{object}	DTO.ErrorResponse
+//	@Security		BearerAuth
+//	@Router			/admin/products/{id}/inventoryMovements [get]
+func (s *Server) GetProductInventoryMovementsHandler(c *gin.Context) {
+	id, err := getInt64PathParam(c, "id")
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	request, err := bindQuery[DTO.PageRequest](c)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	movements, err := s.inventory.GetMovements(c.Request.Context(), id, request)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	movements.Items = nonNilSlice(movements.Items)
+	c.JSON(http.StatusOK, movements)
+}
+
+// GetAllVariantHandler returns all variants of a product
+//
+//	@Summary		List variants
+//	@Description	Get all variants of a product with their options, effective price and stock
+//	@Tags			variants
+//	@Produce		json
+//	@Param			id	path		int	true	"Product ID"
+//	@Success		200	{array}		DTO.VariantResponse
+//	@Failure		400	{object}	DTO.ErrorResponse
+//	@Failure		401	{object}	DTO.ErrorResponse
+//	@Failure		404	{object}	DTO.ErrorResponse
+//	@Failure		500	{object}	DTO.ErrorResponse
+//	@Security		BearerAuth
+//	@Router			/admin/products/{id}/variants [get]
+func (s *Server) GetAllVariantHandler(c *gin.Context) {
+	productId, err := getInt64PathParam(c, "id")
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	variants, err := s.variant.GetAll(c.Request.Context(), productId)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, nonNilSlice(variants))
+}
+
+// CreateVariantHandler creates a new variant of a product
+//
+//	@Summary		Create variant
+//	@Description	Create a product variant. All variants of a product must use the same option types; price overrides the product price and must be in its currency
+//	@Tags			variants
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		int					true	"Product ID"
+//	@Param			body	body		DTO.VariantRequest	true	"Variant data"
+//	@Success		201		{object}	DTO.VariantResponse
+//	@Failure		400		{object}	DTO.ErrorResponse
+//	@Failure		401		{object}	DTO.ErrorResponse
+//	@Failure		404		{object}	DTO.ErrorResponse
+//	@Failure		409		{object}	DTO.ErrorResponse	"sku, barcode or option combination already exists"
+//	@Failure		422		{object}	DTO.ErrorResponse	"option types differ from other variants or the product has stock without a variant"
+//	@Failure		500		{object}	DTO.ErrorResponse
+//	@Security		BearerAuth
+//	@Router			/admin/products/{id}/variants [post]
+func (s *Server) CreateVariantHandler(c *gin.Context) {
+	productId, err := getInt64PathParam(c, "id")
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	request, err := bindJson[DTO.VariantRequest](c)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	variant, err := s.variant.Create(c.Request.Context(), productId, request)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, variant)
+}
+
+// GetVariantHandler returns a variant of a product
+//
+//	@Summary		Get variant
+//	@Description	Get a product variant by ID
+//	@Tags			variants
+//	@Produce		json
+//	@Param			id			path		int	true	"Product ID"
+//	@Param			variantId	path		int	true	"Variant ID"
+//	@Success		200			{object}	DTO.VariantResponse
+//	@Failure		400			{object}	DTO.ErrorResponse
+//	@Failure		401			{object}	DTO.ErrorResponse
+//	@Failure		404			{object}	DTO.ErrorResponse
+//	@Failure		500			{object}	DTO.ErrorResponse
+//	@Security		BearerAuth
+//	@Router			/admin/products/{id}/variants/{variantId} [get]
+func (s *Server) GetVariantHandler(c *gin.Context) {
+	productId, err := getInt64PathParam(c, "id")
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	id, err := getInt64PathParam(c, "variantId")
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	variant, err := s.variant.Get(c.Request.Context(), productId, id)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, variant)
+}
+
+// UpdateVariantHandler updates a variant of a product
+//
+//	@Summary		Update variant
+//	@Description	Update a product variant by ID. A change of the effective price is written to the price history
+//	@Tags			variants
+//	@Accept			json
+//	@Produce		json
+//	@Param			id			path		int					true	"Product ID"
+//	@Param			variantId	path		int					true	"Variant ID"
+//	@Param			body		body		DTO.VariantRequest	true	"Updated variant data"
+//	@Success		200			{object}	DTO.VariantResponse
+//	@Failure		400			{object}	DTO.ErrorResponse
+//	@Failure		401			{object}	DTO.ErrorResponse
+//	@Failure		404			{object}	DTO.ErrorResponse
+//	@Failure		409			{object}	DTO.ErrorResponse	"sku, barcode or option combination already exists"
+//	@Failure		422			{object}	DTO.ErrorResponse	"option types differ from other variants"
+//	@Failure		500			{object}	DTO.ErrorResponse
+//	@Security		BearerAuth
+//	@Router			/admin/products/{id}/variants/{variantId} [put]
+func (s *Server) UpdateVariantHandler(c *gin.Context) {
+	productId, err := getInt64PathParam(c, "id")
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	id, err := getInt64PathParam(c, "variantId")
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	request, err := bindJson[DTO.VariantRequest](c)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	variant, err := s.variant.Update(c.Request.Context(), productId, id, request)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, variant)
+}
+
+// DeleteVariantHandler deletes a variant of a product
+//
+//	@Summary		Delete variant
+//	@Description	Soft-delete a product variant by ID. A variant with stock can't be deleted until its stock is adjusted to zero
+//	@Tags			variants
+//	@Produce		json
+//	@Param			id			path	int	true	"Product ID"
+//	@Param			variantId	path	int	true	"Variant ID"
+//	@Success		204
+//	@Failure		400	{object}	DTO.ErrorResponse
+//	@Failure		401	{object}	DTO.ErrorResponse
+//	@Failure		404	{object}	DTO.ErrorResponse
+//	@Failure		422	{object}	DTO.ErrorResponse	"variant has stock"
+//	@Security		BearerAuth
+//	@Router			/admin/products/{id}/variants/{variantId} [delete]
+func (s *Server) DeleteVariantHandler(c *gin.Context) {
+	productId, err := getInt64PathParam(c, "id")
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	id, err := getInt64PathParam(c, "variantId")
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	_, err = s.variant.Delete(c.Request.Context(), productId, id)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// GetVariantPriceHistoryHandler returns price history for a variant
+//
+//	@Summary		Variant price history
+//	@Description	Get a page of price changes of a product variant, with the email of the admin who made each change
+//	@Tags			variants
+//	@Produce		json
+//	@Param			id			path		int	true	"Product ID"
+//	@Param			variantId		path		int		true	"Variant ID"
+//	@Param			limit			query		int		false	"Page size (1-100, default 20)"
+//	@Param			cursor			query		string	false	"Cursor from next_cursor of the previous page"
+//	@Param			order			query		string	false	"Sort order"	Enums(asc, desc)
+//	@Param			sort_by			query		string	false	"Sort field"	Enums(id, created_at)
+//	@Param			updated_by		query		int		false	"Admin user ID"
+//	@Param			created_from	query		string	false	"Changed at or after (RFC 3339)"
+//	@Param			created_to		query		string	false	"Changed at or before (RFC 3339)"
+//	@Success		200				{object}	DTO.PriceHistoryPageResponse
+//	@Failure		400				{object}	DTO.ErrorResponse
+//	@Failure		401				{object}	DTO.ErrorResponse
+//	@Failure		404				{object}	DTO.ErrorResponse
+//	@Security		BearerAuth
+//	@Router			/admin/products/{id}/variants/{variantId}/priceHistory [get]
+func (s *Server) GetVariantPriceHistoryHandler(c *gin.Context) {
+	productId, err := getInt64PathParam(c, "id")
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	id, err := getInt64PathParam(c, "variantId")
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	request, err := bindQuery[DTO.PriceHistoryListRequest](c)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	priceHistory, err := s.priceHistory.GetByVariant(c.Request.Context(), productId, id, request)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	priceHistory.Items = nonNilSlice(priceHistory.Items)
+	c.JSON(http.StatusOK, priceHistory)
+}
+
+// GetAllAttributeHandler returns attribute definitions of a category
+//
+//	@Summary		List category attributes
+//	@Description	Get attribute definitions that apply to a category, including the ones inherited from its ancestors
+//	@Tags			attributes
+//	@Produce		json
+//	@Param			id	path		int	true	"Category ID"
+//	@Success		200	{array}		DTO.AttributeDefinitionResponse
+//	@Failure		400	{object}	DTO.ErrorResponse
+//	@Failure		401	{object}	DTO.ErrorResponse
+//	@Failure		404	{object}	DTO.ErrorResponse
+//	@Failure		500	{object}	DTO.ErrorResponse
+//	@Security		BearerAuth
+//	@Router			/admin/categories/{id}/attributes [get]
+func (s *Server) GetAllAttributeHandler(c *gin.Context) {
+	categoryId, err := getInt64PathParam(c, "id")
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	attributes, err := s.attribute.GetAll(c.Request.Context(), categoryId)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, nonNilSlice(attributes))
+}
+
+// CreateAttributeHandler attaches a new attribute definition to a category
+//
+//	@Summary		Create category attribute
+//	@Description	Define a typed attribute (string, number with unit, boolean or enum) for products of a category and its subcategories
+//	@Tags			attributes
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		int								true	"Category ID"
+//	@Param			body	body		DTO.AttributeDefinitionRequest	true	"Attribute definition"
+//	@Success		201		{object}	DTO.AttributeDefinitionResponse
+//	@Failure		400		{object}	DTO.ErrorResponse
+//	@Failure		401		{object}	DTO.ErrorResponse
+//	@Failure		404		{object}	DTO.ErrorResponse
+//	@Failure		409		{object}	DTO.ErrorResponse	"code already defined in the category branch"
+//	@Failure		500		{object}	DTO.ErrorResponse
+//	@Security		BearerAuth
+//	@Router			/admin/categories/{id}/attributes [post]
+func (s *Server) CreateAttributeHandler(c *gin.Context) {
+	categoryId, err := getInt64PathParam(c, "id")
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	request, err := bindJson[DTO.AttributeDefinitionRequest](c)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	attribute, err := s.attribute.Create(c.Request.Context(), categoryId, request)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, attribute)
+}
+
+// UpdateAttributeHandler updates an attribute definition of a category
+//
+//	@Summary		Update category attribute
+//	@Description	Update an attribute definition. The type can't change while products have values, and enum values in use can't be removed
+//	@Tags			attributes
+//	@Accept			json
+//	@Produce		json
+//	@Param			id			path		int								true	"Category ID"
+//	@Param			attributeId	path		int								true	"Attribute ID"
+//	@Param			body		body		DTO.AttributeDefinitionRequest	true	"Updated attribute definition"
+//	@Success		200			{object}	DTO.AttributeDefinitionResponse
+//	@Failure		400			{object}	DTO.ErrorResponse
+//	@Failure		401			{object}	DTO.ErrorResponse
+//	@Failure		404			{object}	DTO.ErrorResponse
+//	@Failure		409			{object}	DTO.ErrorResponse	"code already defined in the category branch"
+//	@Failure		422			{object}	DTO.ErrorResponse	"change would invalidate product values"
+//	@Failure		500			{object}	DTO.ErrorResponse
+//	@Security		BearerAuth
+//	@Router			/admin/categories/{id}/attributes/{attributeId} [put]
+func (s *Server) UpdateAttributeHandler(c *gin.Context) {
+	categoryId, err := getInt64PathParam(c, "id")
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	id, err := getInt64PathParam(c, "attributeId")
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	request, err := bindJson[DTO.AttributeDefinitionRequest](c)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	attribute, err := s.attribute.Update(c.Request.Context(), categoryId, id, request)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, attribute)
+}
+
+// DeleteAttributeHandler deletes an attribute definition of a category
+//
+//	@Summary		Delete category attribute
+//	@Description	Permanently delete an attribute definition together with all product values of it
+//	@Tags			attributes
+//	@Produce		json
+//	@Param			id			path	int	true	"Category ID"
+//	@Param			attributeId	path	int	true	"Attribute ID"
+//	@Success		204
+//	@Failure		400	{object}	DTO.ErrorResponse
+//	@Failure		401	{object}	DTO.ErrorResponse
+//	@Failure		404	{object}	DTO.ErrorResponse
+//	@Security		BearerAuth
+//	@Router			/admin/categories/{id}/attributes/{attributeId} [delete]
+func (s *Server) DeleteAttributeHandler(c *gin.Context) {
+	categoryId, err := getInt64PathParam(c, "id")
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	id, err := getInt64PathParam(c, "attributeId")
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	_, err = s.attribute.Delete(c.Request.Context(), categoryId, id)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// GetProductAttributesHandler returns attribute values of a product
+//
+//	@Summary		Product attributes
+//	@Description	Get attribute values of a product
+//	@Tags			attributes
+//	@Produce		json
+//	@Param			id	path		int	true	"Product ID"
+//	@Success		200	{array}		DTO.ProductAttributeValueResponse
+//	@Failure		400	{object}	DTO.ErrorResponse
+//	@Failure		401	{object}	DTO.ErrorResponse
+//	@Failure		404	{object}	DTO.ErrorResponse
+//	@Failure		500	{object}	DTO.ErrorResponse
+//	@Security		BearerAuth
+//	@Router			/admin/products/{id}/attributes [get]
+func (s *Server) GetProductAttributesHandler(c *gin.Context) {
+	productId, err := getInt64PathParam(c, "id")
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	values, err := s.attribute.GetProductValues(c.Request.Context(), productId)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, nonNilSlice(values))
+}
+
+// SetProductAttributesHandler replaces attribute values of a product
+//
+//	@Summary		Set product attributes
+//	@Description	Replace all attribute values of a product. Values are keyed by attribute code and validated against the definitions of the product's category and its ancestors
+//	@Tags			attributes
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		int								true	"Product ID"
+//	@Param			body	body		DTO.ProductAttributesRequest	true	"Attribute values"
+//	@Success		200		{array}		DTO.ProductAttributeValueResponse
+//	@Failure		400		{object}	DTO.ErrorResponse
+//	@Failure		401		{object}	DTO.ErrorResponse
+//	@Failure		404		{object}	DTO.ErrorResponse
+//	@Failure		422		{object}	DTO.ErrorResponse	"values don't match attribute definitions"
+//	@Failure		500		{object}	DTO.ErrorResponse
+//	@Security		BearerAuth
+//	@Router			/admin/products/{id}/attributes [put]
+func (s *Server) SetProductAttributesHandler(c *gin.Context) {
+	productId, err := getInt64PathParam(c, "id")
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	request, err := bindJson[DTO.ProductAttributesRequest](c)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	values, err := s.attribute.SetProductValues(c.Request.Context(), productId, request)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, nonNilSlice(values))
+}
+
+// UploadProductMediaHandler uploads an image of a product
+//
+//	@Summary		Upload product media
+//	@Description	Upload a jpeg, png or gif image of a product as multipart form data. Thumbnails are generated, the first image becomes the primary one
+//	@Tags			media
+//	@Accept			multipart/form-data
+//	@Produce		json
+//	@Param			id		path		int		true	"Product ID"
+//	@Param			file	formData	file	true	"Image file"
+//	@Success		201		{object}	DTO.MediaResponse
+//	@Failure		400		{object}	DTO.ErrorResponse	"missing file, file too large, unsupported content type or invalid image"
+//	@Failure		401		{object}	DTO.ErrorResponse
+//	@Failure		404		{object}	DTO.ErrorResponse
+//	@Failure		500		{object}	DTO.ErrorResponse
+//	@Security		BearerAuth
+//	@Router			/admin/products/{id}/media [post]
+func (s *Server) UploadProductMediaHandler(c *gin.Context) {
+	id, err := getInt64PathParam(c, "id")
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	file, err := openFormFile(c, "file", s.c.MediaMaxSize)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	defer file.Close()
+	uploaded, err := s.media.Upload(c.Request.Context(), media.Product(id), file)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, uploaded)
+}
+
+// GetProductMediaHandler returns media of a product
+//
+//	@Summary		Get product media
+//	@Description	Get all images of a product ordered by position
+//	@Tags			media
+//	@Produce		json
+//	@Param			id	path		int	true	"Product ID"
+//	@Success		200	{array}		DTO.MediaResponse
+//	@Failure		400	{object}	DTO.ErrorResponse
+//	@Failure		401	{object}	DTO.ErrorResponse
+//	@Failure		404	{object}	DTO.ErrorResponse
+//	@Failure		500	{object}	DTO.ErrorResponse
+//	@Security		BearerAuth
+//	@Router			/admin/products/{id}/media [get]
+func (s *Server) GetProductMediaHandler(c *gin.Context) {
+	id, err := getInt64PathParam(c, "id")
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	mediaList, err := s.media.GetAll(c.Request.Context(), media.Product(id))
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, nonNilSlice(mediaList))
+}
+
+// ReorderProductMediaHandler changes the order of product media
+//
+//	@Summary		Reorder product media
+//	@Description	Set the order of the images of a product. The ids must list every image of the product exactly once
+//	@Tags			media
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		int						true	"Product ID"
+//	@Param			body	body		DTO.MediaOrderRequest	true	"Media ids in the new order"
+//	@Success		200		{array}		DTO.MediaResponse
+//	@Failure		400		{object}	DTO.ErrorResponse
+//	@Failure		401		{object}	DTO.ErrorResponse
+//	@Failure		404		{object}	DTO.ErrorResponse
+//	@Failure		422		{object}	DTO.ErrorResponse	"ids don't match the product media"
+//	@Failure		500		{object}	DTO.ErrorResponse
+//	@Security		BearerAuth
+//	@Router			/admin/products/{id}/media/order [put]
+func (s *Server) ReorderProductMediaHandler(c *gin.Context) {
+	id, err := getInt64PathParam(c, "id")
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	request, err := bindJson[DTO.MediaOrderRequest](c)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	mediaList, err := s.media.Reorder(c.Request.Context(), media.Product(id), request)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, nonNilSlice(mediaList))
+}
+
+// SetPrimaryProductMediaHandler makes an image the primary one of a product
+//
+//	@Summary		Set primary product media
+//	@Description	Make an image the primary one of a product, the previous primary image stays in the list
+//	@Tags			media
+//	@Produce		json
+//	@Param			id		path		int	true	"Product ID"
+//	@Param			mediaId	path		int	true	"Media ID"
+//	@Success		200		{array}		DTO.MediaResponse
+//	@Failure		400		{object}	DTO.ErrorResponse
+//	@Failure		401		{object}	DTO.ErrorResponse
+//	@Failure		404		{object}	DTO.ErrorResponse
+//	@Failure		500		{object}	DTO.ErrorResponse
+//	@Security		BearerAuth
+//	@Router			/admin/products/{id}/media/{mediaId}/primary [post]
+func (s *Server) SetPrimaryProductMediaHandler(c *gin.Context) {
+	id, err := getInt64PathParam(c, "id")
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	mediaId, err := getInt64PathParam(c, "mediaId")
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	mediaList, err := s.media.SetPrimary(c.Request.Context(), media.Product(id), mediaId)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, nonNilSlice(mediaList))
+}
+
+// DeleteProductMediaHandler deletes an image of a product
+//
+//	@Summary		Delete product media
+//	@Description	Delete an image of a product with its thumbnails. If it was the primary one, the first remaining image becomes primary
+//	@Tags			media
+//	@Produce		json
+//	@Param			id		path	int	true	"Product ID"
+//	@Param			mediaId	path	int	true	"Media ID"
+//	@Success		204
+//	@Failure		400	{object}	DTO.ErrorResponse
+//	@Failure		401	{object}	DTO.ErrorResponse
+//	@Failure		404	{object}	DTO.ErrorResponse
+//	@Failure		500	{object}	DTO.ErrorResponse
+//	@Security		BearerAuth
+//	@Router			/admin/products/{id}/media/{mediaId} [delete]
+func (s *Server) DeleteProductMediaHandler(c *gin.Context) {
+	id, err := getInt64PathParam(c, "id")
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	mediaId, err := getInt64PathParam(c, "mediaId")
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	err = s.media.Delete(c.Request.Context(), media.Product(id), mediaId)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// CreateBrandHandler creates a new brand
+//
+//	@Summary		Create brand
+//	@Description	Create a new brand entry. The slug is generated from the name when empty, a taken slug gets the first free -2, -3... suffix
+//	@Tags			brands
+//	@Accept			json
+//	@Produce		json
+//	@Param			body	body		DTO.BrandRequest	true	"Brand data"
+//	@Success		201		{object}	DTO.BrandResponse
+//	@Header			201		{string}	ETag	"Version of the brand"
+//	@Failure		400		{object}	DTO.ErrorResponse
+//	@Failure		401		{object}	DTO.ErrorResponse
+//	@Failure		409		{object}	DTO.ErrorResponse	"name already exists"
+//	@Failure		500		{object}	DTO.ErrorResponse
+//	@Security		BearerAuth
+//	@Router			/admin/brands [post]
+func (s *Server) CreateBrandHandler(c *gin.Context) {
+	request, err := bindJson[DTO.BrandRequest](c)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	brand, err := s.brand.Create(c.Request.Context(), request)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	respondVersioned(c, http.StatusCreated, brand.Version, brand)
+}
+
+// ExportBrandsHandler streams brands as a file
+//
+//	@Summary		Export brands
+//	@Description	Stream the brands matching the filters of the brand list in the requested format. The whole list is written in batches, limit and cursor don't apply
+//	@Tags			brands
+//	@Produce		text/csv,application/x-ndjson,application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+//	@Param			format			query		string	false	"File format, csv by default"	Enums(csv, jsonl, xlsx)
+//	@Param			order			query		string	false	"Sort order"					Enums(asc, desc)
+//	@Param			sort_by			query		string	false	"Sort field"					Enums(id, name, created_at, updated_at)
+//	@Param			created_from	query		string	false	"Created at or after (RFC 3339)"
+//	@Param			created_to		query		string	false	"Created at or before (RFC 3339)"
+//	@Param			updated_from	query		string	false	"Updated at or after (RFC 3339)"
+//	@Param			updated_to		query		string	false	"Updated at or before (RFC 3339)"
+//	@Success		200				{file}		file
+//	@Failure		400				{object}	DTO.ErrorResponse
+//	@Failure		401				{object}	DTO.ErrorResponse
+//	@Failure		500				{object}	DTO.ErrorResponse
+//	@Failure		503				{object}	DTO.ErrorResponse	"too many exports are running"
+//	@Failure		504				{object}	DTO.ErrorResponse	"export took too long"
+//	@Security		BearerAuth
+//	@Router			/admin/brands/export [get]
+func (s *Server) ExportBrandsHandler(c *gin.Context) {
+	request, err := bindQuery[DTO.BrandListRequest](c)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	respondExport(c, "brands", func(w export.Writer) *errs.AppError {
+		return s.brand.Export(c.Request.Context(), request, w)
+	})
+}
+
+// GetAllBrandHandler returns a page of brands
+//
+//	@Summary		List brands
+//	@Description	Get a filtered and sorted page of brands
+//	@Tags			brands
+//	@Produce		json
+//	@Param			limit			query		int		false	"Page size (1-100, default 20)"
+//	@Param			cursor			query		string	false	"Cursor from next_cursor of the previous page"
+//	@Param			order			query		string	false	"Sort order"	Enums(asc, desc)
+//	@Param			sort_by			query		string	false	"Sort field"	Enums(id, name, created_at, updated_at)
+//	@Param			created_from	query		string	false	"Created at or after (RFC 3339)"
+//	@Param			created_to		query		string	false	"Created at or before (RFC 3339)"
+//	@Param			updated_from	query		string	false	"Updated at or after (RFC 3339)"
+//	@Param			updated_to		query		string	false	"Updated at or before (RFC 3339)"
+//	@Success		200				{object}	DTO.BrandPageResponse
+//	@Failure		400				{object}	DTO.ErrorResponse
+//	@Failure		401				{object}	DTO.ErrorResponse
+//	@Security		BearerAuth
+//	@Router			/admin/brands [get]
+func (s *Server) GetAllBrandHandler(c *gin.Context) {
+	request, err := bindQuery[DTO.BrandListRequest](c)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	brands, err := s.brand.GetAll(c.Request.Context(), request)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	brands.Items = nonNilSlice(brands.Items)
+	c.JSON(http.StatusOK, brands)
+}
+
+// GetBrandHandler returns a brand by ID
+//
+//	@Summary		Get brand
+//	@Description	Fetch a single brand by its ID
+//	@Tags			brands
+//	@Produce		json
+//	@Param			id				path		int		true	"Brand ID"
+//	@Param			If-None-Match	header		string	false	"ETag of the cached brand"
+//	@Success		200				{object}	DTO.BrandResponse
+//	@Success		304				"Brand wasn't changed since the ETag"
+//	@Header			200				{string}	ETag	"Version of the brand"
+//	@Failure		400				{object}	DTO.ErrorResponse
+//	@Failure		401				{object}	DTO.ErrorResponse
+//	@Failure		404				{object}	DTO.ErrorResponse
+//	@Security		BearerAuth
+//	@Router			/admin/brands/{id} [get]
+func (s *Server) GetBrandHandler(c *gin.Context) {
+	id, err := getInt64PathParam(c, "id")
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	brand, err := s.brand.Get(c.Request.Context(), id)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	respondCached(c, brand.Version, brand)
+}
+
+// UpdateBrandHandler updates an existing brand
+//
+//	@Summary		Update brand
+//	@Description	Update brand data by ID. The current slug is kept when empty, a taken slug gets the first free -2, -3... suffix and the replaced one keeps resolving on the storefront
+//	@Tags			brands
+//	@Accept			json
+//	@Produce		json
+//	@Param			id			path		int					true	"Brand ID"
+//	@Param			body		body		DTO.BrandRequest	true	"Updated brand data"
+//	@Param			If-Match	header		string				false	"ETag the brand is expected to have"
+//	@Success		200			{object}	DTO.BrandResponse
+//	@Header			200			{string}	ETag	"Version of the brand"
+//	@Failure		400			{object}	DTO.ErrorResponse
+//	@Failure		401			{object}	DTO.ErrorResponse
+//	@Failure		404			{object}	DTO.ErrorResponse
+//	@Failure		409			{object}	DTO.ErrorResponse	"name already exists"
+//	@Failure		412			{object}	DTO.ErrorResponse	"brand was changed since the ETag"
+//	@Failure		500			{object}	DTO.ErrorResponse
+//	@Security		BearerAuth
+//	@Router			/admin/brands/{id} [put]
+func (s *Server) UpdateBrandHandler(c *gin.Context) {
+	id, err := getInt64PathParam(c, "id")
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	request, err := bindJson[DTO.BrandRequest](c)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	brand, err := s.brand.Update(c.Request.Context(), id, getIfMatch(c), request)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	respondVersioned(c, http.StatusOK, brand.Version, brand)
+}
+
+// PatchBrandHandler partially updates an existing brand
+//
+//	@Summary		Patch brand
+//	@Description	Update only the members present in an RFC 7396 merge patch. A null slug generates it from the name again, name can't be null. A replaced slug keeps resolving on the storefront
+//	@Tags			brands
+//	@Accept			json
+//	@Accept			application/merge-patch+json
+//	@Produce		json
+//	@Param			id			path		int						true	"Brand ID"
+//	@Param			body		body		DTO.BrandPatchRequest	true	"Merge patch of the brand"
+//	@Param			If-Match	header		string					false	"ETag the brand is expected to have"
+//	@Success		200			{object}	DTO.BrandResponse
+//	@Header			200			{string}	ETag	"Version of the brand"
+//	@Failure		400			{object}	DTO.ErrorResponse
+//	@Failure		401			{object}	DTO.ErrorResponse
+//	@Failure		404			{object}	DTO.ErrorResponse
+//	@Failure		409			{object}	DTO.ErrorResponse	"name already exists"
+//	@Failure		412			{object}	DTO.ErrorResponse	"brand was changed since the ETag"
+//	@Failure		500			{object}	DTO.ErrorResponse
+//	@Security		BearerAuth
+//	@Router			/admin/brands/{id} [patch]
+func (s *Server) PatchBrandHandler(c *gin.Context) {
+	id, err := getInt64PathParam(c, "id")
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	patch, err := bindMergePatch[DTO.BrandPatchRequest](c)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	brand, err := s.brand.Patch(c.Request.Context(), id, getIfMatch(c), patch)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	respondVersioned(c, http.StatusOK, brand.Version, brand)
+}
+
+// DeleteBrandHandler deletes a brand by ID
+//
+//	@Summary		Delete brand
+//	@Description	Permanently delete a brand by its ID
+//	@Tags			brands
+//	@Produce		json
+//	@Param			id			path	int		true	"Brand ID"
+//	@Param			If-Match	header	string	false	"ETag the brand is expected to have"
+//	@Success		204
+//	@Failure		400	{object}	DTO.ErrorResponse
+//	@Failure		401	{object}	DTO.ErrorResponse
+//	@Failure		404	{object}	DTO.ErrorResponse
+//	@Failure		412	{object}	DTO.ErrorResponse	"brand was changed since the ETag"
+//	@Security		BearerAuth
+//	@Router			/admin/brands/{id} [delete]
+func (s *Server) DeleteBrandHandler(c *gin.Context) {
+	id, err := getInt64PathParam(c, "id")
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	_, err = s.brand.Delete(c.Request.Context(), id, getIfMatch(c))
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// GetTrashedBrandHandler returns a page of soft-deleted brands
+//
+//	@Summary		List trashed brands
+//	@Description	Get a page of soft-deleted brands
+//	@Tags			brands
+//	@Produce		json
+//	@Param			limit	query		int		false	"Page size (1-100, default 20)"
+//	@Param			cursor	query		string	false	"Cursor from next_cursor of the previous page"
+//	@Param			sort_by	query		string	false	"Sort field"	Enums(id, deleted_at)
+//	@Param			order	query		string	false	"Sort order"	Enums(asc, desc)
+//	@Success		200		{object}	DTO.TrashedBrandPageResponse
+//	@Failure		400		{object}	DTO.ErrorResponse
+//	@Failure		401		{object}	DTO.ErrorResponse
+//	@Failure		500		{object}	DTO.ErrorResponse
+//	@Security		BearerAuth
+//	@Router			/admin/brands/trash [get]
+func (s *Server) GetTrashedBrandHandler(c *gin.Context) {
+	request, err := bindQuery[DTO.PageRequest](c)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	brands, err := s.brand.GetTrash(c.Request.Context(), request)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	brands.Items = nonNilSlice(brands.Items)
+	c.JSON(http.StatusOK, brands)
+}
+
+// RestoreBrandHandler restores a soft-deleted brand
+//
+//	@Summary		Restore brand
+//	@Description	Restore a soft-deleted brand together with the products its deletion cascaded to
+//	@Tags			brands
+//	@Produce		json
+//	@Param			id	path		int	true	"Brand ID"
+//	@Success		200	{object}	DTO.BrandRestoreResponse
+//	@Failure		400	{object}	DTO.ErrorResponse
+//	@Failure		401	{object}	DTO.ErrorResponse
+//	@Failure		404	{object}	DTO.ErrorResponse	"brand not found in trash"
+//	@Failure		500	{object}	DTO.ErrorResponse
+//	@Security		BearerAuth
+//	@Router			/admin/brands/{id}/restore [post]
+func (s *Server) RestoreBrandHandler(c *gin.Context) {
+	id, err := getInt64PathParam(c, "id")
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	response, err := s.brand.Restore(c.Request.Context(), id)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, response)
+}
+
+// UploadBrandMediaHandler uploads an image of a brand
+//
+//	@Summary		Upload brand media
+//	@Description	Upload a jpeg, png or gif image of a brand as multipart form data. Thumbnails are generated, the first image becomes the primary one
+//	@Tags			media
+//	@Accept			multipart/form-data
+//	@Produce		json
+//	@Param			id		path		int		true	"Brand ID"
+//	@Param			file	formData	file	true	"Image file"
+//	@Success		201		{object}	DTO.MediaResponse
+//	@Failure		400		{object}	DTO.ErrorResponse	"missing file, file too large, unsupported content type or invalid image"
+//	@Failure		401		{object}	DTO.ErrorResponse
+//	@Failure		404		{object}	DTO.ErrorResponse
+//	@Failure		500		{object}	DTO.ErrorResponse
+//	@Security		BearerAuth
+//	@Router			/admin/brands/{id}/media [post]
+func (s *Server) UploadBrandMediaHandler(c *gin.Context) {
+	id, err := getInt64PathParam(c, "id")
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	file, err := openFormFile(c, "file", s.c.MediaMaxSize)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	defer file.Close()
+	uploaded, err := s.media.Upload(c.Request.Context(), media.Brand(id), file)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, uploaded)
+}
+
+// GetBrandMediaHandler returns media of a brand
+//
+//	@Summary		Get brand media
+//	@Description	Get all images of a brand ordered by position
+//	@Tags			media
+//	@Produce		json
+//	@Param			id	path		int	true	"Brand ID"
+//	@Success		200	{array}		DTO.MediaResponse
+//	@Failure		400	{object}	DTO.ErrorResponse
+//	@Failure		401	{object}	DTO.ErrorResponse
+//	@Failure		404	{object}	DTO.ErrorResponse
+//	@Failure		500	{object}	DTO.ErrorResponse
+//	@Security		BearerAuth
+//	@Router			/admin/brands/{id}/media [get]
+func (s *Server) GetBrandMediaHandler(c *gin.Context) {
+	id, err := getInt64PathParam(c, "id")
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	mediaList, err := s.media.GetAll(c.Request.Context(), media.Brand(id))
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, nonNilSlice(mediaList))
+}
+
+// ReorderBrandMediaHandler changes the order of brand media
+//
+//	@Summary		Reorder brand media
+//	@Description	Set the order of the images of a brand. The ids must list every image of the brand exactly once
+//	@Tags			media
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		int						true	"Brand ID"
+//	@Param			body	body		DTO.MediaOrderRequest	true	"Media ids in the new order"
+//	@Success		200		{array}		DTO.MediaResponse
+//	@Failure		400		{object}	DTO.ErrorResponse
+//	@Failure		401		{object}	DTO.ErrorResponse
+//	@Failure		404		{object}	DTO.ErrorResponse
+//	@Failure		422		{object}	DTO.ErrorResponse	"ids don't match the brand media"
+//	@Failure		500		{object}	DTO.ErrorResponse
+//	@Security		BearerAuth
+//	@Router			/admin/brands/{id}/media/order [put]
+func (s *Server) ReorderBrandMediaHandler(c *gin.Context) {
+	id, err := getInt64PathParam(c, "id")
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	request, err := bindJson[DTO.MediaOrderRequest](c)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	mediaList, err := s.media.Reorder(c.Request.Context(), media.Brand(id), request)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, nonNilSlice(mediaList))
+}
+
+// SetPrimaryBrandMediaHandler makes an image the primary one of a brand
+//
+//	@Summary		Set primary brand media
+//	@Description	Make an image the primary one of a brand, the previous primary image stays in the list
+//	@Tags			media
+//	@Produce		json
+//	@Param			id		path		int	true	"Brand ID"
+//	@Param			mediaId	path		int	true	"Media ID"
+//	@Success		200		{array}		DTO.MediaResponse
+//	@Failure		400		{object}	DTO.ErrorResponse
+//	@Failure		401		{object}	DTO.ErrorResponse
+//	@Failure		404		{object}	DTO.ErrorResponse
+//	@Failure		500		{object}	DTO.ErrorResponse
+//	@Security		BearerAuth
+//	@Router			/admin/brands/{id}/media/{mediaId}/primary [post]
+func (s *Server) SetPrimaryBrandMediaHandler(c *gin.Context) {
+	id, err := getInt64PathParam(c, "id")
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	mediaId, err := getInt64PathParam(c, "mediaId")
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	mediaList, err := s.media.SetPrimary(c.Request.Context(), media.Brand(id), mediaId)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, nonNilSlice(mediaList))
+}
+
+// DeleteBrandMediaHandler deletes an image of a brand
+//
+//	@Summary		Delete brand media
+//	@Description	Delete an image of a brand with its thumbnails. If it was the primary one, the first remaining image becomes primary
+//	@Tags			media
+//	@Produce		json
+//	@Param			id		path	int	true	"Brand ID"
+//	@Param			mediaId	path	int	true	"Media ID"
+//	@Success		204
+//	@Failure		400	{object}	DTO.ErrorResponse
+//	@Failure		401	{object}	DTO.ErrorResponse
+//	@Failure		404	{object}	DTO.ErrorResponse
+//	@Failure		500	{object}	DTO.ErrorResponse
+//	@Security		BearerAuth
+//	@Router			/admin/brands/{id}/media/{mediaId} [delete]
+func (s *Server) DeleteBrandMediaHandler(c *gin.Context) {
+	id, err := getInt64PathParam(c, "id")
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	mediaId, err := getInt64PathParam(c, "mediaId")
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	err = s.media.Delete(c.Request.Context(), media.Brand(id), mediaId)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// CreateCategoryHandler creates a new category
+//
+//	@Summary		Create category
+//	@Description	Create a new category entry. The slug is generated from the name when empty, a taken slug gets the first free -2, -3... suffix
+//	@Tags			categories
+//	@Accept			json
+//	@Produce		json
+//	@Param			body	body		DTO.CategoryRequest	true	"Category data"
+//	@Success		201		{object}	DTO.CategoryResponse
+//	@Header			201		{string}	ETag	"Version of the category"
+//	@Failure		400		{object}	DTO.ErrorResponse
+//	@Failure		401		{object}	DTO.ErrorResponse
+//	@Failure		409		{object}	DTO.ErrorResponse	"slug was taken by a concurrent request"
+//	@Failure		422		{object}	DTO.ErrorResponse	"category cycle or tree depth limit exceeded"
+//	@Failure		500		{object}	DTO.ErrorResponse
+//	@Security		BearerAuth
+//	@Router			/admin/categories [post]
+func (s *Server) CreateCategoryHandler(c *gin.Context) {
+	request, err := bindJson[DTO.CategoryRequest](c)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	category, err := s.category.Create(c.Request.Context(), request)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	respondVersioned(c, http.StatusCreated, category.Version, category)
+}
+
+// ExportCategoriesHandler streams categories as a file
+//
+//	@Summary		Export categories
+//	@Description	Stream the categories matching the filters of the category list in the requested format. The whole list is written in batches, limit and cursor don't apply
+//	@Tags			categories
+//	@Produce		text/csv,application/x-ndjson,application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+//	@Param			format			query		string	false	"File format, csv by default"	Enums(csv, jsonl, xlsx)
+//	@Param			order			query		string	false	"Sort order"					Enums(asc, desc)
+//	@Param			sort_by			query		string	false	"Sort field"					Enums(id, name, created_at, updated_at)
+//	@Param			parent_id		query		int		false	"Parent category ID"
+//	@Param			created_from	query		string	false	"Created at or after (RFC 3339)"
+//	@Param			created_to		query		string	false	"Created at or before (RFC 3339)"
+//	@Param			updated_from	query		string	false	"Updated at or after (RFC 3339)"
+//	@Param			updated_to		query		string	false	"Updated at or before (RFC 3339)"
+//	@Success		200				{file}		file
+//	@Failure		400				{object}	DTO.ErrorResponse
+//	@Failure		401				{object}	DTO.ErrorResponse
+//	@Failure		500				{object}	DTO.ErrorResponse
+//	@Failure		503				{object}	DTO.ErrorResponse	"too many exports are running"
+//	@Failure		504				{object}	DTO.ErrorResponse	"export took too long"
+//	@Security		BearerAuth
+//	@Router			/admin/categories/export [get]
+func (s *Server) ExportCategoriesHandler(c *gin.Context) {
+	request, err := bindQuery[DTO.CategoryListRequest](c)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	respondExport(c, "categories", func(w export.Writer) *errs.AppError {
+		return s.category.Export(c.Request.Context(), request, w)
+	})
+}
+
+// GetAllCategoryHandler returns a page of categories
+//
+//	@Summary		List categories
+//	@Description	Get a filtered and sorted page of categories
+//	@Tags			categories
+//	@Produce		json
+//	@Param			limit			query		int		false	"Page size (1-100, default 20)"
+//	@Param			cursor			query		string	false	"Cursor from next_cursor of the previous page"
+//	@Param			order			query		string	false	"Sort order"	Enums(asc, desc)
+//	@Param			sort_by			query		string	false	"Sort field"	Enums(id, name, created_at, updated_at)
+//	@Param			parent_id		query		int		false	"Parent category ID"
+//	@Param			created_from	query		string	false	"Created at or after (RFC 3339)"
+//	@Param			created_to		query		string	false	"Created at or before (RFC 3339)"
+//	@Param			updated_from	query		string	false	"Updated at or after (RFC 3339)"
+//	@Param			updated_to		query		string	false	"Updated at or before (RFC 3339)"
+//	@Success		200				{object}	DTO.CategoryPageResponse
+//	@Failure		400				{object}	DTO.ErrorResponse
+//	@Failure		401				{object}	DTO.ErrorResponse
+//	@Security		BearerAuth
+//	@Router			/admin/categories [get]
+func (s *Server) GetAllCategoryHandler(c *gin.Context) {
+	request, err := bindQuery[DTO.CategoryListRequest](c)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	categories, err := s.category.GetAll(c.Request.Context(), request)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	categories.Items = nonNilSlice(categories.Items)
+	c.JSON(http.StatusOK, categories)
+}
+
+// GetCategoryTreeHandler returns the whole category tree
+//
+//	@Summary		Category tree
+//	@Description	Get all categories nested under their parents
+//	@Tags			categories
+//	@Produce		json
+//	@Success		200	{array}		DTO.CategoryTreeResponse
+//	@Failure		401	{object}	DTO.ErrorResponse
+//	@Failure		500	{object}	DTO.ErrorResponse
+//	@Security		BearerAuth
+//	@Router			/admin/categories/tree [get]
+func (s *Server) GetCategoryTreeHandler(c *gin.Context) {
+	tree, err := s.category.GetTree(c.Request.Context())
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, nonNilSlice(tree))
+}
+
+// GetCategoryHandler returns a category by ID
+//
+//	@Summary		Get category
+//	@Description	Fetch a single category by its ID
+//	@Tags			categories
+//	@Produce		json
+//	@Param			id				path		int		true	"Category ID"
+//	@Param			If-None-Match	header		string	false	"ETag of the cached category"
+//	@Success		200				{object}	DTO.CategoryResponse
+//	@Success		304				"Category wasn't changed since the ETag"
+//	@Header			200				{string}	ETag	"Version of the category"
+//	@Failure		400				{object}	DTO.ErrorResponse
+//	@Failure		401				{object}	DTO.ErrorResponse
+//	@Failure		404				{object}	DTO.ErrorResponse
+//	@Security		BearerAuth
+//	@Router			/admin/categories/{id} [get]
+func (s *Server) GetCategoryHandler(c *gin.Context) {
+	id, err := getInt64PathParam(c, "id")
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	category, err := s.category.Get(c.Request.Context(), id)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	respondCached(c, category.Version, category)
+}
+
+// UpdateCategoryHandler updates an existing category
+//
+//	@Summary		Update category
+//	@Description	Update category data by ID. The current slug is kept when empty, a taken slug gets the first free -2, -3... suffix and the replaced one keeps resolving on the storefront
+//	@Tags			categories
+//	@Accept			json
+//	@Produce		json
+//	@Param			id			path		int					true	"Category ID"
+//	@Param			body		body		DTO.CategoryRequest	true	"Updated category data"
+//	@Param			If-Match	header		string				false	"ETag the category is expected to have"
+//	@Success		200			{object}	DTO.CategoryResponse
+//	@Header			200			{string}	ETag	"Version of the category"
+//	@Failure		400			{object}	DTO.ErrorResponse
+//	@Failure		401			{object}	DTO.ErrorResponse
+//	@Failure		404			{object}	DTO.ErrorResponse
+//	@Failure		409			{object}	DTO.ErrorResponse	"slug was taken by a concurrent request or an attribute code of the subtree is defined in the new parents"
+//	@Failure		412			{object}	DTO.ErrorResponse	"category was changed since the ETag"
+//	@Failure		422			{object}	DTO.ErrorResponse	"category cycle or tree depth limit exceeded"
+//	@Failure		500			{object}	DTO.ErrorResponse
+//	@Security		BearerAuth
+//	@Router			/admin/categories/{id} [put]
+func (s *Server) UpdateCategoryHandler(c *gin.Context) {
+	id, err := getInt64PathParam(c, "id")
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	request, err := bindJson[DTO.CategoryRequest](c)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	category, err := s.category.Update(c.Request.Context(), id, getIfMatch(c), request)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	respondVersioned(c, http.StatusOK, category.Version, category)
+}
+
+// MoveCategoryHandler moves a category with its subtree under another parent
+//
+//	@Summary		Move category
+//	@Description	Atomically move a category together with its whole subtree under a new parent, or to the root when parent_id is null. The products of the subtree lose the values of attributes inherited from the old parents
+//	@Tags			categories
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		int							true	"Category ID"
+//	@Param			body	body		DTO.CategoryMoveRequest	true	"New parent"
+//	@Success		200		{object}	DTO.CategoryResponse
+//	@Failure		400		{object}	DTO.ErrorResponse
+//	@Failure		401		{object}	DTO.ErrorResponse
+//	@Failure		404		{object}	DTO.ErrorResponse	"category or parent category not found"
+//	@Failure		409		{object}	DTO.ErrorResponse	"an attribute code of the subtree is defined in the new parents"
+//	@Failure		422		{object}	DTO.ErrorResponse	"category cycle or tree depth limit exceeded"
+//	@Failure		500		{object}	DTO.ErrorResponse
+//	@Security		BearerAuth
+//	@Router			/admin/categories/{id}/move [post]
+func (s *Server) MoveCategoryHandler(c *gin.Context) {
+	id, err := getInt64PathParam(c, "id")
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	request, err := bindJson[DTO.CategoryMoveRequest](c)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	category, err := s.category.Move(c.Request.Context(), id, request)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, category)
+}
+
+// PatchCategoryHandler partially updates an existing category
+//
+//	@Summary		Patch category
+//	@Description	Update only the members present in an RFC 7396 merge patch. A null parent_id makes the category a root one, a null slug generates it from the name again, name can't be null. A replaced slug keeps resolving on the storefront
+//	@Tags			categories
+//	@Accept			json
+//	@Accept			application/merge-patch+json
+//	@Produce		json
+//	@Param			id			path		int							true	"Category ID"
+//	@Param			body		body		DTO.CategoryPatchRequest	true	"Merge patch of the category"
+//	@Param			If-Match	header		string						false	"ETag the category is expected to have"
+//	@Success		200			{object}	DTO.CategoryResponse
+//	@Header			200			{string}	ETag	"Version of the category"
+//	@Failure		400			{object}	DTO.ErrorResponse
+//	@Failure		401			{object}	DTO.ErrorResponse
+//	@Failure		404			{object}	DTO.ErrorResponse
+//	@Failure		409			{object}	DTO.ErrorResponse	"slug was taken by a concurrent request or an attribute code of the subtree is defined in the new parents"
+//	@Failure		412			{object}	DTO.ErrorResponse	"category was changed since the ETag"
+//	@Failure		422			{object}	DTO.ErrorResponse	"category cycle or tree depth limit exceeded"
+//	@Failure		500			{object}	DTO.ErrorResponse
+//	@Security		BearerAuth
+//	@Router			/admin/categories/{id} [patch]
+func (s *Server) PatchCategoryHandler(c *gin.Context) {
+	id, err := getInt64PathParam(c, "id")
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	patch, err := bindMergePatch[DTO.CategoryPatchRequest](c)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	category, err := s.category.Patch(c.Request.Context(), id, getIfMatch(c), patch)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	respondVersioned(c, http.StatusOK, category.Version, category)
+}
+
+// DeleteCategoryHandler deletes a category by ID
+//
+//	@Summary		Delete category
+//	@Description	Permanently delete a category by its ID
+//	@Tags			categories
+//	@Produce		json
+//	@Param			id			path	int		true	"Category ID"
+//	@Param			If-Match	header	string	false	"ETag the category is expected to have"
+//	@Success		204
+//	@Failure		400	{object}	DTO.ErrorResponse
+//	@Failure		401	{object}	DTO.ErrorResponse
+//	@Failure		404	{object}	DTO.ErrorResponse
+//	@Failure		412	{object}	DTO.ErrorResponse	"category was changed since the ETag"
+//	@Security		BearerAuth
+//	@Router			/admin/categories/{id} [delete]
+func (s *Server) DeleteCategoryHandler(c *gin.Context) {
+	id, err := getInt64PathParam(c, "id")
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	_, err = s.category.Delete(c.Request.Context(), id, getIfMatch(c))
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// GetTrashedCategoryHandler returns a page of soft-deleted categories
+//
+//	@Summary		List trashed categories
+//	@Description	Get a page of soft-deleted categories
+//	@Tags			categories
+//	@Produce		json
+//	@Param			limit	query		int		false	"Page size (1-100, default 20)"
+//	@Param			cursor	query		string	false	"Cursor from next_cursor of the previous page"
+//	@Param			sort_by	query		string	false	"Sort field"	Enums(id, deleted_at)
+//	@Param			order	query		string	false	"Sort order"	Enums(asc, desc)
+//	@Success		200		{object}	DTO.TrashedCategoryPageResponse
+//	@Failure		400		{object}	DTO.ErrorResponse
+//	@Failure		401		{object}	DTO.ErrorResponse
+//	@Failure		500		{object}	DTO.ErrorResponse
+//	@Security		BearerAuth
+//	@Router			/admin/categories/trash [get]
+func (s *Server) GetTrashedCategoryHandler(c *gin.Context) {
+	request, err := bindQuery[DTO.PageRequest](c)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	categories, err := s.category.GetTrash(c.Request.Context(), request)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	categories.Items = nonNilSlice(categories.Items)
+	c.JSON(http.StatusOK, categories)
+}
+
+// RestoreCategoryHandler restores a soft-deleted category
+//
+//	@Summary		Restore category
+//	@Description	Restore a soft-deleted category together with the subcategories and products its deletion cascaded to
+//	@Tags			categories
+//	@Produce		json
+//	@Param			id	path		int	true	"Category ID"
+//	@Success		200	{object}	DTO.CategoryRestoreResponse
+//	@Failure		400	{object}	DTO.ErrorResponse
+//	@Failure		401	{object}	DTO.ErrorResponse
+//	@Failure		404	{object}	DTO.ErrorResponse	"category not found in trash"
+//	@Failure		422	{object}	DTO.ErrorResponse	"parent category is deleted"
+//	@Failure		500	{object}	DTO.ErrorResponse
+//	@Security		BearerAuth
+//	@Router			/admin/categories/{id}/restore [post]
+func (s *Server) RestoreCategoryHandler(c *gin.Context) {
+	id, err := getInt64PathParam(c, "id")
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	response, err := s.category.Restore(c.Request.Context(), id)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, response)
+}
+
+// CreateInventoryMovementHandler records an inventory movement
+//
+//	@Summary		Adjust inventory
+//	@Description	Record a signed stock movement for a product or one of its variants. Products with variants require variant_id. Movements that would drive the stock negative are rejected
+//	@Tags			inventory
+//	@Accept			json
+//	@Produce		json
+//	@Param			body	body		DTO.InventoryMovementRequest	true	"Movement data"
+//	@Success		201		{object}	DTO.InventoryMovementResponse
+//	@Failure		400		{object}	DTO.ErrorResponse
+//	@Failure		401		{object}	DTO.ErrorResponse
+//	@Failure		404		{object}	DTO.ErrorResponse	"product or variant not found"
+//	@Failure		422		{object}	DTO.ErrorResponse	"not enough stock or variant_id required"
+//	@Failure		500		{object}	DTO.ErrorResponse
+//	@Security		BearerAuth
+//	@Router			/admin/inventory/adjustments [post]
+func (s *Server) CreateInventoryMovementHandler(c *gin.Context) {
+	request, err := bindJson[DTO.InventoryMovementRequest](c)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	movement, err := s.inventory.Create(c.Request.Context(), request)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, movement)
+}
+
+// CreatePriceScheduleHandler schedules a price change
+//
+//	@Summary		Create price schedule
+//	@Description	Schedule a new price for a product, a brand or a category with its subtree, optionally reverted at ends_at. The change is written to the price history on behalf of the creator
+//	@Tags			priceSchedules
+//	@Accept			json
+//	@Produce		json
+//	@Param			body	body		DTO.PriceScheduleRequest	true	"Price schedule data"
+//	@Success		201		{object}	DTO.PriceScheduleResponse
+//	@Failure		400		{object}	DTO.ErrorResponse
+//	@Failure		401		{object}	DTO.ErrorResponse
+//	@Failure		404		{object}	DTO.ErrorResponse	"product, brand or category not found"
+//	@Failure		500		{object}	DTO.ErrorResponse
+//	@Security		BearerAuth
+//	@Router			/admin/priceSchedules [post]
+func (s *Server) CreatePriceScheduleHandler(c *gin.Context) {
+	request, err := bindJson[DTO.PriceScheduleRequest](c)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	schedule, err := s.schedule.Create(c.Request.Context(), request)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, schedule)
+}
+
+// GetAllPriceScheduleHandler returns a page of price schedules
+//
+//	@Summary		List price schedules
+//	@Description	Get a filtered and sorted page of price schedules
+//	@Tags			priceSchedules
+//	@Produce		json
+//	@Param			limit		query		int		false	"Page size (1-100, default 20)"
+//	@Param			cursor		query		string	false	"Cursor from next_cursor of the previous page"
+//	@Param			order		query		string	false	"Sort order"	Enums(asc, desc)
+//	@Param			sort_by		query		string	false	"Sort field"	Enums(id, starts_at)
+//	@Param			status		query		string	false	"Schedule status"	Enums(pending, applied, completed, failed)
+//	@Param			product_id	query		int		false	"Product ID"
+//	@Param			brand_id	query		int		false	"Brand ID"
+//	@Param			category_id	query		int		false	"Category ID"
+//	@Success		200			{object}	DTO.PriceSchedulePageResponse
+//	@Failure		400			{object}	DTO.ErrorResponse
+//	@Failure		401			{object}	DTO.ErrorResponse
+//	@Security		BearerAuth
+//	@Router			/admin/priceSchedules [get]
+func (s *Server) GetAllPriceScheduleHandler(c *gin.Context) {
+	request, err := bindQuery[DTO.PriceScheduleListRequest](c)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	schedules, err := s.schedule.GetAll(c.Request.Context(), request)
 	if err != nil {
 		respondError(c, err)
 		return
 	}
-	c.JSON(http.StatusOK, nonNilSlice(products))
+	schedules.Items = nonNilSlice(schedules.Items)
+	c.JSON(http.StatusOK, schedules)
 }
 
-// GetProductHandler returns a product by ID
+// GetPriceScheduleHandler returns a price schedule by ID
 //
-//	@Summary		Get product
-//	@Description	Fetch a single product by its ID
-//	@Tags			products
+//	@Summary		Get price schedule
+//	@Description	Fetch a single price schedule by its ID
+//	@Tags			priceSchedules
 //	@Produce		json
-//	@Param			id	path		int	true	"Product ID"
-//	@Success		200	{object}	DTO.ProductResponse
+//	@Param			id	path		int	true	"Price schedule ID"
+//	@Success		200	{object}	DTO.PriceScheduleResponse
 //	@Failure		400	{object}	DTO.ErrorResponse
 //	@Failure		401	{object}	DTO.ErrorResponse
 //	@Failure		404	{object}	DTO.ErrorResponse
-//	@Failure		500	{object}	DTO.ErrorResponse
 //	@Security		BearerAuth
-//	@Router			/admin/products/{id} [get]
-func (s *Server) GetProductHandler(c *gin.Context) {
+//	@Router			/admin/priceSchedules/{id} [get]
+func (s *Server) GetPriceScheduleHandler(c *gin.Context) {
 	id, err := getInt64PathParam(c, "id")
 	if err != nil {
 		respondError(c, err)
 		return
 	}
-	product, err := s.product.Get(c.Request.Context(), id)
+	schedule, err := s.schedule.Get(c.Request.Context(), id)
 	if err != nil {
 		respondError(c, err)
 		return
 	}
-	c.JSON(http.StatusOK, product)
+	c.JSON(http.StatusOK, schedule)
 }
 
-// UpdateProductHandler updates an existing product
+// UpdatePriceScheduleHandler updates a pending price schedule
 //
-//	@Summary		Update product
-//	@Description	Update product data by ID
-//	@Tags			products
+//	@Summary		Update price schedule
+//	@Description	Update a price schedule by ID while it is still pending or has failed to apply. A failed schedule is queued again
+//	@Tags			priceSchedules
 //	@Accept			json
 //	@Produce		json
-//	@Param			id		path		int					true	"Product ID"
-//	@Param			body	body		DTO.ProductRequest	true	"Updated product data"
-//	@Success		200		{object}	DTO.ProductResponse
+//	@Param			id		path		int							true	"Price schedule ID"
+//	@Param			body	body		DTO.PriceScheduleRequest	true	"Updated price schedule data"
+//	@Success		200		{object}	DTO.PriceScheduleResponse
 //	@Failure		400		{object}	DTO.ErrorResponse
 //	@Failure		401		{object}	DTO.ErrorResponse
 //	@Failure		404		{object}	DTO.ErrorResponse
-//	@Failure		409		{object}	DTO.ErrorResponse	"slug or name already exists"
-//	@Failure		422		{object}	DTO.ErrorResponse	"brand or category not found"
+//	@Failure		422		{object}	DTO.ErrorResponse	"schedule is already applied"
 //	@Failure		500		{object}	DTO.ErrorResponse
 //	@Security		BearerAuth
-//	@Router			/admin/products/{id} [put]
-func (s *Server) UpdateProductHandler(c *gin.Context) {
+//	@Router			/admin/priceSchedules/{id} [put]
+func (s *Server) UpdatePriceScheduleHandler(c *gin.Context) {
 	id, err := getInt64PathParam(c, "id")
 	if err != nil {
 		respondError(c, err)
 		return
 	}
-	request, err := bindJson[DTO.ProductRequest](c)
+	request, err := bindJson[DTO.PriceScheduleRequest](c)
 	if err != nil {
 		respondError(c, err)
 		return
 	}
-	product, err := s.product.Update(c.Request.Context(), id, request)
+	schedule, err := s.schedule.Update(c.Request.Context(), id, request)
 	if err != nil {
 		respondError(c, err)
 		return
 	}
-	c.JSON(http.StatusOK, product)
+	c.JSON(http.StatusOK, schedule)
 }
 
-// DeleteProductHandler deletes a product by ID
+// DeletePriceScheduleHandler cancels a pending or failed price schedule
 //
-//	@Summary		Delete product
-//	@Description	Permanently delete a product by its ID
-//	@Tags			products
+//	@Summary		Delete price schedule
+//	@Description	Cancel a price schedule by ID while it is still pending or has failed. Cancelling a schedule that failed to revert leaves its price on the products
+//	@Tags			priceSchedules
 //	@Produce		json
-//	@Param			id	path	int	true	"Product ID"
+//	@Param			id	path	int	true	"Price schedule ID"
 //	@Success		204
 //	@Failure		400	{object}	DTO.ErrorResponse
 //	@Failure		401	{object}	DTO.ErrorResponse
 //	@Failure		404	{object}	DTO.ErrorResponse
+//	@Failure		422	{object}	DTO.ErrorResponse	"schedule is already applied"
 //	@Security		BearerAuth
-//	@Router			/admin/products/{id} [delete]
-func (s *Server) DeleteProductHandler(c *gin.Context) {
+//	@Router			/admin/priceSchedules/{id} [delete]
+func (s *Server) DeletePriceScheduleHandler(c *gin.Context) {
 	id, err := getInt64PathParam(c, "id")
 	if err != nil {
 		respondError(c, err)
 		return
 	}
-	_, err = s.product.Delete(c.Request.Context(), id)
+	_, err = s.schedule.Delete(c.Request.Context(), id)
 	if err != nil {
 		respondError(c, err)
 		return
@@ -258,164 +2374,180 @@ func (s *Server) DeleteProductHandler(c *gin.Context) {
 	c.Status(http.StatusNoContent)
 }
 
-// GetProductPriceHistory returns price history for a product
+// RetryPriceScheduleHandler queues a failed price schedule again
 //
-//	@Summary		Product price history
-//	@Description	Get the full price change history for a product by ID
-//	@Tags			products
+//	@Summary		Retry price schedule
+//	@Description	Put a failed price schedule back in the status it failed in, so the scheduler applies or reverts it again on its next run
+//	@Tags			priceSchedules
 //	@Produce		json
-//	@Param			id	path		int	true	"Product ID"
-//	@Success		200	{array}		queries.ProductPriceHistory
+//	@Param			id	path		int	true	"Price schedule ID"
+//	@Success		200	{object}	DTO.PriceScheduleResponse
 //	@Failure		400	{object}	DTO.ErrorResponse
 //	@Failure		401	{object}	DTO.ErrorResponse
 //	@Failure		404	{object}	DTO.ErrorResponse
+//	@Failure		422	{object}	DTO.ErrorResponse	"schedule hasn't failed"
+//	@Failure		500	{object}	DTO.ErrorResponse
 //	@Security		BearerAuth
-//	@Router			/admin/products/{id}/priceHistory [get]
-func (s *Server) GetProductPriceHistory(c *gin.Context) {
+//	@Router			/admin/priceSchedules/{id}/retry [post]
+func (s *Server) RetryPriceScheduleHandler(c *gin.Context) {
 	id, err := getInt64PathParam(c, "id")
 	if err != nil {
 		respondError(c, err)
 		return
 	}
-	priceHistory, err := s.product.GetPriceHistory(c.Request.Context(), id)
+	schedule, err := s.schedule.Retry(c.Request.Context(), id)
 	if err != nil {
 		respondError(c, err)
 		return
 	}
-	c.JSON(http.StatusOK, nonNilSlice(priceHistory))
+	c.JSON(http.StatusOK, schedule)
 }
 
-// CreateBrandHandler creates a new brand
+// CreatePromotionHandler creates a promotion
 //
-//	@Summary		Create brand
-//	@Description	Create a new brand entry
-//	@Tags			brands
+//	@Summary		Create promotion
+//	@Description	Create a percent or fixed discount for a product, a brand or a category with its subtree. Of the promotions in effect the one with the highest priority applies, stackable promotions add up only when the top one is stackable too
+//	@Tags			promotions
 //	@Accept			json
 //	@Produce		json
-//	@Param			body	body		DTO.BrandRequest	true	"Brand data"
-//	@Success		201		{object}	DTO.BrandResponse
+//	@Param			body	body		DTO.PromotionRequest	true	"Promotion data"
+//	@Success		201		{object}	DTO.PromotionResponse
 //	@Failure		400		{object}	DTO.ErrorResponse
 //	@Failure		401		{object}	DTO.ErrorResponse
-//	@Failure		409		{object}	DTO.ErrorResponse	"name or slug already exists"
+//	@Failure		404		{object}	DTO.ErrorResponse	"product, brand or category not found"
 //	@Failure		500		{object}	DTO.ErrorResponse
 //	@Security		BearerAuth
-//	@Router			/admin/brands [post]
-func (s *Server) CreateBrandHandler(c *gin.Context) {
-	request, err := bindJson[DTO.BrandRequest](c)
+//	@Router			/admin/promotions [post]
+func (s *Server) CreatePromotionHandler(c *gin.Context) {
+	request, err := bindJson[DTO.PromotionRequest](c)
 	if err != nil {
 		respondError(c, err)
 		return
 	}
-	brand, err := s.brand.Create(c.Request.Context(), request)
+	promotion, err := s.promotion.Create(c.Request.Context(), request)
 	if err != nil {
 		respondError(c, err)
 		return
 	}
-	c.JSON(http.StatusCreated, brand)
+	c.JSON(http.StatusCreated, promotion)
 }
 
-// GetAllBrandHandler returns all brands
+// GetAllPromotionHandler returns a page of promotions
 //
-//	@Summary		List brands
-//	@Description	Get a list of all brands
-//	@Tags			brands
+//	@Summary		List promotions
+//	@Description	Get a filtered and sorted page of promotions
+//	@Tags			promotions
 //	@Produce		json
-//	@Success		200	{array}		DTO.BrandResponse
-//	@Failure		401	{object}	DTO.ErrorResponse
+//	@Param			limit		query		int		false	"Page size (1-100, default 20)"
+//	@Param			cursor		query		string	false	"Cursor from next_cursor of the previous page"
+//	@Param			order		query		string	false	"Sort order"	Enums(asc, desc)
+//	@Param			sort_by		query		string	false	"Sort field"	Enums(id, priority, starts_at)
+//	@Param			is_active	query		bool	false	"Active flag"
+//	@Param			product_id	query		int		false	"Product ID"
+//	@Param			brand_id	query		int		false	"Brand ID"
+//	@Param			category_id	query		int		false	"Category ID"
+//	@Success		200			{object}	DTO.PromotionPageResponse
+//	@Failure		400			{object}	DTO.ErrorResponse
+//	@Failure		401			{object}	DTO.ErrorResponse
 //	@Security		BearerAuth
-//	@Router			/admin/brands [get]
-func (s *Server) GetAllBrandHandler(c *gin.Context) {
-	brands, err := s.brand.GetAll(c.Request.Context())
+//	@Router			/admin/promotions [get]
+func (s *Server) GetAllPromotionHandler(c *gin.Context) {
+	request, err := bindQuery[DTO.PromotionListRequest](c)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	promotions, err := s.promotion.GetAll(c.Request.Context(), request)
 	if err != nil {
 		respondError(c, err)
 		return
 	}
-	c.JSON(http.StatusOK, nonNilSlice(brands))
+	promotions.Items = nonNilSlice(promotions.Items)
+	c.JSON(http.StatusOK, promotions)
 }
 
-// GetBrandHandler returns a brand by ID
+// GetPromotionHandler returns a promotion by ID
 //
-//	@Summary		Get brand
-//	@Description	Fetch a single brand by its ID
-//	@Tags			brands
+//	@Summary		Get promotion
+//	@Description	Fetch a single promotion by its ID
+//	@Tags			promotions
 //	@Produce		json
-//	@Param			id	path		int	true	"Brand ID"
-//	@Success		200	{object}	DTO.BrandResponse
+//	@Param			id	path		int	true	"Promotion ID"
+//	@Success		200	{object}	DTO.PromotionResponse
 //	@Failure		400	{object}	DTO.ErrorResponse
 //	@Failure		401	{object}	DTO.ErrorResponse
 //	@Failure		404	{object}	DTO.ErrorResponse
 //	@Security		BearerAuth
-//	@Router			/admin/brands/{id} [get]
-func (s *Server) GetBrandHandler(c *gin.Context) {
+//	@Router			/admin/promotions/{id} [get]
+func (s *Server) GetPromotionHandler(c *gin.Context) {
 	id, err := getInt64PathParam(c, "id")
 	if err != nil {
 		respondError(c, err)
 		return
 	}
-	brand, err := s.brand.Get(c.Request.Context(), id)
+	promotion, err := s.promotion.Get(c.Request.Context(), id)
 	if err != nil {
 		respondError(c, err)
 		return
 	}
-	c.JSON(http.StatusOK, brand)
+	c.JSON(http.StatusOK, promotion)
 }
 
-// UpdateBrandHandler updates an existing brand
+// UpdatePromotionHandler updates a promotion
 //
-//	@Summary		Update brand
-//	@Description	Update brand data by ID
-//	@Tags			brands
+//	@Summary		Update promotion
+//	@Description	Update promotion data by ID
+//	@Tags			promotions
 //	@Accept			json
 //	@Produce		json
-//	@Param			id		path		int					true	"Brand ID"
-//	@Param			body	body		DTO.BrandRequest	true	"Updated brand data"
-//	@Success		200		{object}	DTO.BrandResponse
+//	@Param			id		path		int						true	"Promotion ID"
+//	@Param			body	body		DTO.PromotionRequest	true	"Updated promotion data"
+//	@Success		200		{object}	DTO.PromotionResponse
 //	@Failure		400		{object}	DTO.ErrorResponse
 //	@Failure		401		{object}	DTO.ErrorResponse
 //	@Failure		404		{object}	DTO.ErrorResponse
-//	@Failure		409		{object}	DTO.ErrorResponse	"name or slug already exists"
 //	@Failure		500		{object}	DTO.ErrorResponse
 //	@Security		BearerAuth
-//	@Router			/admin/brands/{id} [put]
-func (s *Server) UpdateBrandHandler(c *gin.Context) {
+//	@Router			/admin/promotions/{id} [put]
+func (s *Server) UpdatePromotionHandler(c *gin.Context) {
 	id, err := getInt64PathParam(c, "id")
 	if err != nil {
 		respondError(c, err)
 		return
 	}
-	request, err := bindJson[DTO.BrandRequest](c)
+	request, err := bindJson[DTO.PromotionRequest](c)
 	if err != nil {
 		respondError(c, err)
 		return
 	}
-	brand, err := s.brand.Update(c.Request.Context(), id, request)
+	promotion, err := s.promotion.Update(c.Request.Context(), id, request)
 	if err != nil {
 		respondError(c, err)
 		return
 	}
-	c.JSON(http.StatusOK, brand)
+	c.JSON(http.StatusOK, promotion)
 }
 
-// DeleteBrandHandler deletes a brand by ID
+// DeletePromotionHandler deletes a promotion
 //
-//	@Summary		Delete brand
-//	@Description	Permanently delete a brand by its ID
-//	@Tags			brands
+//	@Summary		Delete promotion
+//	@Description	Delete a promotion by ID
+//	@Tags			promotions
 //	@Produce		json
-//	@Param			id	path	int	true	"Brand ID"
+//	@Param			id	path	int	true	"Promotion ID"
 //	@Success		204
 //	@Failure		400	{object}	DTO.ErrorResponse
 //	@Failure		401	{object}	DTO.ErrorResponse
 //	@Failure		404	{object}	DTO.ErrorResponse
 //	@Security		BearerAuth
-//	@Router			/admin/brands/{id} [delete]
-func (s *Server) DeleteBrandHandler(c *gin.Context) {
+//	@Router			/admin/promotions/{id} [delete]
+func (s *Server) DeletePromotionHandler(c *gin.Context) {
 	id, err := getInt64PathParam(c, "id")
 	if err != nil {
 		respondError(c, err)
 		return
 	}
-	_, err = s.brand.Delete(c.Request.Context(), id)
+	_, err = s.promotion.Delete(c.Request.Context(), id)
 	if err != nil {
 		respondError(c, err)
 		return
@@ -423,139 +2555,152 @@ func (s *Server) DeleteBrandHandler(c *gin.Context) {
 	c.Status(http.StatusNoContent)
 }
 
-// CreateCategoryHandler creates a new category
+// CreatePriceListHandler creates a price list
 //
-//	@Summary		Create category
-//	@Description	Create a new category entry
-//	@Tags			categories
+//	@Summary		Create price list
+//	@Description	Create a price list for a currency and optionally a customer group. Products without an explicit price in the list get their base price converted by the exchange rates and rounded to rounding_step minor units by rounding_mode. An active list without a customer group is the default one of its currency
+//	@Tags			price lists
 //	@Accept			json
 //	@Produce		json
-//	@Param			body	body		DTO.CategoryRequest	true	"Category data"
-//	@Success		201		{object}	DTO.CategoryResponse
+//	@Param			body	body		DTO.PriceListRequest	true	"Price list data"
+//	@Success		201		{object}	DTO.PriceListResponse
 //	@Failure		400		{object}	DTO.ErrorResponse
 //	@Failure		401		{object}	DTO.ErrorResponse
-//	@Failure		409		{object}	DTO.ErrorResponse	"slug already exists"
-//	@Failure		422		{object}	DTO.ErrorResponse	"parent category not found"
+//	@Failure		409		{object}	DTO.ErrorResponse	"code is taken or the currency already has a default price list"
 //	@Failure		500		{object}	DTO.ErrorResponse
 //	@Security		BearerAuth
-//	@Router			/admin/categories [post]
-func (s *Server) CreateCategoryHandler(c *gin.Context) {
-	request, err := bindJson[DTO.CategoryRequest](c)
+//	@Router			/admin/priceLists [post]
+func (s *Server) CreatePriceListHandler(c *gin.Context) {
+	request, err := bindJson[DTO.PriceListRequest](c)
 	if err != nil {
 		respondError(c, err)
 		return
 	}
-	category, err := s.category.Create(c.Request.Context(), request)
+	priceList, err := s.priceList.Create(c.Request.Context(), request)
 	if err != nil {
 		respondError(c, err)
 		return
 	}
-	c.JSON(http.StatusCreated, category)
+	c.JSON(http.StatusCreated, priceList)
 }
 
-// GetAllCategoryHandler returns all categories
+// GetAllPriceListHandler returns a page of price lists
 //
-//	@Summary		List categories
-//	@Description	Get a list of all categories
-//	@Tags			categories
+//	@Summary		List price lists
+//	@Description	Get a filtered and sorted page of price lists
+//	@Tags			price lists
 //	@Produce		json
-//	@Success		200	{array}		DTO.CategoryResponse
-//	@Failure		401	{object}	DTO.ErrorResponse
+//	@Param			limit			query		int		false	"Page size (1-100, default 20)"
+//	@Param			cursor			query		string	false	"Cursor from next_cursor of the previous page"
+//	@Param			order			query		string	false	"Sort order"	Enums(asc, desc)
+//	@Param			sort_by			query		string	false	"Sort field"	Enums(id, name)
+//	@Param			currency		query		string	false	"Currency"
+//	@Param			customer_group	query		string	false	"Customer group"
+//	@Param			is_active		query		bool	false	"Active flag"
+//	@Success		200				{object}	DTO.PriceListPageResponse
+//	@Failure		400				{object}	DTO.ErrorResponse
+//	@Failure		401				{object}	DTO.ErrorResponse
 //	@Security		BearerAuth
-//	@Router			/admin/categories [get]
-func (s *Server) GetAllCategoryHandler(c *gin.Context) {
-	categories, err := s.category.GetAll(c.Request.Context())
+//	@Router			/admin/priceLists [get]
+func (s *Server) GetAllPriceListHandler(c *gin.Context) {
+	request, err := bindQuery[DTO.PriceListListRequest](c)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	priceLists, err := s.priceList.GetAll(c.Request.Context(), request)
 	if err != nil {
 		respondError(c, err)
 		return
 	}
-	c.JSON(http.StatusOK, nonNilSlice(categories))
+	priceLists.Items = nonNilSlice(priceLists.Items)
+	c.JSON(http.StatusOK, priceLists)
 }
 
-// GetCategoryHandler returns a category by ID
+// GetPriceListHandler returns a price list by ID
 //
-//	@Summary		Get category
-//	@Description	Fetch a single category by its ID
-//	@Tags			categories
+//	@Summary		Get price list
+//	@Description	Fetch a single price list by its ID
+//	@Tags			price lists
 //	@Produce		json
-//	@Param			id	path		int	true	"Category ID"
-//	@Success		200	{object}	DTO.CategoryResponse
+//	@Param			id	path		int	true	"Price list ID"
+//	@Success		200	{object}	DTO.PriceListResponse
 //	@Failure		400	{object}	DTO.ErrorResponse
 //	@Failure		401	{object}	DTO.ErrorResponse
 //	@Failure		404	{object}	DTO.ErrorResponse
 //	@Security		BearerAuth
-//	@Router			/admin/categories/{id} [get]
-func (s *Server) GetCategoryHandler(c *gin.Context) {
+//	@Router			/admin/priceLists/{id} [get]
+func (s *Server) GetPriceListHandler(c *gin.Context) {
 	id, err := getInt64PathParam(c, "id")
 	if err != nil {
 		respondError(c, err)
 		return
 	}
-	category, err := s.category.Get(c.Request.Context(), id)
+	priceList, err := s.priceList.Get(c.Request.Context(), id)
 	if err != nil {
 		respondError(c, err)
 		return
 	}
-	c.JSON(http.StatusOK, category)
+	c.JSON(http.StatusOK, priceList)
 }
 
-// UpdateCategoryHandler updates an existing category
+// UpdatePriceListHandler updates a price list
 //
-//	@Summary		Update category
-//	@Description	Update category data by ID
-//	@Tags			categories
+//	@Summary		Update price list
+//	@Description	Update price list data by ID. The currency can't be changed
+//	@Tags			price lists
 //	@Accept			json
 //	@Produce		json
-//	@Param			id		path		int					true	"Category ID"
-//	@Param			body	body		DTO.CategoryRequest	true	"Updated category data"
-//	@Success		200		{object}	DTO.CategoryResponse
+//	@Param			id		path		int						true	"Price list ID"
+//	@Param			body	body		DTO.PriceListRequest	true	"Updated price list data"
+//	@Success		200		{object}	DTO.PriceListResponse
 //	@Failure		400		{object}	DTO.ErrorResponse
 //	@Failure		401		{object}	DTO.ErrorResponse
 //	@Failure		404		{object}	DTO.ErrorResponse
-//	@Failure		409		{object}	DTO.ErrorResponse	"slug already exists"
-//	@Failure		422		{object}	DTO.ErrorResponse	"parent category not found"
+//	@Failure		409		{object}	DTO.ErrorResponse	"code is taken or the currency already has a default price list"
+//	@Failure		422		{object}	DTO.ErrorResponse	"currency change"
 //	@Failure		500		{object}	DTO.ErrorResponse
 //	@Security		BearerAuth
-//	@Router			/admin/categories/{id} [put]
-func (s *Server) UpdateCategoryHandler(c *gin.Context) {
+//	@Router			/admin/priceLists/{id} [put]
+func (s *Server) UpdatePriceListHandler(c *gin.Context) {
 	id, err := getInt64PathParam(c, "id")
 	if err != nil {
 		respondError(c, err)
 		return
 	}
-	request, err := bindJson[DTO.CategoryRequest](c)
+	request, err := bindJson[DTO.PriceListRequest](c)
 	if err != nil {
 		respondError(c, err)
 		return
 	}
-	category, err := s.category.Update(c.Request.Context(), id, request)
+	priceList, err := s.priceList.Update(c.Request.Context(), id, request)
 	if err != nil {
 		respondError(c, err)
 		return
 	}
-	c.JSON(http.StatusOK, category)
+	c.JSON(http.StatusOK, priceList)
 }
 
-// DeleteCategoryHandler deletes a category by ID
+// DeletePriceListHandler deletes a price list
 //
-//	@Summary		Delete category
-//	@Description	Permanently delete a category by its ID
-//	@Tags			categories
+//	@Summary		Delete price list
+//	@Description	Delete a price list by ID together with its explicit prices
+//	@Tags			price lists
 //	@Produce		json
-//	@Param			id	path	int	true	"Category ID"
+//	@Param			id	path	int	true	"Price list ID"
 //	@Success		204
 //	@Failure		400	{object}	DTO.ErrorResponse
 //	@Failure		401	{object}	DTO.ErrorResponse
 //	@Failure		404	{object}	DTO.ErrorResponse
 //	@Security		BearerAuth
-//	@Router			/admin/categories/{id} [delete]
-func (s *Server) DeleteCategoryHandler(c *gin.Context) {
+//	@Router			/admin/priceLists/{id} [delete]
+func (s *Server) DeletePriceListHandler(c *gin.Context) {
 	id, err := getInt64PathParam(c, "id")
 	if err != nil {
 		respondError(c, err)
 		return
 	}
-	_, err = s.category.Delete(c.Request.Context(), id)
+	_, err = s.priceList.Delete(c.Request.Context(), id)
 	if err != nil {
 		respondError(c, err)
 		return
@@ -563,6 +2708,238 @@ func (s *Server) DeleteCategoryHandler(c *gin.Context) {
 	c.Status(http.StatusNoContent)
 }
 
-func (s *Server) CreateInventoryMovementHandler(c *gin.Context) {
-	// TODO
+// GetPriceListItemsHandler returns a page of explicit prices of a price list
+//
+//	@Summary		List price list items
+//	@Description	Get a page of the explicit product prices of a price list ordered by product ID
+//	@Tags			price lists
+//	@Produce		json
+//	@Param			id		path		int		true	"Price list ID"
+//	@Param			limit	query		int		false	"Page size (1-100, default 20)"
+//	@Param			cursor	query		string	false	"Cursor from next_cursor of the previous page"
+//	@Param			order	query		string	false	"Sort order"	Enums(asc, desc)
+//	@Success		200		{object}	DTO.PriceListItemPageResponse
+//	@Failure		400		{object}	DTO.ErrorResponse
+//	@Failure		401		{object}	DTO.ErrorResponse
+//	@Failure		404		{object}	DTO.ErrorResponse
+//	@Failure		500		{object}	DTO.ErrorResponse
+//	@Security		BearerAuth
+//	@Router			/admin/priceLists/{id}/items [get]
+func (s *Server) GetPriceListItemsHandler(c *gin.Context) {
+	id, err := getInt64PathParam(c, "id")
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	request, err := bindQuery[DTO.PageRequest](c)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	items, err := s.priceList.GetItems(c.Request.Context(), id, request)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	items.Items = nonNilSlice(items.Items)
+	c.JSON(http.StatusOK, items)
+}
+
+// SetPriceListItemHandler sets the explicit price of a product in a price list
+//
+//	@Summary		Set price list item
+//	@Description	Set the price of a product in a price list instead of its converted base price. The price must be in the currency of the list
+//	@Tags			price lists
+//	@Accept			json
+//	@Produce		json
+//	@Param			id			path		int							true	"Price list ID"
+//	@Param			productId	path		int							true	"Product ID"
+//	@Param			body		body		DTO.PriceListItemRequest	true	"Price"
+//	@Success		200			{object}	DTO.PriceListItemResponse
+//	@Failure		400			{object}	DTO.ErrorResponse
+//	@Failure		401			{object}	DTO.ErrorResponse
+//	@Failure		404			{object}	DTO.ErrorResponse	"price list or product not found"
+//	@Failure		422			{object}	DTO.ErrorResponse	"price in another currency"
+//	@Failure		500			{object}	DTO.ErrorResponse
+//	@Security		BearerAuth
+//	@Router			/admin/priceLists/{id}/items/{productId} [put]
+func (s *Server) SetPriceListItemHandler(c *gin.Context) {
+	id, err := getInt64PathParam(c, "id")
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	productId, err := getInt64PathParam(c, "productId")
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	request, err := bindJson[DTO.PriceListItemRequest](c)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	item, err := s.priceList.SetItem(c.Request.Context(), id, productId, request)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, item)
+}
+
+// DeletePriceListItemHandler removes the explicit price of a product from a price list
+//
+//	@Summary		Delete price list item
+//	@Description	Remove the explicit price of a product from a price list, the product gets its converted base price in the list again
+//	@Tags			price lists
+//	@Produce		json
+//	@Param			id			path	int	true	"Price list ID"
+//	@Param			productId	path	int	true	"Product ID"
+//	@Success		204
+//	@Failure		400	{object}	DTO.ErrorResponse
+//	@Failure		401	{object}	DTO.ErrorResponse
+//	@Failure		404	{object}	DTO.ErrorResponse
+//	@Security		BearerAuth
+//	@Router			/admin/priceLists/{id}/items/{productId} [delete]
+func (s *Server) DeletePriceListItemHandler(c *gin.Context) {
+	id, err := getInt64PathParam(c, "id")
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	productId, err := getInt64PathParam(c, "productId")
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	_, err = s.priceList.DeleteItem(c.Request.Context(), id, productId)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// GetExchangeRatesHandler returns all exchange rates
+//
+//	@Summary		List exchange rates
+//	@Description	Get all exchange rates ordered by currencies. rate is the number of quote_currency units for one base_currency unit
+//	@Tags			exchange rates
+//	@Produce		json
+//	@Success		200	{array}		DTO.ExchangeRateResponse
+//	@Failure		401	{object}	DTO.ErrorResponse
+//	@Failure		500	{object}	DTO.ErrorResponse
+//	@Security		BearerAuth
+//	@Router			/admin/exchangeRates [get]
+func (s *Server) GetExchangeRatesHandler(c *gin.Context) {
+	rates, err := s.priceList.GetRates(c.Request.Context())
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, nonNilSlice(rates))
+}
+
+// UpsertExchangeRateHandler sets an exchange rate
+//
+//	@Summary		Set exchange rate
+//	@Description	Create or replace the rate of a currency pair. The inverse pair and cross rates through RUB are derived when there is no direct rate
+//	@Tags			exchange rates
+//	@Accept			json
+//	@Produce		json
+//	@Param			body	body		DTO.ExchangeRateRequest	true	"Exchange rate"
+//	@Success		200		{object}	DTO.ExchangeRateResponse
+//	@Failure		400		{object}	DTO.ErrorResponse
+//	@Failure		401		{object}	DTO.ErrorResponse
+//	@Failure		500		{object}	DTO.ErrorResponse
+//	@Security		BearerAuth
+//	@Router			/admin/exchangeRates [put]
+func (s *Server) UpsertExchangeRateHandler(c *gin.Context) {
+	request, err := bindJson[DTO.ExchangeRateRequest](c)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	rate, err := s.priceList.UpsertRate(c.Request.Context(), request)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, rate)
+}
+
+// DeleteExchangeRateHandler deletes an exchange rate
+//
+//	@Summary		Delete exchange rate
+//	@Description	Delete an exchange rate by ID
+//	@Tags			exchange rates
+//	@Produce		json
+//	@Param			id	path	int	true	"Exchange rate ID"
+//	@Success		204
+//	@Failure		400	{object}	DTO.ErrorResponse
+//	@Failure		401	{object}	DTO.ErrorResponse
+//	@Failure		404	{object}	DTO.ErrorResponse
+//	@Security		BearerAuth
+//	@Router			/admin/exchangeRates/{id} [delete]
+func (s *Server) DeleteExchangeRateHandler(c *gin.Context) {
+	id, err := getInt64PathParam(c, "id")
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	_, err = s.priceList.DeleteRate(c.Request.Context(), id)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// ImportExchangeRatesHandler loads exchange rates from a CSV file
+//
+//	@Summary		Import exchange rates
+//	@Description	Create or replace exchange rates from a CSV file with base_currency,quote_currency,rate lines, the header line is optional. Nothing is imported if any line is invalid
+//	@Tags			exchange rates
+//	@Accept			multipart/form-data
+//	@Produce		json
+//	@Param			file	formData	file	true	"CSV file of at most 16 MiB"
+//	@Success		200		{object}	DTO.ExchangeRateImportResponse
+//	@Failure		400		{object}	DTO.ErrorResponse	"missing file or invalid lines"
+//	@Failure		401		{object}	DTO.ErrorResponse
+//	@Failure		500		{object}	DTO.ErrorResponse
+//	@Security		BearerAuth
+//	@Router			/admin/exchangeRates/import [post]
+func (s *Server) ImportExchangeRatesHandler(c *gin.Context) {
+	file, err := openFormFile(c, "file", importMaxSize)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	defer file.Close()
+	response, err := s.priceList.ImportRates(c.Request.Context(), file)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, response)
+}
+
+// PurgeTrashHandler permanently deletes old soft-deleted rows
+//
+//	@Summary		Purge trash
+//	@Description	Permanently delete brands, categories, products and variants that were soft-deleted longer ago than the retention window, together with their inventory movements and price history
+//	@Tags			trash
+//	@Produce		json
+//	@Success		200	{object}	DTO.PurgeResponse
+//	@Failure		401	{object}	DTO.ErrorResponse
+//	@Failure		500	{object}	DTO.ErrorResponse
+//	@Security		BearerAuth
+//	@Router			/admin/trash/purge [post]
+func (s *Server) PurgeTrashHandler(c *gin.Context) {
+	response, err := s.trash.Purge(c.Request.Context())
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, response)
 }
@@ -1,14 +1,23 @@
 package server
 
 import (
+	"bytes"
+	"encoding/json"
 	"errors"
+	"fmt"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"path"
 	"strconv"
 	"strings"
 
 	"github.com/Aoladiy/go-with-tools/internal/DTO"
 	"github.com/Aoladiy/go-with-tools/internal/errs"
+	"github.com/Aoladiy/go-with-tools/internal/export"
 
 	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
 )
 
 func nonNilSlice[T any](v []T) []T {
@@ -22,11 +31,162 @@ func nonNilSlice[T any](v []T) []T {
 func bindJson[T any](c *gin.Context) (T, *errs.AppError) {
 	var request T
 	if err := c.ShouldBindJSON(&request); err != nil {
-		return request, errs.BadRequest(err)
+		return request, validationErr(err)
 	}
 	return request, nil
 }
 
+// bindMergePatch decodes an RFC 7396 merge patch. The patch has to be an object, members the
+// resource doesn't have are rejected rather than ignored.
+func bindMergePatch[T any](c *gin.Context) (T, *errs.AppError) {
+	var patch T
+	body, err := c.GetRawData()
+	if err != nil {
+		return patch, errs.BadRequest(err)
+	}
+	body = bytes.TrimSpace(body)
+	if len(body) == 0 || body[0] != '{' {
+		return patch, errs.BadRequest(errors.New("merge patch must be a JSON object"))
+	}
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.DisallowUnknownFields()
+	err = decoder.Decode(&patch)
+	if err != nil {
+		return patch, validationErr(err)
+	}
+	err = binding.Validator.ValidateStruct(patch)
+	if err != nil {
+		return patch, validationErr(err)
+	}
+	return patch, nil
+}
+
+func bindQuery[T any](c *gin.Context) (T, *errs.AppError) {
+	var request T
+	if err := c.ShouldBindQuery(&request); err != nil {
+		return request, validationErr(err)
+	}
+	return request, nil
+}
+
+// multipartOverhead is what a multipart body may hold besides the file: boundaries, part headers
+// and other small fields.
+const multipartOverhead = 64 << 10
+
+// importMaxSize is the largest CSV file an import accepts.
+const importMaxSize = 16 << 20
+
+// openFormFile opens the file of a multipart field. The body is cut at maxSize plus the multipart
+// overhead, so a larger file is refused before it is read into memory or spooled to disk.
+func openFormFile(c *gin.Context, field string, maxSize int64) (multipart.File, *errs.AppError) {
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxSize+multipartOverhead)
+	fileHeader, err := c.FormFile(field)
+	var maxBytesErr *http.MaxBytesError
+	if errors.As(err, &maxBytesErr) {
+		return nil, errs.BadRequest(fmt.Errorf("file is larger than %d bytes", maxSize))
+	}
+	if err != nil {
+		return nil, errs.BadRequest(fmt.Errorf("multipart field %q is required | %w", field, err))
+	}
+	if fileHeader.Size > maxSize {
+		return nil, errs.BadRequest(fmt.Errorf("file is larger than %d bytes", maxSize))
+	}
+	file, err := fileHeader.Open()
+	if err != nil {
+		return nil, errs.BadRequest(err)
+	}
+	return file, nil
+}
+
+// respondExport streams the rows fn writes as an attachment in the format of the request. An error
+// found before the first row is answered as usual, a later one can only cut the download short.
+func respondExport(c *gin.Context, name string, fn func(w export.Writer) *errs.AppError) {
+	request, err := bindQuery[DTO.ExportRequest](c)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	w, err := export.NewWriter(request.Format, name, c.Writer)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.Header("Content-Type", w.ContentType())
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s.%s"`, name, w.Extension()))
+	err = fn(w)
+	if err == nil {
+		closeErr := w.Close()
+		if closeErr != nil {
+			err = errs.Internal(closeErr)
+		}
+	}
+	if err != nil {
+		if !c.Writer.Written() {
+			c.Writer.Header().Del("Content-Disposition")
+			respondError(c, err)
+			return
+		}
+		_ = c.Error(err)
+		c.Abort()
+	}
+}
+
+// redirectToSlug answers with 301 and the url of the request with the current slug in place of the old one.
+func redirectToSlug(c *gin.Context, resolved DTO.SlugResponse) {
+	location := url.URL{
+		Path:     path.Join(path.Dir(c.Request.URL.Path), resolved.Slug),
+		RawQuery: c.Request.URL.RawQuery,
+	}
+	c.Header("Location", location.String())
+	c.JSON(http.StatusMovedPermanently, resolved)
+}
+
+// versionETag is the strong entity tag of a record version.
+func versionETag(version int64) string {
+	return `"` + strconv.FormatInt(version, 10) + `"`
+}
+
+// getIfMatch returns the version the If-Match header of the request expects, nil when there is no
+// header or it is "*". A tag that isn't a single strong version tag never matches, so the write
+// fails with 412 instead of overwriting a record the client hasn't seen.
+func getIfMatch(c *gin.Context) *int64 {
+	ifMatch := strings.TrimSpace(c.GetHeader("If-Match"))
+	if ifMatch == "" || ifMatch == "*" {
+		return nil
+	}
+	version := int64(-1)
+	tag, ok := strings.CutPrefix(ifMatch, `"`)
+	if tag, ok = strings.CutSuffix(tag, `"`); ok {
+		parsed, err := strconv.ParseInt(tag, 10, 64)
+		if err == nil {
+			version = parsed
+		}
+	}
+	return &version
+}
+
+// respondVersioned answers with the record and its version as the ETag.
+func respondVersioned(c *gin.Context, status int, version int64, body any) {
+	c.Header("ETag", versionETag(version))
+	c.JSON(status, body)
+}
+
+// respondCached answers a GET with the record and its version as the ETag, or with 304 and no body
+// when one of the tags of If-None-Match is that version. The tags are compared weakly.
+func respondCached(c *gin.Context, version int64, body any) {
+	etag := versionETag(version)
+	c.Header("ETag", etag)
+	for _, tag := range strings.Split(c.GetHeader("If-None-Match"), ",") {
+		tag = strings.TrimPrefix(strings.TrimSpace(tag), "W/")
+		if tag == etag || tag == "*" {
+			c.Status(http.StatusNotModified)
+			return
+		}
+	}
+	c.JSON(http.StatusOK, body)
+}
+
 func getStringPathParam(c *gin.Context, param string) string {
 	return c.Param(param)
 }
@@ -39,12 +199,32 @@ func getInt64PathParam(c *gin.Context, param string) (int64, *errs.AppError) {
 	return pathParam, nil
 }
 
+// problemContentType is the media type of the RFC 7807 problems errors are answered with.
+const problemContentType = "application/problem+json"
+
+// respondError answers with the error as an RFC 7807 problem. The instance is the path of the request
+// and the request id is the one the error is logged with.
 func respondError(c *gin.Context, err *errs.AppError) {
 	_ = c.Error(err)
-	c.JSON(
-		err.HttpCode(),
-		DTO.ErrorResponse{Error: err.Error()},
-	)
+	response := DTO.ErrorResponse{
+		Type:       err.Code.Type(),
+		Title:      err.Code.Title(),
+		Status:     err.HttpCode(),
+		Detail:     err.Error(),
+		Instance:   c.Request.URL.Path,
+		RequestId:  c.GetString(requestIdKey),
+		Code:       err.Code.String(),
+		Extensions: err.Extensions,
+	}
+	for _, violation := range err.Violations {
+		response.Violations = append(response.Violations, DTO.ViolationResponse{
+			Field:   violation.Field,
+			Rule:    violation.Rule,
+			Message: violation.Message,
+		})
+	}
+	c.Header("Content-Type", problemContentType)
+	c.JSON(response.Status, response)
 }
 
 func getJWTFromHeader(c *gin.Context) (string, *errs.AppError) {
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
+	"net/http"
 	"strconv"
 	"time"
 
@@ -34,7 +35,7 @@ func AuthByJWT(client gen.AuthMicroserviceClient, jwtSecret string) gin.HandlerF
 		}
 		signedOutResponse, err := client.IsTokenSignedOut(c.Request.Context(), &gen.IsTokenSignedOutRequest{Token: parsedToken.Raw})
 		if err != nil {
-			respondError(c, errs.Internal(err))
+			respondError(c, auth.FromGrpcErr(err))
 			c.Abort()
 			return
 		}
@@ -61,10 +62,13 @@ func AuthByJWT(client gen.AuthMicroserviceClient, jwtSecret string) gin.HandlerF
 	}
 }
 
+// requestIdKey is the key of the id LogErrors gives every request in the gin context.
+const requestIdKey = "request_id"
+
 func LogErrors() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		uuid := uuid2.New().String()
-		c.Set("request_id", uuid)
+		c.Set(requestIdKey, uuid)
 
 		start := time.Now()
 		c.Next()
@@ -81,7 +85,8 @@ func LogErrors() gin.HandlerFunc {
 				logError(uuid, c.Request.Method, c.FullPath(), errs.Internal(fmt.Errorf("error is not of type *errs.AppError: %w", e.Err)))
 				continue
 			}
-			if appErr.Code == errs.InternalErrCode {
+			// the server and the services behind it failed, not the client
+			if appErr.HttpCode() >= http.StatusInternalServerError {
 				logError(uuid, c.Request.Method, c.FullPath(), appErr.Err)
 				continue
 			}
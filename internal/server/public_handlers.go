@@ -3,13 +3,18 @@ package server
 import (
 	"net/http"
 
+	"github.com/Aoladiy/go-with-tools/internal/DTO"
+	"github.com/Aoladiy/go-with-tools/internal/errs"
+	"github.com/Aoladiy/go-with-tools/internal/feed"
+	"github.com/Aoladiy/go-with-tools/internal/slug"
+
 	"github.com/gin-gonic/gin"
 )
 
 func (s *Server) HelloWorldHandler(c *gin.Context) {
-	brands, err := s.q.GetAllBrands(c.Request.Context())
+	brands, err := s.q.GetAllFrontBrands(c.Request.Context())
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		respondError(c, errs.Internal(err))
 		return
 	}
 	c.JSON(http.StatusOK, nonNilSlice(brands))
@@ -19,14 +24,441 @@ func (s *Server) healthHandler(c *gin.Context) {
 	c.JSON(http.StatusOK, s.db.Health())
 }
 
+// BrandsHandler returns all brands for the storefront
+//
+//	@Summary		List storefront brands
+//	@Description	Get a list of all brands that aren't deleted
+//	@Tags			front
+//	@Produce		json
+//	@Success		200	{array}		DTO.FrontBrandResponse
+//	@Failure		500	{object}	DTO.ErrorResponse
+//	@Router			/front/brands [get]
 func (s *Server) BrandsHandler(c *gin.Context) {
-	// TODO
+	brands, err := s.brand.GetAllFront(c.Request.Context())
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, nonNilSlice(brands))
 }
 
+// CategoriesHandler returns all categories for the storefront
+//
+//	@Summary		List storefront categories
+//	@Description	Get a list of all categories that aren't deleted
+//	@Tags			front
+//	@Produce		json
+//	@Success		200	{array}		DTO.FrontCategoryResponse
+//	@Failure		500	{object}	DTO.ErrorResponse
+//	@Router			/front/categories [get]
 func (s *Server) CategoriesHandler(c *gin.Context) {
-	// TODO
+	categories, err := s.category.GetAllFront(c.Request.Context())
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, nonNilSlice(categories))
+}
+
+// CategoryTreeHandler returns the whole category tree for the storefront
+//
+//	@Summary		Storefront category tree
+//	@Description	Get all categories nested under their parents
+//	@Tags			front
+//	@Produce		json
+//	@Success		200	{array}		DTO.CategoryTreeResponse
+//	@Failure		500	{object}	DTO.ErrorResponse
+//	@Router			/front/categories/tree [get]
+func (s *Server) CategoryTreeHandler(c *gin.Context) {
+	tree, err := s.category.GetTree(c.Request.Context())
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, nonNilSlice(tree))
+}
+
+// CategoryBreadcrumbsHandler returns the path from the root category to the given one
+//
+//	@Summary		Category breadcrumbs
+//	@Description	Get ancestors of a category ordered from the root, ending with the category itself
+//	@Tags			front
+//	@Produce		json
+//	@Param			id	path		int	true	"Category ID"
+//	@Success		200	{array}		DTO.FrontCategoryResponse
+//	@Failure		400	{object}	DTO.ErrorResponse
+//	@Failure		404	{object}	DTO.ErrorResponse
+//	@Failure		500	{object}	DTO.ErrorResponse
+//	@Router			/front/categories/{id}/breadcrumbs [get]
+func (s *Server) CategoryBreadcrumbsHandler(c *gin.Context) {
+	id, err := getInt64PathParam(c, "id")
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	breadcrumbs, err := s.category.GetBreadcrumbs(c.Request.Context(), id)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, breadcrumbs)
+}
+
+// CategoryDescendantsHandler returns the subtree under a category
+//
+//	@Summary		Category descendants
+//	@Description	Get all descendants of a category nested under their parents
+//	@Tags			front
+//	@Produce		json
+//	@Param			id	path		int	true	"Category ID"
+//	@Success		200	{array}		DTO.CategoryTreeResponse
+//	@Failure		400	{object}	DTO.ErrorResponse
+//	@Failure		404	{object}	DTO.ErrorResponse
+//	@Failure		500	{object}	DTO.ErrorResponse
+//	@Router			/front/categories/{id}/descendants [get]
+func (s *Server) CategoryDescendantsHandler(c *gin.Context) {
+	id, err := getInt64PathParam(c, "id")
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	descendants, err := s.category.GetDescendants(c.Request.Context(), id)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, nonNilSlice(descendants))
 }
 
+// CategoryProductsHandler returns active products of a category and all its subcategories
+//
+//	@Summary		Category products
+//	@Description	Get a page of active products of a category including its subcategories. price is in the selected price list, effective_price is the price with the promotions in effect
+//	@Tags			front
+//	@Produce		json
+//	@Param			id			path		int		true	"Category ID"
+//	@Param			limit		query		int		false	"Page size (1-100, default 20)"
+//	@Param			cursor		query		string	false	"Cursor from next_cursor of the previous page"
+//	@Param			order		query		string	false	"Sort order"	Enums(asc, desc)
+//	@Param			currency	query		string	false	"Currency to price in, the default price list of the currency is used if there is one"
+//	@Param			price_list	query		string	false	"Price list code"
+//	@Success		200			{object}	DTO.FrontProductPageResponse
+//	@Failure		400			{object}	DTO.ErrorResponse
+//	@Failure		404			{object}	DTO.ErrorResponse
+//	@Failure		422			{object}	DTO.ErrorResponse
+//	@Failure		500			{object}	DTO.ErrorResponse
+//	@Router			/front/categories/{id}/products [get]
+func (s *Server) CategoryProductsHandler(c *gin.Context) {
+	id, err := getInt64PathParam(c, "id")
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	request, err := bindQuery[DTO.FrontCategoryProductListRequest](c)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	products, err := s.product.GetAllFrontByCategory(c.Request.Context(), id, request)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	products.Items = nonNilSlice(products.Items)
+	c.JSON(http.StatusOK, products)
+}
+
+// ProductsHandler returns a filtered page of active products with facet counts
+//
+//	@Summary		List storefront products
+//	@Description	Get a page of active products together with their brand and category names, filtered by category subtree, brands and attributes. Facets count brands and filterable attribute values over all matching products, ignoring the filter of the facet itself so several values of it can be selected. price is in the selected price list, price filters apply to the base price. effective_price is the price with the promotions in effect
+//	@Tags			front
+//	@Produce		json
+//	@Param			limit		query		int		false	"Page size (1-100, default 20)"
+//	@Param			cursor		query		string	false	"Cursor from next_cursor of the previous page"
+//	@Param			order		query		string	false	"Sort order"	Enums(asc, desc)
+//	@Param			category_id	query		int		false	"Category ID, products of subcategories are included"
+//	@Param			brand_id	query		[]int	false	"Brand IDs"	collectionFormat(multi)
+//	@Param			attr[code]	query		string	false	"Attribute filter by code: comma separated values (attr[ram]=8,16) or a number range (attr[weight]=1..2.5)"
+//	@Param			currency	query		string	false	"Currency to price in, the default price list of the currency is used if there is one"
+//	@Param			price_list	query		string	false	"Price list code"
+//	@Success		200			{object}	DTO.FrontProductFacetedPageResponse
+//	@Failure		400			{object}	DTO.ErrorResponse
+//	@Failure		404			{object}	DTO.ErrorResponse
+//	@Failure		422			{object}	DTO.ErrorResponse
+//	@Failure		500			{object}	DTO.ErrorResponse
+//	@Router			/front/products [get]
 func (s *Server) ProductsHandler(c *gin.Context) {
-	// TODO
+	request, err := bindQuery[DTO.FrontProductListRequest](c)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	request.Attributes = c.QueryMap("attr")
+	products, err := s.product.GetAllFront(c.Request.Context(), request)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	products.Items = nonNilSlice(products.Items)
+	c.JSON(http.StatusOK, products)
+}
+
+// SearchProductsHandler searches active products for the storefront
+//
+//	@Summary		Search storefront products
+//	@Description	Full-text search over active products' name, description, brand and category names with typo tolerance. Results are ordered by relevance, price is in the selected price list, effective_price is the price with the promotions in effect
+//	@Tags			front
+//	@Produce		json
+//	@Param			q			query		string	true	"Search query"
+//	@Param			limit		query		int		false	"Page size (1-100, default 20)"
+//	@Param			cursor		query		string	false	"Cursor from next_cursor of the previous page"
+//	@Param			currency	query		string	false	"Currency to price in, the default price list of the currency is used if there is one"
+//	@Param			price_list	query		string	false	"Price list code"
+//	@Success		200			{object}	DTO.FrontProductPageResponse
+//	@Failure		400			{object}	DTO.ErrorResponse
+//	@Failure		404			{object}	DTO.ErrorResponse
+//	@Failure		422			{object}	DTO.ErrorResponse
+//	@Failure		500			{object}	DTO.ErrorResponse
+//	@Router			/front/products/search [get]
+func (s *Server) SearchProductsHandler(c *gin.Context) {
+	request, err := bindQuery[DTO.FrontProductSearchRequest](c)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	products, err := s.product.SearchFront(c.Request.Context(), request)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	products.Items = nonNilSlice(products.Items)
+	c.JSON(http.StatusOK, products)
+}
+
+// SuggestProductsHandler autocompletes active products' names
+//
+//	@Summary		Autocomplete storefront products
+//	@Description	Get active products whose words start with the words of the query
+//	@Tags			front
+//	@Produce		json
+//	@Param			q		query		string	true	"Prefix of the product's name"
+//	@Param			limit	query		int		false	"Number of suggestions (1-100, default 10)"
+//	@Success		200		{array}		DTO.ProductSuggestionResponse
+//	@Failure		400		{object}	DTO.ErrorResponse
+//	@Failure		500		{object}	DTO.ErrorResponse
+//	@Router			/front/products/suggest [get]
+func (s *Server) SuggestProductsHandler(c *gin.Context) {
+	request, err := bindQuery[DTO.ProductSuggestRequest](c)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	suggestions, err := s.product.Suggest(c.Request.Context(), request)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, nonNilSlice(suggestions))
+}
+
+// ProductVariantsHandler returns the variant matrix of a product
+//
+//	@Summary		Product variants
+//	@Description	Get option types with their values and active variants of an active product. price is in the selected price list, effective_price is the variant price with the promotions of the product in effect
+//	@Tags			front
+//	@Produce		json
+//	@Param			id			path		int		true	"Product ID"
+//	@Param			currency	query		string	false	"Currency to price in, the default price list of the currency is used if there is one"
+//	@Param			price_list	query		string	false	"Price list code"
+//	@Success		200			{object}	DTO.FrontVariantMatrixResponse
+//	@Failure		400			{object}	DTO.ErrorResponse
+//	@Failure		404			{object}	DTO.ErrorResponse
+//	@Failure		422			{object}	DTO.ErrorResponse
+//	@Failure		500			{object}	DTO.ErrorResponse
+//	@Router			/front/products/{id}/variants [get]
+func (s *Server) ProductVariantsHandler(c *gin.Context) {
+	id, err := getInt64PathParam(c, "id")
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	request, err := bindQuery[DTO.PriceListQuery](c)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	matrix, err := s.variant.GetFrontMatrix(c.Request.Context(), id, request)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, matrix)
+}
+
+// SlugHandler resolves a storefront slug
+//
+//	@Summary		Resolve a slug
+//	@Description	Find a brand, a category or an active product by its slug. An old slug is answered with 301 and the Location of the current one, the body is the same as for the current slug
+//	@Tags			front
+//	@Produce		json
+//	@Param			kind	path		string	true	"Kind of the record"	Enums(brands, categories, products)
+//	@Param			slug	path		string	true	"Current or old slug"
+//	@Success		200		{object}	DTO.SlugResponse
+//	@Success		301		{object}	DTO.SlugResponse
+//	@Failure		400		{object}	DTO.ErrorResponse
+//	@Failure		404		{object}	DTO.ErrorResponse
+//	@Failure		500		{object}	DTO.ErrorResponse
+//	@Router			/front/slugs/{kind}/{slug} [get]
+func (s *Server) SlugHandler(c *gin.Context) {
+	kind, err := slug.ParseKind(getStringPathParam(c, "kind"))
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	requested := getStringPathParam(c, "slug")
+	resolved, err := s.slug.Resolve(c.Request.Context(), kind, requested)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	if resolved.Slug != requested {
+		redirectToSlug(c, resolved)
+		return
+	}
+	c.JSON(http.StatusOK, resolved)
+}
+
+// ProductBySlugHandler returns an active product by slug
+//
+//	@Summary		Product by slug
+//	@Description	Get an active product with its media, brand, category and the breadcrumbs of the category from the root. price is in the selected price list, effective_price is the price with the promotions in effect. An old slug is answered with 301 and the Location of the current one
+//	@Tags			front
+//	@Produce		json
+//	@Param			slug		path		string	true	"Product slug"
+//	@Param			currency	query		string	false	"Currency to price in, the default price list of the currency is used if there is one"
+//	@Param			price_list	query		string	false	"Price list code"
+//	@Success		200			{object}	DTO.FrontProductDetailResponse
+//	@Success		301			{object}	DTO.SlugResponse
+//	@Failure		400			{object}	DTO.ErrorResponse
+//	@Failure		404			{object}	DTO.ErrorResponse
+//	@Failure		422			{object}	DTO.ErrorResponse
+//	@Failure		500			{object}	DTO.ErrorResponse
+//	@Router			/front/products/slug/{slug} [get]
+func (s *Server) ProductBySlugHandler(c *gin.Context) {
+	request, err := bindQuery[DTO.PriceListQuery](c)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	requested := getStringPathParam(c, "slug")
+	product, err := s.product.GetFrontBySlug(c.Request.Context(), requested, request)
+	if err != nil {
+		s.respondSlugError(c, slug.Products, requested, err)
+		return
+	}
+	c.JSON(http.StatusOK, product)
+}
+
+// BrandBySlugHandler returns a brand by slug
+//
+//	@Summary		Brand by slug
+//	@Description	Get a brand that isn't deleted with its media. An old slug is answered with 301 and the Location of the current one
+//	@Tags			front
+//	@Produce		json
+//	@Param			slug	path		string	true	"Brand slug"
+//	@Success		200		{object}	DTO.FrontBrandDetailResponse
+//	@Success		301		{object}	DTO.SlugResponse
+//	@Failure		404		{object}	DTO.ErrorResponse
+//	@Failure		500		{object}	DTO.ErrorResponse
+//	@Router			/front/brands/slug/{slug} [get]
+func (s *Server) BrandBySlugHandler(c *gin.Context) {
+	requested := getStringPathParam(c, "slug")
+	brand, err := s.brand.GetFrontBySlug(c.Request.Context(), requested)
+	if err != nil {
+		s.respondSlugError(c, slug.Brands, requested, err)
+		return
+	}
+	c.JSON(http.StatusOK, brand)
+}
+
+// CategoryBySlugHandler returns a category by slug
+//
+//	@Summary		Category by slug
+//	@Description	Get a category that isn't deleted with its breadcrumbs from the root, ending with the category itself. An old slug is answered with 301 and the Location of the current one
+//	@Tags			front
+//	@Produce		json
+//	@Param			slug	path		string	true	"Category slug"
+//	@Success		200		{object}	DTO.FrontCategoryDetailResponse
+//	@Success		301		{object}	DTO.SlugResponse
+//	@Failure		404		{object}	DTO.ErrorResponse
+//	@Failure		500		{object}	DTO.ErrorResponse
+//	@Router			/front/categories/slug/{slug} [get]
+func (s *Server) CategoryBySlugHandler(c *gin.Context) {
+	requested := getStringPathParam(c, "slug")
+	category, err := s.category.GetFrontBySlug(c.Request.Context(), requested)
+	if err != nil {
+		s.respondSlugError(c, slug.Categories, requested, err)
+		return
+	}
+	c.JSON(http.StatusOK, category)
+}
+
+// respondSlugError redirects to the current slug when the one that wasn't found is an old slug
+// of a record, any other error is responded as is.
+func (s *Server) respondSlugError(c *gin.Context, kind slug.Kind, requested string, err *errs.AppError) {
+	if err.Code == errs.NotFoundErrCode {
+		resolved, resolveErr := s.slug.Resolve(c.Request.Context(), kind, requested)
+		if resolveErr == nil && resolved.Slug != requested {
+			redirectToSlug(c, resolved)
+			return
+		}
+	}
+	respondError(c, err)
+}
+
+// YandexFeedHandler returns the Yandex Market feed of the catalog
+//
+//	@Summary		Yandex Market feed
+//	@Description	Get active products with their categories in the YML format. The feed is cached until the catalog changes, a request with the ETag in If-None-Match gets 304 while it hasn't
+//	@Tags			front
+//	@Produce		xml
+//	@Param			If-None-Match	header		string	false	"ETag of the feed the client has"
+//	@Success		200				{string}	string	"YML document"
+//	@Success		304				{string}	string	"Not Modified"
+//	@Failure		500				{object}	DTO.ErrorResponse
+//	@Router			/front/feeds/yandex.xml [get]
+func (s *Server) YandexFeedHandler(c *gin.Context) {
+	s.respondFeed(c, feed.FormatYandex)
+}
+
+// GoogleFeedHandler returns the Google Merchant Center feed of the catalog
+//
+//	@Summary		Google Merchant feed
+//	@Description	Get active products as an RSS 2.0 Merchant Center feed. The feed is cached until the catalog changes, a request with the ETag in If-None-Match gets 304 while it hasn't
+//	@Tags			front
+//	@Produce		xml
+//	@Param			If-None-Match	header		string	false	"ETag of the feed the client has"
+//	@Success		200				{string}	string	"RSS document"
+//	@Success		304				{string}	string	"Not Modified"
+//	@Failure		500				{object}	DTO.ErrorResponse
+//	@Router			/front/feeds/google.xml [get]
+func (s *Server) GoogleFeedHandler(c *gin.Context) {
+	s.respondFeed(c, feed.FormatGoogle)
+}
+
+func (s *Server) respondFeed(c *gin.Context, format string) {
+	generated, err := s.feed.Get(c.Request.Context(), format)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	c.Header("ETag", generated.ETag)
+	c.Header("Last-Modified", generated.GeneratedAt.UTC().Format(http.TimeFormat))
+	c.Header("Cache-Control", "no-cache")
+	if c.GetHeader("If-None-Match") == generated.ETag {
+		c.Status(http.StatusNotModified)
+		return
+	}
+	c.Data(http.StatusOK, "application/xml; charset=utf-8", generated.Body)
 }
@@ -10,25 +10,43 @@ import (
 )
 
 func (s *Server) RegisterRoutes() http.Handler {
+	registerValidations()
 	r := gin.New()
 
 	r.Use(gin.Recovery())
 	r.Use(cors.New(cors.Config{
 		AllowOrigins:     []string{"http://localhost:5173"}, // Add your frontend URL
 		AllowMethods:     []string{"GET", "POST", "PUT", "DELETE", "OPTIONS", "PATCH"},
-		AllowHeaders:     []string{"Accept", "Authorization", "Content-Type"},
+		AllowHeaders:     []string{"Accept", "Authorization", "Content-Type", "If-Match", "If-None-Match"},
+		ExposeHeaders:    []string{"ETag"},
 		AllowCredentials: true, // Enable cookies/auth
 	}))
 	r.Use(LogErrors())
 
+	// uploaded media of the local storage, MEDIA_BASE_URL has to point here
+	r.Static("/media", s.c.MediaDir)
+
 	apiV1 := r.Group("/api/v1")
 
 	front := apiV1.Group("/front")
 	front.GET("/health", s.healthHandler)
 	front.GET("/hello", s.HelloWorldHandler)
 	front.GET("/categories", s.CategoriesHandler)
+	front.GET("/categories/tree", s.CategoryTreeHandler)
+	front.GET("/categories/:id/breadcrumbs", s.CategoryBreadcrumbsHandler)
+	front.GET("/categories/:id/descendants", s.CategoryDescendantsHandler)
+	front.GET("/categories/:id/products", s.CategoryProductsHandler)
+	front.GET("/categories/slug/:slug", s.CategoryBySlugHandler)
 	front.GET("/brands", s.BrandsHandler)
+	front.GET("/brands/slug/:slug", s.BrandBySlugHandler)
 	front.GET("/products", s.ProductsHandler)
+	front.GET("/products/search", s.SearchProductsHandler)
+	front.GET("/products/suggest", s.SuggestProductsHandler)
+	front.GET("/products/:id/variants", s.ProductVariantsHandler)
+	front.GET("/products/slug/:slug", s.ProductBySlugHandler)
+	front.GET("/slugs/:kind/:slug", s.SlugHandler)
+	front.GET("/feeds/yandex.xml", s.YandexFeedHandler)
+	front.GET("/feeds/google.xml", s.GoogleFeedHandler)
 
 	apiV1.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler, ginSwagger.DefaultModelsExpandDepth(2)))
 
@@ -42,28 +60,117 @@ func (s *Server) RegisterRoutes() http.Handler {
 	products.Use(AuthByJWT(s.auth, s.c.JwtSecret))
 	products.POST("", s.CreateProductHandler)
 	products.GET("", s.GetAllProductHandler)
+	products.GET("/search", s.SearchProductHandler)
+	products.GET("/trash", s.GetTrashedProductHandler)
+	products.POST("/import", s.ImportProductsHandler)
+	products.GET("/export", s.ExportProductsHandler)
 	products.GET("/:id", s.GetProductHandler)
 	products.PUT("/:id", s.UpdateProductHandler)
+	products.PATCH("/:id", s.PatchProductHandler)
 	products.DELETE("/:id", s.DeleteProductHandler)
+	products.POST("/:id/restore", s.RestoreProductHandler)
 	products.GET("/:id/priceHistory", s.GetProductPriceHistory)
+	products.POST("/:id/priceHistory/:historyId/rollback", s.RollbackProductPriceHandler)
+	products.GET("/:id/price", s.GetProductPriceHandler)
+	products.GET("/:id/stock", s.GetProductStockHandler)
+	products.GET("/:id/inventoryMovements", s.GetProductInventoryMovementsHandler)
+	products.GET("/:id/attributes", s.GetProductAttributesHandler)
+	products.PUT("/:id/attributes", s.SetProductAttributesHandler)
+	products.GET("/:id/variants", s.GetAllVariantHandler)
+	products.POST("/:id/variants", s.CreateVariantHandler)
+	products.GET("/:id/variants/:variantId", s.GetVariantHandler)
+	products.PUT("/:id/variants/:variantId", s.UpdateVariantHandler)
+	products.DELETE("/:id/variants/:variantId", s.DeleteVariantHandler)
+	products.GET("/:id/variants/:variantId/priceHistory", s.GetVariantPriceHistoryHandler)
+	products.GET("/:id/media", s.GetProductMediaHandler)
+	products.POST("/:id/media", s.UploadProductMediaHandler)
+	products.PUT("/:id/media/order", s.ReorderProductMediaHandler)
+	products.POST("/:id/media/:mediaId/primary", s.SetPrimaryProductMediaHandler)
+	products.DELETE("/:id/media/:mediaId", s.DeleteProductMediaHandler)
 
 	brands := admin.Group("/brands")
 	brands.Use(AuthByJWT(s.auth, s.c.JwtSecret))
 	brands.POST("", s.CreateBrandHandler)
 	brands.GET("", s.GetAllBrandHandler)
+	brands.GET("/trash", s.GetTrashedBrandHandler)
+	brands.GET("/export", s.ExportBrandsHandler)
 	brands.GET("/:id", s.GetBrandHandler)
 	brands.PUT("/:id", s.UpdateBrandHandler)
+	brands.PATCH("/:id", s.PatchBrandHandler)
 	brands.DELETE("/:id", s.DeleteBrandHandler)
+	brands.POST("/:id/restore", s.RestoreBrandHandler)
+	brands.GET("/:id/media", s.GetBrandMediaHandler)
+	brands.POST("/:id/media", s.UploadBrandMediaHandler)
+	brands.PUT("/:id/media/order", s.ReorderBrandMediaHandler)
+	brands.POST("/:id/media/:mediaId/primary", s.SetPrimaryBrandMediaHandler)
+	brands.DELETE("/:id/media/:mediaId", s.DeleteBrandMediaHandler)
 
 	categories := admin.Group("/categories")
 	categories.Use(AuthByJWT(s.auth, s.c.JwtSecret))
 	categories.POST("", s.CreateCategoryHandler)
 	categories.GET("", s.GetAllCategoryHandler)
+	categories.GET("/tree", s.GetCategoryTreeHandler)
+	categories.GET("/trash", s.GetTrashedCategoryHandler)
+	categories.GET("/export", s.ExportCategoriesHandler)
 	categories.GET("/:id", s.GetCategoryHandler)
 	categories.PUT("/:id", s.UpdateCategoryHandler)
+	categories.PATCH("/:id", s.PatchCategoryHandler)
+	categories.POST("/:id/move", s.MoveCategoryHandler)
 	categories.DELETE("/:id", s.DeleteCategoryHandler)
+	categories.POST("/:id/restore", s.RestoreCategoryHandler)
+	categories.GET("/:id/attributes", s.GetAllAttributeHandler)
+	categories.POST("/:id/attributes", s.CreateAttributeHandler)
+	categories.PUT("/:id/attributes/:attributeId", s.UpdateAttributeHandler)
+	categories.DELETE("/:id/attributes/:attributeId", s.DeleteAttributeHandler)
+
+	inventory := admin.Group("/inventory")
+	inventory.Use(AuthByJWT(s.auth, s.c.JwtSecret))
+	inventory.POST("/adjustments", s.CreateInventoryMovementHandler)
+	inventory.GET("/export", s.ExportProductStockHandler)
+
+	priceSchedules := admin.Group("/priceSchedules")
+	priceSchedules.Use(AuthByJWT(s.auth, s.c.JwtSecret))
+	priceSchedules.POST("", s.CreatePriceScheduleHandler)
+	priceSchedules.GET("", s.GetAllPriceScheduleHandler)
+	priceSchedules.GET("/:id", s.GetPriceScheduleHandler)
+	priceSchedules.PUT("/:id", s.UpdatePriceScheduleHandler)
+	priceSchedules.DELETE("/:id", s.DeletePriceScheduleHandler)
+	priceSchedules.POST("/:id/retry", s.RetryPriceScheduleHandler)
+
+	priceHistory := admin.Group("/priceHistory")
+	priceHistory.Use(AuthByJWT(s.auth, s.c.JwtSecret))
+	priceHistory.GET("", s.GetRecentPriceChangesHandler)
+	priceHistory.GET("/export", s.ExportPriceHistoryHandler)
+
+	promotions := admin.Group("/promotions")
+	promotions.Use(AuthByJWT(s.auth, s.c.JwtSecret))
+	promotions.POST("", s.CreatePromotionHandler)
+	promotions.GET("", s.GetAllPromotionHandler)
+	promotions.GET("/:id", s.GetPromotionHandler)
+	promotions.PUT("/:id", s.UpdatePromotionHandler)
+	promotions.DELETE("/:id", s.DeletePromotionHandler)
+
+	priceLists := admin.Group("/priceLists")
+	priceLists.Use(AuthByJWT(s.auth, s.c.JwtSecret))
+	priceLists.POST("", s.CreatePriceListHandler)
+	priceLists.GET("", s.GetAllPriceListHandler)
+	priceLists.GET("/:id", s.GetPriceListHandler)
+	priceLists.PUT("/:id", s.UpdatePriceListHandler)
+	priceLists.DELETE("/:id", s.DeletePriceListHandler)
+	priceLists.GET("/:id/items", s.GetPriceListItemsHandler)
+	priceLists.PUT("/:id/items/:productId", s.SetPriceListItemHandler)
+	priceLists.DELETE("/:id/items/:productId", s.DeletePriceListItemHandler)
+
+	exchangeRates := admin.Group("/exchangeRates")
+	exchangeRates.Use(AuthByJWT(s.auth, s.c.JwtSecret))
+	exchangeRates.GET("", s.GetExchangeRatesHandler)
+	exchangeRates.PUT("", s.UpsertExchangeRateHandler)
+	exchangeRates.DELETE("/:id", s.DeleteExchangeRateHandler)
+	exchangeRates.POST("/import", s.ImportExchangeRatesHandler)
 
-	admin.POST("/inventory/adjustments", s.CreateInventoryMovementHandler).Use(AuthByJWT(s.auth, s.c.JwtSecret))
+	trash := admin.Group("/trash")
+	trash.Use(AuthByJWT(s.auth, s.c.JwtSecret))
+	trash.POST("/purge", s.PurgeTrashHandler)
 
 	return r
 }
@@ -8,14 +8,25 @@ import (
 	"time"
 
 	"github.com/Aoladiy/go-with-tools/gen"
+	"github.com/Aoladiy/go-with-tools/internal/attribute"
 	"github.com/Aoladiy/go-with-tools/internal/auth"
 	"github.com/Aoladiy/go-with-tools/internal/brand"
 	"github.com/Aoladiy/go-with-tools/internal/category"
 	"github.com/Aoladiy/go-with-tools/internal/config"
 	"github.com/Aoladiy/go-with-tools/internal/database"
 	"github.com/Aoladiy/go-with-tools/internal/database/queries"
+	"github.com/Aoladiy/go-with-tools/internal/feed"
+	"github.com/Aoladiy/go-with-tools/internal/inventory"
+	"github.com/Aoladiy/go-with-tools/internal/media"
 	"github.com/Aoladiy/go-with-tools/internal/metrics"
+	"github.com/Aoladiy/go-with-tools/internal/pricehistory"
+	"github.com/Aoladiy/go-with-tools/internal/pricelist"
 	"github.com/Aoladiy/go-with-tools/internal/product"
+	"github.com/Aoladiy/go-with-tools/internal/promotion"
+	"github.com/Aoladiy/go-with-tools/internal/schedule"
+	"github.com/Aoladiy/go-with-tools/internal/slug"
+	"github.com/Aoladiy/go-with-tools/internal/trash"
+	"github.com/Aoladiy/go-with-tools/internal/variant"
 	_ "github.com/joho/godotenv/autoload"
 )
 
@@ -28,6 +39,17 @@ type Server struct {
 	brand         *brand.Service
 	category      *category.Service
 	product       *product.Service
+	variant       *variant.Service
+	attribute     *attribute.Service
+	inventory     *inventory.Service
+	media         *media.Service
+	promotion     *promotion.Service
+	priceHistory  *pricehistory.Service
+	priceList     *pricelist.Service
+	schedule      *schedule.Service
+	trash         *trash.Service
+	feed          *feed.Service
+	slug          *slug.Service
 	auth          gen.AuthMicroserviceClient
 }
 
@@ -36,15 +58,33 @@ func New(c config.Config) *Server {
 	db := database.New(c)
 	pool := db.GetPool()
 	q := queries.New(db.GetPool())
+	mediaService := media.New(q, pool, media.NewLocalStorage(c.MediaDir, c.MediaBaseURL), c.MediaMaxSize)
+	promotionService := promotion.New(q, pool)
+	priceListService := pricelist.New(q, pool)
 	newServer := &Server{
 		c:             c,
 		db:            db,
 		q:             q,
 		metricsServer: metricsServer,
-		brand:         brand.New(q, pool),
-		category:      category.New(q, pool),
-		product:       product.New(q, pool),
-		auth:          auth.NewClient(c),
+		brand:         brand.New(q, pool, mediaService),
+		category:      category.New(q, pool, c.CategoryMaxDepth),
+		product:       product.New(q, pool, mediaService, promotionService, priceListService),
+		variant:       variant.New(q, pool, promotionService, priceListService),
+		attribute:     attribute.New(q, pool),
+		inventory:     inventory.New(q, pool),
+		media:         mediaService,
+		promotion:     promotionService,
+		priceHistory:  pricehistory.New(q, pool),
+		priceList:     priceListService,
+		schedule:      schedule.New(q, pool),
+		trash:         trash.New(q, pool, c.TrashRetention, mediaService),
+		slug:          slug.New(q, pool),
+		feed: feed.New(q, pool, mediaService, promotionService, feed.Shop{
+			Name:    c.ShopName,
+			Company: c.ShopCompany,
+			URL:     c.StorefrontURL,
+		}),
+		auth: auth.NewClient(c),
 	}
 
 	// Declare Server config
@@ -71,6 +111,11 @@ func (s *Server) ShutdownServer(ctx context.Context) error {
 	return nil
 }
 
+// RunPriceScheduler starts applying scheduled price changes in the background until ctx is done.
+func (s *Server) RunPriceScheduler(ctx context.Context) chan bool {
+	return s.schedule.Run(ctx, s.c.PriceSchedulerInterval)
+}
+
 func (s *Server) Serve() {
 	go func() {
 		err := s.metricsServer.ListenAndServe()
@@ -0,0 +1,88 @@
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strings"
+
+	"github.com/Aoladiy/go-with-tools/internal/DTO"
+	"github.com/Aoladiy/go-with-tools/internal/attribute"
+	"github.com/Aoladiy/go-with-tools/internal/errs"
+	"github.com/Aoladiy/go-with-tools/internal/helpers"
+	"github.com/Aoladiy/go-with-tools/internal/money"
+	"github.com/Aoladiy/go-with-tools/internal/slug"
+
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+	"github.com/go-playground/validator/v10/non-standard/validators"
+)
+
+// registerValidations sets up the validator gin checks the binding tags of the requests with: fields
+// are named like in the request, the rules of the catalog are added and merge patch members are
+// checked by their values.
+func registerValidations() {
+	validate, ok := binding.Validator.Engine().(*validator.Validate)
+	if !ok {
+		return
+	}
+	validate.RegisterTagNameFunc(func(field reflect.StructField) string {
+		for _, tag := range []string{"json", "form"} {
+			name, _, _ := strings.Cut(field.Tag.Get(tag), ",")
+			if name != "" && name != "-" {
+				return name
+			}
+		}
+		return ""
+	})
+	_ = validate.RegisterValidation("notblank", validators.NotBlank)
+	_ = validate.RegisterValidation("slug", func(fl validator.FieldLevel) bool {
+		return slug.IsValid(fl.Field().String())
+	})
+	_ = validate.RegisterValidation("currency", func(fl validator.FieldLevel) bool {
+		// the services take currencies in any case, like the decoding of money.Money
+		return money.ValidateCurrency(strings.ToUpper(strings.TrimSpace(fl.Field().String()))) == nil
+	})
+	_ = validate.RegisterValidation("attribute_code", func(fl validator.FieldLevel) bool {
+		return attribute.IsValidCode(fl.Field().String())
+	})
+	validate.RegisterCustomTypeFunc(func(field reflect.Value) any {
+		return field.MethodByName("Present").Call(nil)[0].Interface()
+	}, DTO.Patch[int64]{}, DTO.Patch[string]{}, DTO.Patch[bool]{}, DTO.Patch[DTO.MoneyPatch]{})
+}
+
+// validationErr turns the error of binding a request into the violations of its fields when it is
+// one, any other error means the request can't be read at all.
+func validationErr(err error) *errs.AppError {
+	if violations, ok := helpers.Violations(err); ok {
+		return errs.Validation(violations)
+	}
+	var typeErr *json.UnmarshalTypeError
+	if errors.As(err, &typeErr) && typeErr.Field != "" {
+		return errs.Validation([]errs.Violation{{
+			Field:   typeErr.Field,
+			Rule:    "type",
+			Message: "must be " + jsonType(typeErr.Type),
+		}})
+	}
+	return errs.BadRequest(err)
+}
+
+// jsonType names the JSON type a value of the Go type is decoded from.
+func jsonType(typ reflect.Type) string {
+	switch typ.Kind() {
+	case reflect.String:
+		return "a string"
+	case reflect.Bool:
+		return "a boolean"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return "an integer"
+	case reflect.Float32, reflect.Float64:
+		return "a number"
+	case reflect.Slice, reflect.Array:
+		return "an array"
+	default:
+		return "an object"
+	}
+}
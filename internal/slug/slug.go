@@ -0,0 +1,206 @@
+package slug
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/Aoladiy/go-with-tools/internal/DTO"
+	"github.com/Aoladiy/go-with-tools/internal/database/queries"
+	"github.com/Aoladiy/go-with-tools/internal/errs"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// Kind is the table a slug belongs to, the slugs of every kind are unique on their own.
+type Kind string
+
+const (
+	Brands     Kind = "brands"
+	Categories Kind = "categories"
+	Products   Kind = "products"
+)
+
+// maxLength is the length generated slugs are cut to before a de-duplication suffix is added.
+const maxLength = 100
+
+// slugRegexp matches what Generate makes: lowercase latin letters and digits separated by single dashes.
+var slugRegexp = regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)
+
+var transliteration = map[rune]string{
+	'а': "a", 'б': "b", 'в': "v", 'г': "g", 'д': "d", 'е': "e", 'ё': "e", 'ж': "zh",
+	'з': "z", 'и': "i", 'й': "y", 'к': "k", 'л': "l", 'м': "m", 'н': "n", 'о': "o",
+	'п': "p", 'р': "r", 'с': "s", 'т': "t", 'у': "u", 'ф': "f", 'х': "kh", 'ц': "ts",
+	'ч': "ch", 'ш': "sh", 'щ': "shch", 'ъ': "", 'ы': "y", 'ь': "", 'э': "e", 'ю': "yu",
+	'я': "ya", 'і': "i", 'ї': "yi", 'є': "ye", 'ґ': "g",
+}
+
+type Service struct {
+	q *queries.Queries
+	p *pgxpool.Pool
+}
+
+func New(q *queries.Queries, p *pgxpool.Pool) *Service {
+	return &Service{q: q, p: p}
+}
+
+// ParseKind checks the kind taken from a request.
+func ParseKind(kind string) (Kind, *errs.AppError) {
+	switch Kind(kind) {
+	case Brands, Categories, Products:
+		return Kind(kind), nil
+	default:
+		return "", errs.BadRequest(fmt.Errorf("unknown slug kind %q, expected one of %s, %s, %s", kind, Brands, Categories, Products))
+	}
+}
+
+// IsValid reports whether the slug looks like a generated one, the requested slugs have to.
+func IsValid(slug string) bool {
+	return slugRegexp.MatchString(slug)
+}
+
+// Generate makes a slug of the name: cyrillic letters are transliterated to latin, everything
+// but latin letters and digits becomes a dash. The result is empty if nothing is left of the name.
+func Generate(name string) string {
+	var builder strings.Builder
+	dash := false
+	for _, r := range strings.ToLower(name) {
+		latin, ok := transliteration[r]
+		switch {
+		case ok:
+		case r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r)):
+			latin = string(r)
+		default:
+			dash = builder.Len() > 0
+			continue
+		}
+		if latin == "" {
+			continue
+		}
+		if dash {
+			builder.WriteByte('-')
+			dash = false
+		}
+		builder.WriteString(latin)
+	}
+
+	slug := builder.String()
+	if len(slug) > maxLength {
+		slug = strings.TrimRight(slug[:maxLength], "-")
+	}
+	return slug
+}
+
+// Assign returns the slug to store for the record with the id, 0 for a new one. It is the requested
+// slug or the one generated from the name when none is requested, followed by the first free "-2",
+// "-3"... suffix if another record of the kind, trashed ones included, already has it. The slug is
+// dropped from the history of the kind, the current slugs always win over the old ones.
+func Assign(ctx context.Context, q *queries.Queries, kind Kind, id int64, requested, name string) (string, *errs.AppError) {
+	slug := strings.TrimSpace(requested)
+	if slug == "" {
+		slug = Generate(name)
+	}
+	if slug == "" {
+		return "", errs.BadRequest(errors.New("slug can't be generated from the name, set it explicitly"))
+	}
+
+	var (
+		taken []string
+		err   error
+	)
+	switch kind {
+	case Brands:
+		taken, err = q.GetTakenBrandSlugs(ctx, queries.GetTakenBrandSlugsParams{ID: id, Slug: slug})
+	case Categories:
+		taken, err = q.GetTakenCategorySlugs(ctx, queries.GetTakenCategorySlugsParams{ID: id, Slug: slug})
+	case Products:
+		taken, err = q.GetTakenProductSlugs(ctx, queries.GetTakenProductSlugsParams{ID: id, Slug: slug})
+	}
+	if err != nil {
+		return "", errs.Internal(err)
+	}
+	slug = free(slug, taken)
+
+	switch kind {
+	case Brands:
+		err = q.DeleteBrandSlugHistory(ctx, slug)
+	case Categories:
+		err = q.DeleteCategorySlugHistory(ctx, slug)
+	case Products:
+		err = q.DeleteProductSlugHistory(ctx, slug)
+	}
+	if err != nil {
+		return "", errs.Internal(err)
+	}
+	return slug, nil
+}
+
+// free returns the slug itself or with the lowest suffix that isn't taken.
+func free(slug string, taken []string) string {
+	used := make(map[string]bool, len(taken))
+	for _, t := range taken {
+		used[t] = true
+	}
+	candidate := slug
+	for n := 2; used[candidate]; n++ {
+		candidate = slug + "-" + strconv.Itoa(n)
+	}
+	return candidate
+}
+
+// Record keeps the old slug of the record in the history after it was changed, so it still resolves.
+func Record(ctx context.Context, q *queries.Queries, kind Kind, id int64, oldSlug, newSlug string) *errs.AppError {
+	if oldSlug == newSlug {
+		return nil
+	}
+	params := queries.CreateSlugHistoryParams{Slug: oldSlug}
+	switch kind {
+	case Brands:
+		params.BrandID = &id
+	case Categories:
+		params.CategoryID = &id
+	case Products:
+		params.ProductID = &id
+	}
+	err := q.CreateSlugHistory(ctx, params)
+	if err != nil {
+		return errs.FromPgErr(err)
+	}
+	return nil
+}
+
+// Resolve finds the record of the kind shown on the storefront by its current or old slug.
+// The slug of the response is the current one, it differs from the requested slug for an old one.
+func (s *Service) Resolve(ctx context.Context, kind Kind, slug string) (DTO.SlugResponse, *errs.AppError) {
+	var (
+		id      int64
+		current string
+		err     error
+	)
+	switch kind {
+	case Brands:
+		var row queries.ResolveBrandSlugRow
+		row, err = s.q.ResolveBrandSlug(ctx, slug)
+		id, current = row.ID, row.Slug
+	case Categories:
+		var row queries.ResolveCategorySlugRow
+		row, err = s.q.ResolveCategorySlug(ctx, slug)
+		id, current = row.ID, row.Slug
+	case Products:
+		var row queries.ResolveProductSlugRow
+		row, err = s.q.ResolveProductSlug(ctx, slug)
+		id, current = row.ID, row.Slug
+	}
+	if err != nil {
+		appErr := errs.FromPgErr(err)
+		if appErr.Code == errs.NotFoundErrCode {
+			return DTO.SlugResponse{}, errs.NotFound(fmt.Errorf("%s with slug %q not found | %w", kind, slug, err))
+		}
+		return DTO.SlugResponse{}, appErr
+	}
+	return DTO.SlugResponse{Kind: string(kind), Id: id, Slug: current}, nil
+}
@@ -0,0 +1,84 @@
+package trash
+
+import (
+	"context"
+	"time"
+
+	"github.com/Aoladiy/go-with-tools/internal/DTO"
+	"github.com/Aoladiy/go-with-tools/internal/database/queries"
+	"github.com/Aoladiy/go-with-tools/internal/errs"
+	"github.com/Aoladiy/go-with-tools/internal/helpers"
+	"github.com/Aoladiy/go-with-tools/internal/media"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// purgeTimeout is how long a purge may take, it deletes everything past the retention at once.
+const purgeTimeout = time.Minute
+
+type Service struct {
+	q         *queries.Queries
+	p         *pgxpool.Pool
+	retention time.Duration
+	media     *media.Service
+}
+
+func New(q *queries.Queries, p *pgxpool.Pool, retention time.Duration, media *media.Service) *Service {
+	return &Service{q: q, p: p, retention: retention, media: media}
+}
+
+// Purge hard-deletes everything that has been in the trash longer than the retention window.
+// Rows referencing the purged products go first so the restricting foreign keys hold, and
+// brands and categories still referenced by a product that isn't purged yet are kept. Categories
+// go leaves first, one level at a time, so a category is kept while any child of it is, and a child
+// still in the window is never turned into a root by the purge of its parent.
+// Files of the purged media are removed from the storage only after the commit.
+func (s *Service) Purge(ctx context.Context) (DTO.PurgeResponse, *errs.AppError) {
+	response := DTO.PurgeResponse{DeletedBefore: time.Now().Add(-s.retention)}
+	var purgedMedia []queries.PurgeMediaRow
+	appErr := helpers.WithTxTimeout(ctx, s.p, s.q, purgeTimeout, func(timeout context.Context, q *queries.Queries) *errs.AppError {
+		var err error
+		purgedMedia, err = q.PurgeMedia(timeout, response.DeletedBefore)
+		if err != nil {
+			return errs.Internal(err)
+		}
+		response.Media = int64(len(purgedMedia))
+		response.InventoryMovements, err = q.PurgeInventoryMovements(timeout, response.DeletedBefore)
+		if err != nil {
+			return errs.Internal(err)
+		}
+		response.PriceHistory, err = q.PurgeProductPriceHistory(timeout, response.DeletedBefore)
+		if err != nil {
+			return errs.Internal(err)
+		}
+		response.Variants, err = q.PurgeProductVariants(timeout, response.DeletedBefore)
+		if err != nil {
+			return errs.Internal(err)
+		}
+		response.Products, err = q.PurgeProducts(timeout, response.DeletedBefore)
+		if err != nil {
+			return errs.Internal(err)
+		}
+		for {
+			purged, err := q.PurgeCategories(timeout, response.DeletedBefore)
+			if err != nil {
+				return errs.Internal(err)
+			}
+			if purged == 0 {
+				break
+			}
+			response.Categories += purged
+		}
+		response.Brands, err = q.PurgeBrands(timeout, response.DeletedBefore)
+		if err != nil {
+			return errs.Internal(err)
+		}
+		return nil
+	})
+	if appErr != nil {
+		return DTO.PurgeResponse{}, appErr
+	}
+
+	s.media.DeletePurgedFiles(ctx, purgedMedia)
+	return response, nil
+}
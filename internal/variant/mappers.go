@@ -0,0 +1,104 @@
+package variant
+
+import (
+	"github.com/Aoladiy/go-with-tools/internal/DTO"
+	"github.com/Aoladiy/go-with-tools/internal/database/queries"
+	"github.com/Aoladiy/go-with-tools/internal/helpers"
+	"github.com/Aoladiy/go-with-tools/internal/money"
+)
+
+func mapRequestToCreateParams(productId int64, request DTO.VariantRequest) (queries.CreateProductVariantParams, error) {
+	key, err := optionsKey(request.Options)
+	if err != nil {
+		return queries.CreateProductVariantParams{}, err
+	}
+	return queries.CreateProductVariantParams{
+		ProductID:   productId,
+		Sku:         request.Sku,
+		Barcode:     request.Barcode,
+		PriceKopeck: amountOf(request.Price),
+		IsActive:    helpers.DerefBool(request.IsActive, true),
+		OptionsKey:  key,
+	}, nil
+}
+
+func mapRequestToUpdateParams(productId, id int64, request DTO.VariantRequest) (queries.UpdateProductVariantParams, error) {
+	key, err := optionsKey(request.Options)
+	if err != nil {
+		return queries.UpdateProductVariantParams{}, err
+	}
+	return queries.UpdateProductVariantParams{
+		ID:          id,
+		ProductID:   productId,
+		Sku:         request.Sku,
+		Barcode:     request.Barcode,
+		PriceKopeck: amountOf(request.Price),
+		IsActive:    helpers.DerefBool(request.IsActive, true),
+		OptionsKey:  key,
+	}, nil
+}
+
+func mapGetRowToResponse(variant queries.GetProductVariantRow, options map[string]string) DTO.VariantResponse {
+	return DTO.VariantResponse{
+		Id:             variant.ID,
+		ProductId:      variant.ProductID,
+		Sku:            variant.Sku,
+		Barcode:        variant.Barcode,
+		Price:          moneyOf(variant.PriceKopeck, variant.Currency),
+		EffectivePrice: money.New(variant.EffectivePriceKopeck, variant.Currency),
+		IsActive:       variant.IsActive,
+		Stock:          variant.Stock,
+		Options:        nonNilOptions(options),
+		CreatedAt:      variant.CreatedAt,
+		UpdatedAt:      variant.UpdatedAt,
+	}
+}
+
+func mapGetAllRowToResponse(variant queries.GetProductVariantsRow, options map[string]string) DTO.VariantResponse {
+	return DTO.VariantResponse{
+		Id:             variant.ID,
+		ProductId:      variant.ProductID,
+		Sku:            variant.Sku,
+		Barcode:        variant.Barcode,
+		Price:          moneyOf(variant.PriceKopeck, variant.Currency),
+		EffectivePrice: money.New(variant.EffectivePriceKopeck, variant.Currency),
+		IsActive:       variant.IsActive,
+		Stock:          variant.Stock,
+		Options:        nonNilOptions(options),
+		CreatedAt:      variant.CreatedAt,
+		UpdatedAt:      variant.UpdatedAt,
+	}
+}
+
+func mapFrontRowToFrontResponse(variant queries.GetFrontProductVariantsRow, options map[string]string) DTO.FrontVariantResponse {
+	return DTO.FrontVariantResponse{
+		Id:      variant.ID,
+		Sku:     variant.Sku,
+		Price:   money.New(variant.PriceKopeck, variant.Currency),
+		InStock: variant.Stock > 0,
+		Options: nonNilOptions(options),
+	}
+}
+
+// amountOf returns the amount of an optional price override, the currency is checked against the product.
+func amountOf(price *money.Money) *int64 {
+	if price == nil {
+		return nil
+	}
+	return &price.Amount
+}
+
+func moneyOf(amount *int64, currency string) *money.Money {
+	if amount == nil {
+		return nil
+	}
+	price := money.New(*amount, currency)
+	return &price
+}
+
+func nonNilOptions(options map[string]string) map[string]string {
+	if options == nil {
+		return map[string]string{}
+	}
+	return options
+}
@@ -0,0 +1,443 @@
+package variant
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/Aoladiy/go-with-tools/internal/DTO"
+	"github.com/Aoladiy/go-with-tools/internal/database/queries"
+	"github.com/Aoladiy/go-with-tools/internal/errs"
+	"github.com/Aoladiy/go-with-tools/internal/helpers"
+	"github.com/Aoladiy/go-with-tools/internal/money"
+	"github.com/Aoladiy/go-with-tools/internal/pricelist"
+	"github.com/Aoladiy/go-with-tools/internal/promotion"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type Service struct {
+	q         *queries.Queries
+	p         *pgxpool.Pool
+	promotion *promotion.Service
+	pricelist *pricelist.Service
+}
+
+func New(q *queries.Queries, p *pgxpool.Pool, promotion *promotion.Service, pricelist *pricelist.Service) *Service {
+	return &Service{q: q, p: p, promotion: promotion, pricelist: pricelist}
+}
+
+func (s *Service) Create(ctx context.Context, productId int64, request DTO.VariantRequest) (DTO.VariantResponse, *errs.AppError) {
+	request, appErr := normalizeRequest(request)
+	if appErr != nil {
+		return DTO.VariantResponse{}, appErr
+	}
+
+	var id int64
+	appErr = helpers.WithTx(ctx, s.p, s.q, func(timeout context.Context, q *queries.Queries) *errs.AppError {
+		product, appErr := lockProduct(timeout, q, productId)
+		if appErr != nil {
+			return appErr
+		}
+		if request.Price != nil && request.Price.Currency != product.Currency {
+			return errs.UnprocessableEntity(fmt.Errorf("product with id=%d is priced in %s, so must be its variants", productId, product.Currency))
+		}
+		appErr = validateOptions(timeout, q, productId, 0, request.Options)
+		if appErr != nil {
+			return appErr
+		}
+		appErr = validateOwnStock(timeout, q, productId)
+		if appErr != nil {
+			return appErr
+		}
+
+		params, err := mapRequestToCreateParams(productId, request)
+		if err != nil {
+			return errs.Internal(err)
+		}
+		id, err = q.CreateProductVariant(timeout, params)
+		if err != nil {
+			return errs.FromPgErr(err)
+		}
+		appErr = createOptionValues(timeout, q, id, request.Options)
+		if appErr != nil {
+			return appErr
+		}
+
+		if request.Price != nil && request.Price.Amount != product.PriceKopeck {
+			return createPriceHistory(timeout, q, productId, id, money.New(product.PriceKopeck, product.Currency), *request.Price)
+		}
+		return nil
+	})
+	if appErr != nil {
+		return DTO.VariantResponse{}, appErr
+	}
+
+	return s.Get(ctx, productId, id)
+}
+
+func (s *Service) GetAll(ctx context.Context, productId int64) ([]DTO.VariantResponse, *errs.AppError) {
+	_, err := s.q.GetProduct(ctx, productId)
+	if err != nil {
+		return nil, errs.FromPgErr(err)
+	}
+
+	variants, err := s.q.GetProductVariants(ctx, productId)
+	if err != nil {
+		return nil, errs.Internal(err)
+	}
+	options, appErr := s.getOptions(ctx, productId)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	variantsResponse := make([]DTO.VariantResponse, len(variants))
+	for i, variant := range variants {
+		variantsResponse[i] = mapGetAllRowToResponse(variant, options[variant.ID])
+	}
+	return variantsResponse, nil
+}
+
+func (s *Service) Get(ctx context.Context, productId, id int64) (DTO.VariantResponse, *errs.AppError) {
+	variant, err := s.q.GetProductVariant(ctx, queries.GetProductVariantParams{ID: id, ProductID: productId})
+	if err != nil {
+		return DTO.VariantResponse{}, errs.FromPgErr(err)
+	}
+	options, appErr := s.getOptions(ctx, productId)
+	if appErr != nil {
+		return DTO.VariantResponse{}, appErr
+	}
+
+	return mapGetRowToResponse(variant, options[variant.ID]), nil
+}
+
+func (s *Service) Update(ctx context.Context, productId, id int64, request DTO.VariantRequest) (DTO.VariantResponse, *errs.AppError) {
+	request, appErr := normalizeRequest(request)
+	if appErr != nil {
+		return DTO.VariantResponse{}, appErr
+	}
+
+	appErr = helpers.WithTx(ctx, s.p, s.q, func(timeout context.Context, q *queries.Queries) *errs.AppError {
+		product, appErr := lockProduct(timeout, q, productId)
+		if appErr != nil {
+			return appErr
+		}
+		if request.Price != nil && request.Price.Currency != product.Currency {
+			return errs.UnprocessableEntity(fmt.Errorf("product with id=%d is priced in %s, so must be its variants", productId, product.Currency))
+		}
+		oldVariant, err := q.LockProductVariant(timeout, queries.LockProductVariantParams{ID: id, ProductID: productId})
+		if err != nil {
+			return errs.FromPgErr(err)
+		}
+		appErr = validateOptions(timeout, q, productId, id, request.Options)
+		if appErr != nil {
+			return appErr
+		}
+
+		params, err := mapRequestToUpdateParams(productId, id, request)
+		if err != nil {
+			return errs.Internal(err)
+		}
+		_, err = q.UpdateProductVariant(timeout, params)
+		if err != nil {
+			return errs.FromPgErr(err)
+		}
+		err = q.DeleteVariantOptionValues(timeout, id)
+		if err != nil {
+			return errs.Internal(err)
+		}
+		appErr = createOptionValues(timeout, q, id, request.Options)
+		if appErr != nil {
+			return appErr
+		}
+
+		oldPrice := effectivePrice(oldVariant.PriceKopeck, product.PriceKopeck)
+		newPrice := effectivePrice(amountOf(request.Price), product.PriceKopeck)
+		if oldPrice != newPrice {
+			return createPriceHistory(timeout, q, productId, id, money.New(oldPrice, product.Currency), money.New(newPrice, product.Currency))
+		}
+		return nil
+	})
+	if appErr != nil {
+		return DTO.VariantResponse{}, appErr
+	}
+
+	return s.Get(ctx, productId, id)
+}
+
+// Delete soft-deletes a variant without stock. Its movements stay in the stock of the product,
+// so the stock has to be moved out of it first.
+func (s *Service) Delete(ctx context.Context, productId, id int64) (int, *errs.AppError) {
+	var rows int64
+	appErr := helpers.WithTx(ctx, s.p, s.q, func(timeout context.Context, q *queries.Queries) *errs.AppError {
+		// the product row lock serializes the delete with the inventory movements of the variant
+		_, appErr := lockProduct(timeout, q, productId)
+		if appErr != nil {
+			return appErr
+		}
+		_, err := q.LockProductVariant(timeout, queries.LockProductVariantParams{ID: id, ProductID: productId})
+		if err != nil {
+			appErr := errs.FromPgErr(err)
+			if appErr.Code == errs.NotFoundErrCode {
+				return errs.NotFound(errors.New("variant not found"))
+			}
+			return appErr
+		}
+		stock, err := q.GetVariantStock(timeout, &id)
+		if err != nil {
+			return errs.Internal(err)
+		}
+		if stock != 0 {
+			return errs.UnprocessableEntity(fmt.Errorf("variant with id=%d has %d in stock, adjust it to zero first", id, stock))
+		}
+
+		rows, err = q.DeleteProductVariant(timeout, queries.DeleteProductVariantParams{ID: id, ProductID: productId})
+		if err != nil {
+			return errs.Internal(err)
+		}
+		return nil
+	})
+	if appErr != nil {
+		return 0, appErr
+	}
+	return int(rows), nil
+}
+
+// GetFrontMatrix returns the option types of an active product with all values in use
+// and its active variants, so the storefront can render a size/color picker. In a price list
+// variants without their own price get the list price of the product, the others are converted.
+func (s *Service) GetFrontMatrix(ctx context.Context, productId int64, query DTO.PriceListQuery) (DTO.FrontVariantMatrixResponse, *errs.AppError) {
+	_, err := s.q.GetFrontProduct(ctx, productId)
+	if err != nil {
+		return DTO.FrontVariantMatrixResponse{}, errs.FromPgErr(err)
+	}
+
+	variants, err := s.q.GetFrontProductVariants(ctx, productId)
+	if err != nil {
+		return DTO.FrontVariantMatrixResponse{}, errs.Internal(err)
+	}
+	options, appErr := s.getOptions(ctx, productId)
+	if appErr != nil {
+		return DTO.FrontVariantMatrixResponse{}, appErr
+	}
+	pricer, appErr := s.pricelist.Pricer(ctx, query, []int64{productId})
+	if appErr != nil {
+		return DTO.FrontVariantMatrixResponse{}, appErr
+	}
+	rules, appErr := s.promotion.Rules(ctx, []int64{productId})
+	if appErr != nil {
+		return DTO.FrontVariantMatrixResponse{}, appErr
+	}
+
+	response := DTO.FrontVariantMatrixResponse{
+		ProductId: productId,
+		Options:   []DTO.FrontOptionResponse{},
+		Variants:  make([]DTO.FrontVariantResponse, len(variants)),
+	}
+	values := make(map[string][]string)
+	for i, variant := range variants {
+		response.Variants[i] = mapFrontRowToFrontResponse(variant, options[variant.ID])
+		if pricer != nil {
+			if variant.HasOwnPrice {
+				response.Variants[i].Price, appErr = pricer.Convert(response.Variants[i].Price)
+			} else {
+				response.Variants[i].Price, appErr = pricer.Price(productId, response.Variants[i].Price)
+			}
+			if appErr != nil {
+				return DTO.FrontVariantMatrixResponse{}, appErr
+			}
+		}
+		resolved := promotion.Resolve(response.Variants[i].Price, rules[productId])
+		response.Variants[i].EffectivePrice = resolved.EffectivePrice
+		response.Variants[i].AppliedPromotions = resolved.AppliedPromotions
+		for name, value := range options[variant.ID] {
+			if !slices.Contains(values[name], value) {
+				values[name] = append(values[name], value)
+			}
+		}
+	}
+	for name := range values {
+		slices.Sort(values[name])
+		response.Options = append(response.Options, DTO.FrontOptionResponse{Name: name, Values: values[name]})
+	}
+	slices.SortFunc(response.Options, func(a, b DTO.FrontOptionResponse) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+	return response, nil
+}
+
+// getOptions returns option values of every variant of the product keyed by variant id.
+func (s *Service) getOptions(ctx context.Context, productId int64) (map[int64]map[string]string, *errs.AppError) {
+	values, err := s.q.GetVariantOptionValuesByProductId(ctx, productId)
+	if err != nil {
+		return nil, errs.Internal(err)
+	}
+
+	options := make(map[int64]map[string]string)
+	for _, value := range values {
+		if options[value.VariantID] == nil {
+			options[value.VariantID] = make(map[string]string)
+		}
+		options[value.VariantID][value.Name] = value.Value
+	}
+	return options, nil
+}
+
+func normalizeRequest(request DTO.VariantRequest) (DTO.VariantRequest, *errs.AppError) {
+	request.Sku = strings.TrimSpace(request.Sku)
+	if request.Sku == "" {
+		return DTO.VariantRequest{}, errs.BadRequest(errors.New("sku must not be empty"))
+	}
+	if request.Barcode != nil {
+		barcode := strings.TrimSpace(*request.Barcode)
+		if barcode == "" {
+			return DTO.VariantRequest{}, errs.BadRequest(errors.New("barcode must not be empty"))
+		}
+		request.Barcode = &barcode
+	}
+	if request.Price != nil {
+		err := request.Price.Validate()
+		if err != nil {
+			return DTO.VariantRequest{}, errs.BadRequest(fmt.Errorf("price: %w", err))
+		}
+	}
+
+	options := make(map[string]string, len(request.Options))
+	for name, value := range request.Options {
+		name, value = strings.TrimSpace(name), strings.TrimSpace(value)
+		if name == "" || value == "" {
+			return DTO.VariantRequest{}, errs.BadRequest(errors.New("option names and values must not be empty"))
+		}
+		if _, ok := options[name]; ok {
+			return DTO.VariantRequest{}, errs.BadRequest(fmt.Errorf("option %q is set twice", name))
+		}
+		options[name] = value
+	}
+	request.Options = options
+	return request, nil
+}
+
+func lockProduct(timeout context.Context, q *queries.Queries, productId int64) (queries.GetProductRow, *errs.AppError) {
+	// the product row lock serializes variant changes, so the option set check holds until commit
+	_, err := q.LockProduct(timeout, productId)
+	if err != nil {
+		appErr := errs.FromPgErr(err)
+		if appErr.Code == errs.NotFoundErrCode {
+			return queries.GetProductRow{}, errs.NotFound(fmt.Errorf("product with id=%d not found | %w", productId, err))
+		}
+		return queries.GetProductRow{}, appErr
+	}
+	product, err := q.GetProduct(timeout, productId)
+	if err != nil {
+		return queries.GetProductRow{}, errs.FromPgErr(err)
+	}
+	return product, nil
+}
+
+// validateOwnStock makes sure the first variant isn't created while the product has stock of its own.
+// Products with variants take movements of their variants only, so that stock couldn't be adjusted any more.
+func validateOwnStock(timeout context.Context, q *queries.Queries, productId int64) *errs.AppError {
+	variants, err := q.CountProductVariants(timeout, queries.CountProductVariantsParams{ProductID: productId})
+	if err != nil {
+		return errs.Internal(err)
+	}
+	if variants > 0 {
+		return nil
+	}
+	stock, err := q.GetProductOwnStock(timeout, productId)
+	if err != nil {
+		return errs.Internal(err)
+	}
+	if stock != 0 {
+		return errs.UnprocessableEntity(fmt.Errorf("product with id=%d has %d in stock without a variant, adjust it to zero first", productId, stock))
+	}
+	return nil
+}
+
+// validateOptions makes sure all variants of a product are described by the same option types,
+// otherwise the storefront matrix can't be built. excludeId is the variant being updated.
+func validateOptions(timeout context.Context, q *queries.Queries, productId, excludeId int64, options map[string]string) *errs.AppError {
+	others, err := q.CountProductVariants(timeout, queries.CountProductVariantsParams{ProductID: productId, ExcludeID: excludeId})
+	if err != nil {
+		return errs.Internal(err)
+	}
+	if others == 0 {
+		return nil
+	}
+
+	expected, err := q.GetProductOptionTypeNames(timeout, queries.GetProductOptionTypeNamesParams{ProductID: productId, ExcludeID: excludeId})
+	if err != nil {
+		return errs.Internal(err)
+	}
+	names := optionNames(options)
+	if !slices.Equal(names, expected) {
+		return errs.UnprocessableEntity(fmt.Errorf("variants of product with id=%d must have options %v, got %v", productId, expected, names))
+	}
+	return nil
+}
+
+func createOptionValues(timeout context.Context, q *queries.Queries, variantId int64, options map[string]string) *errs.AppError {
+	for _, name := range optionNames(options) {
+		optionTypeId, err := q.UpsertOptionType(timeout, name)
+		if err != nil {
+			return errs.Internal(err)
+		}
+		err = q.CreateVariantOptionValue(timeout, queries.CreateVariantOptionValueParams{
+			VariantID:    variantId,
+			OptionTypeID: optionTypeId,
+			Value:        options[name],
+		})
+		if err != nil {
+			return errs.FromPgErr(err)
+		}
+	}
+	return nil
+}
+
+func createPriceHistory(timeout context.Context, q *queries.Queries, productId, variantId int64, oldPrice, newPrice money.Money) *errs.AppError {
+	id, err := helpers.SafeGetUserID(timeout)
+	if err != nil {
+		return errs.Internal(err)
+	}
+	_, err = q.CreateProductPriceHistory(timeout, queries.CreateProductPriceHistoryParams{
+		ProductID:      productId,
+		VariantID:      &variantId,
+		OldPriceKopeck: oldPrice.Amount,
+		NewPriceKopeck: newPrice.Amount,
+		Currency:       newPrice.Currency,
+		UpdatedBy:      id,
+	})
+	if err != nil {
+		return errs.Internal(err)
+	}
+	return nil
+}
+
+func optionNames(options map[string]string) []string {
+	names := make([]string, 0, len(options))
+	for name := range options {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// optionsKey identifies the option combination of a variant. json.Marshal sorts map keys,
+// so equal combinations always produce equal keys.
+func optionsKey(options map[string]string) (string, error) {
+	key, err := json.Marshal(options)
+	if err != nil {
+		return "", err
+	}
+	return string(key), nil
+}
+
+func effectivePrice(override *int64, productPrice int64) int64 {
+	if override != nil {
+		return *override
+	}
+	return productPrice
+}